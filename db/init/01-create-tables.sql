@@ -1,38 +1,71 @@
 -- Create tables for Wordle game
 
+-- Players table (optional, for future user management)
+CREATE TABLE IF NOT EXISTS players (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    username VARCHAR(50) UNIQUE,
+    email VARCHAR(255) UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    games_played INTEGER DEFAULT 0,
+    games_won INTEGER DEFAULT 0,
+    current_streak INTEGER DEFAULT 0,
+    max_streak INTEGER DEFAULT 0
+);
+
 -- Games table to store individual game sessions
 CREATE TABLE IF NOT EXISTS games (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    target_word VARCHAR(5) NOT NULL,
+    target_word VARCHAR(16) NOT NULL, -- Anagram games may use lengths other than 5
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     completed_at TIMESTAMP WITH TIME ZONE,
     is_completed BOOLEAN DEFAULT FALSE,
     is_won BOOLEAN DEFAULT FALSE,
     guess_count INTEGER DEFAULT 0,
-    max_guesses INTEGER DEFAULT 6
+    max_guesses INTEGER DEFAULT 6,
+    daily_date DATE, -- Set for daily games; NULL for practice games
+    client_ip VARCHAR(45), -- Originating client IP, only when RECORD_CLIENT_INFO is enabled
+    user_agent VARCHAR(512), -- Originating user agent, only when RECORD_CLIENT_INFO is enabled
+    is_tutorial BOOLEAN NOT NULL DEFAULT FALSE, -- Scripted onboarding game
+    scramble VARCHAR(16) NOT NULL DEFAULT '', -- Shuffled target letters for anagram games; empty otherwise
+    mode VARCHAR(16) NOT NULL DEFAULT 'practice' CHECK (mode IN ('practice', 'daily', 'challenge')),
+    player_id UUID REFERENCES players(id) ON DELETE CASCADE, -- Owner of a per-player daily game; NULL otherwise
+    hints_used INTEGER NOT NULL DEFAULT 0,
+    max_hints INTEGER, -- Per-game hint limit; NULL uses MAX_HINTS_PER_GAME
+    keyboard_state JSONB NOT NULL DEFAULT '{}', -- Best status per guessed letter, maintained on each guess
+    boards JSONB NOT NULL DEFAULT '[]' -- Targets and solved guess numbers of a multi-board game; empty otherwise
 );
 
 -- Guesses table to store individual guesses for each game
 CREATE TABLE IF NOT EXISTS guesses (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
-    guess_word VARCHAR(5) NOT NULL,
+    guess_word VARCHAR(16) NOT NULL,
     guess_number INTEGER NOT NULL,
     result JSONB NOT NULL, -- Store the result as JSON (correct, present, absent for each letter)
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     UNIQUE(game_id, guess_number)
 );
 
--- Players table (optional, for future user management)
-CREATE TABLE IF NOT EXISTS players (
-    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    username VARCHAR(50) UNIQUE,
-    email VARCHAR(255) UNIQUE,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    games_played INTEGER DEFAULT 0,
-    games_won INTEGER DEFAULT 0,
-    current_streak INTEGER DEFAULT 0,
-    max_streak INTEGER DEFAULT 0
+-- Answer schedule mapping each daily puzzle date to a pre-assigned target word
+CREATE TABLE IF NOT EXISTS answers (
+    puzzle_date DATE PRIMARY KEY,
+    target_word VARCHAR(16) NOT NULL, -- Anagram games may use lengths other than 5
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- API keys for multi-tenant hosting, each with a daily game-creation quota
+CREATE TABLE IF NOT EXISTS api_keys (
+    api_key VARCHAR(128) PRIMARY KEY,
+    daily_game_quota INTEGER NOT NULL CHECK (daily_game_quota >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Games created per API key per UTC day, checked against the key's quota
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key VARCHAR(128) NOT NULL REFERENCES api_keys(api_key) ON DELETE CASCADE,
+    usage_date DATE NOT NULL,
+    games_created INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key, usage_date)
 );
 
 -- Game statistics (optional, for analytics)
@@ -48,6 +81,9 @@ CREATE TABLE IF NOT EXISTS game_stats (
 -- Create indexes for better query performance
 CREATE INDEX IF NOT EXISTS idx_games_created_at ON games(created_at);
 CREATE INDEX IF NOT EXISTS idx_games_target_word ON games(target_word);
+CREATE INDEX IF NOT EXISTS idx_games_mode_created_at ON games(mode, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_games_daily_date ON games(daily_date) WHERE daily_date IS NOT NULL AND player_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_games_player_daily_date ON games(player_id, daily_date) WHERE mode = 'daily' AND player_id IS NOT NULL;
 CREATE INDEX IF NOT EXISTS idx_guesses_game_id ON guesses(game_id);
 CREATE INDEX IF NOT EXISTS idx_guesses_created_at ON guesses(created_at);
 CREATE INDEX IF NOT EXISTS idx_players_username ON players(username);
@@ -1,10 +1,13 @@
 -- Seed data for Wordle database
 
 -- Insert some sample games for testing
-INSERT INTO games (id, target_word, created_at, completed_at, is_completed, is_won, guess_count) VALUES
-    ('550e8400-e29b-41d4-a716-446655440001', 'HELLO', NOW() - INTERVAL '2 days', NOW() - INTERVAL '2 days', true, true, 4),
-    ('550e8400-e29b-41d4-a716-446655440002', 'WORLD', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day', true, false, 6),
-    ('550e8400-e29b-41d4-a716-446655440003', 'APPLE', NOW() - INTERVAL '1 hour', NULL, false, false, 2);
+INSERT INTO games (id, target_word, created_at, completed_at, is_completed, is_won, guess_count, keyboard_state) VALUES
+    ('550e8400-e29b-41d4-a716-446655440001', 'HELLO', NOW() - INTERVAL '2 days', NOW() - INTERVAL '2 days', true, true, 4,
+     '{"A":"absent","C":"absent","E":"correct","H":"correct","I":"absent","L":"correct","M":"absent","N":"absent","O":"correct","R":"absent","S":"absent"}'),
+    ('550e8400-e29b-41d4-a716-446655440002', 'WORLD', NOW() - INTERVAL '1 day', NOW() - INTERVAL '1 day', true, false, 6,
+     '{"A":"absent","C":"absent","D":"present","E":"absent","G":"absent","L":"present","N":"absent","O":"correct","P":"absent","R":"correct","S":"absent","T":"absent","U":"absent","W":"correct"}'),
+    ('550e8400-e29b-41d4-a716-446655440003', 'APPLE', NOW() - INTERVAL '1 hour', NULL, false, false, 2,
+     '{"A":"present","C":"absent","E":"present","L":"correct","N":"absent","P":"correct","R":"absent","S":"absent"}');
 
 -- Insert corresponding guesses
 INSERT INTO guesses (game_id, guess_word, guess_number, result) VALUES
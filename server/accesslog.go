@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// accessLogger writes one structured line per request. Error responses
+// (status >= 400) are always logged; successful ones are sampled.
+type accessLogger struct {
+	sampleRate uint64 // Log 1 in sampleRate successful requests
+	successes  atomic.Uint64
+}
+
+// newAccessLogger returns an access logger that logs 1 in sampleRate
+// successful requests. Rates below 1 log every request.
+func newAccessLogger(sampleRate int) *accessLogger {
+	if sampleRate < 1 {
+		sampleRate = 1
+	}
+	return &accessLogger{sampleRate: uint64(sampleRate)}
+}
+
+// shouldLog reports whether a request that finished with status is logged.
+// Sampling is deterministic: the 1st, (N+1)th, (2N+1)th... successes are logged.
+func (l *accessLogger) shouldLog(status int) bool {
+	if status >= http.StatusBadRequest {
+		return true
+	}
+	return (l.successes.Add(1)-1)%l.sampleRate == 0
+}
+
+// withAccessLog wraps a handler so requests are logged as key=value lines
+func withAccessLog(next http.Handler, sampleRate int) http.Handler {
+	logger := newAccessLogger(sampleRate)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if logger.shouldLog(recorder.status) {
+			log.Printf("access method=%s path=%q status=%d duration=%s",
+				r.Method, r.URL.Path, recorder.status, time.Since(start))
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithAccessLogSampling(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	handler := withAccessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}), 4)
+
+	serve := func(path string) {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	for i := 0; i < 10; i++ {
+		serve("/ok")
+		if i%2 == 0 {
+			serve("/missing")
+			serve("/broken")
+		}
+	}
+
+	// 1 in 4 of the 10 successes (the 1st, 5th and 9th) and every error
+	if got := strings.Count(logs.String(), `path="/ok" status=200`); got != 3 {
+		t.Errorf("Expected 3 sampled successes, got %d:\n%s", got, logs.String())
+	}
+	if got := strings.Count(logs.String(), `path="/missing" status=404`); got != 5 {
+		t.Errorf("Expected all 5 client errors to be logged, got %d", got)
+	}
+	if got := strings.Count(logs.String(), `path="/broken" status=500`); got != 5 {
+		t.Errorf("Expected all 5 server errors to be logged, got %d", got)
+	}
+}
+
+func TestAccessLoggerDefaultRate(t *testing.T) {
+	for _, rate := range []int{0, 1, -3} {
+		logger := newAccessLogger(rate)
+		for i := 0; i < 5; i++ {
+			if !logger.shouldLog(http.StatusOK) {
+				t.Errorf("Rate %d should log every request", rate)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import "sort"
+
+// scrambleWord returns a random permutation of word's letters, avoiding the
+// word itself unless every arrangement spells it (e.g. a single repeated letter)
+func scrambleWord(word string) string {
+	letters := []rune(word)
+	rng.Shuffle(len(letters), func(i, j int) {
+		letters[i], letters[j] = letters[j], letters[i]
+	})
+
+	// Rotating by one changes any word that is not a single repeated letter
+	if string(letters) == word && len(letters) > 1 {
+		letters = append(letters[1:], letters[0])
+	}
+	return string(letters)
+}
+
+// isAnagramOf reports whether a uses exactly the same letters as b
+func isAnagramOf(a, b string) bool {
+	return sortedLetters(a) == sortedLetters(b)
+}
+
+// AnagramsOf returns the candidates that use exactly the letters of word,
+// sorted and without duplicates. The word itself is never included, and the
+// result is empty, not nil, when it has no anagrams.
+func AnagramsOf(word string, candidates []string) []string {
+	key := sortedLetters(word)
+	seen := map[string]bool{word: true}
+	anagrams := []string{}
+	for _, candidate := range candidates {
+		if !seen[candidate] && sortedLetters(candidate) == key {
+			seen[candidate] = true
+			anagrams = append(anagrams, candidate)
+		}
+	}
+	sort.Strings(anagrams)
+	return anagrams
+}
+
+// sortedLetters returns the letters of word in sorted order
+func sortedLetters(word string) string {
+	letters := []rune(word)
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
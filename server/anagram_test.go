@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestScrambleWord(t *testing.T) {
+	for _, word := range []string{"CRANE", "HELLO", "AB", "ÉCLAT"} {
+		for i := 0; i < 20; i++ {
+			scramble := scrambleWord(word)
+			if !isAnagramOf(scramble, word) {
+				t.Fatalf("Expected %q to be a permutation of %q", scramble, word)
+			}
+			if scramble == word {
+				t.Fatalf("Expected %q to differ from the original", scramble)
+			}
+		}
+	}
+
+	// Words with only one arrangement are returned unchanged
+	if scramble := scrambleWord("AAA"); scramble != "AAA" {
+		t.Errorf("Expected AAA, got %q", scramble)
+	}
+}
+
+func TestIsAnagramOf(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"LISTEN", "SILENT", true},
+		{"CRANE", "CRANE", true},
+		{"HELLO", "HELO", false},
+		{"HELLO", "HOLLE", true},
+		{"HELLO", "HELOO", false},
+	}
+
+	for _, tt := range tests {
+		if got := isAnagramOf(tt.a, tt.b); got != tt.want {
+			t.Errorf("isAnagramOf(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAnagramsOf(t *testing.T) {
+	candidates := []string{"CRANE", "NACRE", "CANER", "CRATE", "TRACE", "REACT", "CATER", "CARET", "HELLO", "TRACE"}
+
+	anagrams := AnagramsOf("CRATE", candidates)
+	expected := []string{"CARET", "CATER", "REACT", "TRACE"}
+	if len(anagrams) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, anagrams)
+	}
+	for i := range expected {
+		if anagrams[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, anagrams)
+			break
+		}
+	}
+
+	if anagrams := AnagramsOf("HELLO", candidates); anagrams == nil || len(anagrams) != 0 {
+		t.Errorf("Expected an empty list for a word without anagrams, got %#v", anagrams)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Audited mutation actions
+const (
+	AuditActionGameCreated  = "game.created"
+	AuditActionGuessCreated = "guess.created"
+	AuditActionGameUpdated  = "game.updated"
+	AuditActionGameDeleted  = "game.deleted"
+)
+
+// AuditActor identifies who made a change, as far as the request tells
+type AuditActor struct {
+	PlayerID string `json:"player_id,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	APIKey   string `json:"api_key,omitempty"` // Fingerprint of the X-API-Key, never the key itself
+}
+
+// AuditRecord is one mutation in the audit log
+type AuditRecord struct {
+	Action    string     `json:"action"`
+	GameID    string     `json:"game_id"`
+	Timestamp time.Time  `json:"timestamp"`
+	Actor     AuditActor `json:"actor"`
+}
+
+// apiKeyFingerprintLength is how many hex digits of the key's SHA-256 are kept
+const apiKeyFingerprintLength = 16
+
+// apiKeyFingerprint identifies an API key in audit records without storing
+// it; an empty key has no fingerprint
+func apiKeyFingerprint(key string) string {
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])[:apiKeyFingerprintLength]
+}
+
+// FileAuditLogger appends audit records to a file as JSON lines. It is safe
+// for concurrent use.
+type FileAuditLogger struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileAuditLogger opens path for appending, creating it if needed
+func NewFileAuditLogger(path string) (*FileAuditLogger, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &FileAuditLogger{file: file}, nil
+}
+
+// Record appends record as one line. The mutation it describes has already
+// happened, so a failed write is logged rather than returned.
+func (l *FileAuditLogger) Record(record AuditRecord) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("Warning: failed to encode audit record for game %s: %v", record.GameID, err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		log.Printf("Warning: failed to write audit record for game %s: %v", record.GameID, err)
+	}
+}
+
+// Close closes the audit log file
+func (l *FileAuditLogger) Close() error {
+	return l.file.Close()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileAuditLoggerAppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	// Records from separate runs append to the same file
+	for _, action := range []string{AuditActionGameCreated, AuditActionGuessCreated} {
+		logger, err := NewFileAuditLogger(path)
+		if err != nil {
+			t.Fatalf("Failed to open audit log: %v", err)
+		}
+		logger.Record(AuditRecord{Action: action, GameID: "A", Actor: AuditActor{IP: "192.0.2.1"}})
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Failed to close audit log: %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Expected a JSON record per line, got %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 || records[0].Action != AuditActionGameCreated || records[1].Action != AuditActionGuessCreated {
+		t.Fatalf("Expected the create and guess records in order, got %+v", records)
+	}
+	if records[1].GameID != "A" || records[1].Actor.IP != "192.0.2.1" {
+		t.Errorf("Expected game and actor to be kept, got %+v", records[1])
+	}
+}
+
+func TestAPIKeyFingerprint(t *testing.T) {
+	fingerprint := apiKeyFingerprint("secret-key")
+	if len(fingerprint) != apiKeyFingerprintLength || strings.Contains(fingerprint, "secret") {
+		t.Errorf("Expected a %d digit fingerprint, got %q", apiKeyFingerprintLength, fingerprint)
+	}
+	if apiKeyFingerprint("secret-key") != fingerprint || apiKeyFingerprint("other-key") == fingerprint {
+		t.Error("Expected fingerprints to identify keys")
+	}
+	if apiKeyFingerprint("") != "" {
+		t.Error("Expected no fingerprint without a key")
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// Errors CreateMultiBoardGame returns for requests the configuration doesn't allow
+var (
+	ErrMultiBoardDisabled = errors.New("multi-board games are disabled")
+	ErrBoardCount         = errors.New("invalid board count")
+)
+
+// Board is one target word of a multi-board (duet/quordle-style) game
+type Board struct {
+	Target   string `json:"target"`
+	SolvedAt int    `json:"solved_at,omitempty"` // Guess number that found the target; 0 while unsolved
+}
+
+// Solved reports whether the board's target has been guessed
+func (b Board) Solved() bool {
+	return b.SolvedAt > 0
+}
+
+// SolvedBy reports whether the board was solved by the guessNumber-th guess
+// or an earlier one
+func (b Board) SolvedBy(guessNumber int) bool {
+	return b.Solved() && b.SolvedAt <= guessNumber
+}
+
+// Boards are the target words of a multi-board game, played with the same
+// guesses. Regular games have none; their only target is the game's
+// TargetWord, which is also the first board's target in multi-board games.
+type Boards []Board
+
+// NewBoards returns an unsolved board for each target
+func NewBoards(targets []string) Boards {
+	boards := make(Boards, len(targets))
+	for i, target := range targets {
+		boards[i] = Board{Target: target}
+	}
+	return boards
+}
+
+// Solve returns the boards after guessWord, the guessNumber-th guess. Each
+// unsolved board whose target it is becomes solved; b itself is unchanged.
+func (b Boards) Solve(guessWord string, guessNumber int) Boards {
+	solved := make(Boards, len(b))
+	copy(solved, b)
+	for i := range solved {
+		if !solved[i].Solved() && solved[i].Target == guessWord {
+			solved[i].SolvedAt = guessNumber
+		}
+	}
+	return solved
+}
+
+// Targets returns each board's target word
+func (b Boards) Targets() []string {
+	targets := make([]string, len(b))
+	for i, board := range b {
+		targets[i] = board.Target
+	}
+	return targets
+}
+
+// Equal reports whether b and other have the same targets, solved by the same guesses
+func (b Boards) Equal(other Boards) bool {
+	if len(b) != len(other) {
+		return false
+	}
+	for i := range b {
+		if b[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AllSolved reports whether every board has been solved
+func (b Boards) AllSolved() bool {
+	for _, board := range b {
+		if !board.Solved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (b Boards) Value() (driver.Value, error) {
+	if b == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(b)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (b *Boards) Scan(value interface{}) error {
+	if value == nil {
+		*b = nil
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("cannot scan Boards from non-string/[]byte")
+	}
+
+	var boards Boards
+	if err := json.Unmarshal(bytes, &boards); err != nil {
+		return err
+	}
+	if len(boards) == 0 {
+		boards = nil
+	}
+	*b = boards
+	return nil
+}
+
+// BoardResult is a guess evaluated against one board of a multi-board game
+type BoardResult struct {
+	Board  int         `json:"board"` // Index into the game's boards
+	Result GuessResult `json:"result"`
+	Solved bool        `json:"solved"` // The board is solved, by this guess or an earlier one
+}
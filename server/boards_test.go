@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBoardsSolve(t *testing.T) {
+	boards := NewBoards([]string{"CRANE", "SLATE"})
+
+	solved := boards.Solve("SLATE", 2)
+	if solved[0].Solved() || solved[1].SolvedAt != 2 {
+		t.Errorf("Expected only SLATE solved at guess 2, got %+v", solved)
+	}
+	if solved.AllSolved() {
+		t.Error("Expected CRANE to be unsolved")
+	}
+
+	// A board keeps the guess that first solved it
+	solved = solved.Solve("CRANE", 3).Solve("SLATE", 4)
+	if solved[0].SolvedAt != 3 || solved[1].SolvedAt != 2 || !solved.AllSolved() {
+		t.Errorf("Expected CRANE at 3 and SLATE at 2, got %+v", solved)
+	}
+
+	// Solve does not modify the receiver
+	if boards[0].Solved() || boards[1].Solved() {
+		t.Errorf("Solve should not modify the original boards, got %+v", boards)
+	}
+}
+
+func TestBoardsValueScan(t *testing.T) {
+	boards := Boards{{Target: "CRANE", SolvedAt: 2}, {Target: "SLATE"}}
+	value, err := boards.Value()
+	if err != nil {
+		t.Fatalf("Value should not return error: %v", err)
+	}
+
+	var scanned Boards
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Scan should not return error: %v", err)
+	}
+	if !reflect.DeepEqual(scanned, boards) {
+		t.Errorf("Expected %+v, got %+v", boards, scanned)
+	}
+
+	// Regular games store an empty list and scan back to no boards
+	value, err = Boards(nil).Value()
+	if err != nil || string(value.([]byte)) != "[]" {
+		t.Errorf("Expected [] for no boards, got %v, %v", value, err)
+	}
+	if err := scanned.Scan("[]"); err != nil || scanned != nil {
+		t.Errorf("Expected no boards from [], got %+v, %v", scanned, err)
+	}
+	if err := scanned.Scan(42); err == nil {
+		t.Error("Expected error scanning a non-string value")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// maxUserAgentLength caps how much of the User-Agent header is stored
+const maxUserAgentLength = 512
+
+// ClientInfo identifies where a game was created from, for abuse analysis.
+// It is stored with the game but never included in API responses.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// parseTrustedProxies parses a comma-separated list of proxy IPs and CIDRs.
+// A bare IP is treated as a single-address network.
+func parseTrustedProxies(value string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid proxy address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy network %q", entry)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// isTrustedProxy reports whether ip belongs to one of the trusted networks
+func isTrustedProxy(ip net.IP, trusted []*net.IPNet) bool {
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the originating client IP for the request. X-Forwarded-For
+// is only honored when the connecting peer is a trusted proxy; its entries are
+// then read right to left, skipping further trusted proxies, so a client cannot
+// spoof its address by sending its own header.
+func clientIP(r *http.Request, trusted []*net.IPNet) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+
+	remoteIP := net.ParseIP(remote)
+	if remoteIP == nil || !isTrustedProxy(remoteIP, trusted) {
+		return remote
+	}
+
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil {
+			// A malformed entry can't be attributed; stop at the last known hop
+			break
+		}
+		if !isTrustedProxy(ip, trusted) {
+			return ip.String()
+		}
+		remote = ip.String()
+	}
+	return remote
+}
+
+// clientInfoFromRequest extracts the client IP and user agent from a request
+func clientInfoFromRequest(r *http.Request, trusted []*net.IPNet) ClientInfo {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	return ClientInfo{
+		IP:        clientIP(r, trusted),
+		UserAgent: userAgent,
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := parseTrustedProxies("10.0.0.1, 192.168.0.0/16,,2001:db8::1")
+	if err != nil {
+		t.Fatalf("parseTrustedProxies should not return error: %v", err)
+	}
+	if len(networks) != 3 {
+		t.Fatalf("Expected 3 networks, got %d", len(networks))
+	}
+	if networks[0].String() != "10.0.0.1/32" || networks[2].String() != "2001:db8::1/128" {
+		t.Errorf("Expected bare IPs as single-address networks, got %v and %v", networks[0], networks[2])
+	}
+
+	if networks, err := parseTrustedProxies(""); err != nil || len(networks) != 0 {
+		t.Errorf("Expected no networks for empty value, got %v, %v", networks, err)
+	}
+
+	for _, value := range []string{"proxy.internal", "10.0.0.0/33"} {
+		if _, err := parseTrustedProxies(value); err == nil {
+			t.Errorf("Expected error for %q", value)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	trusted, err := parseTrustedProxies("10.0.0.1, 172.16.0.0/12")
+	if err != nil {
+		t.Fatalf("Failed to parse trusted proxies: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		trusted      bool
+		expected     string
+	}{
+		{"Direct client", "203.0.113.7:5000", "", true, "203.0.113.7"},
+		{"Untrusted peer header ignored", "203.0.113.7:5000", "198.51.100.1", true, "203.0.113.7"},
+		{"No trusted proxies configured", "10.0.0.1:5000", "198.51.100.1", false, "10.0.0.1"},
+		{"Trusted proxy", "10.0.0.1:5000", "198.51.100.1", true, "198.51.100.1"},
+		{"Spoofed entries before the real client", "10.0.0.1:5000", "1.2.3.4, 198.51.100.1", true, "198.51.100.1"},
+		{"Chain of trusted proxies", "10.0.0.1:5000", "198.51.100.1, 172.16.5.5", true, "198.51.100.1"},
+		{"Trusted proxy without header", "10.0.0.1:5000", "", true, "10.0.0.1"},
+		{"Malformed entry", "10.0.0.1:5000", "198.51.100.1, garbage", true, "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/games", nil)
+			request.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				request.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+
+			networks := trusted
+			if !tt.trusted {
+				networks = nil
+			}
+			if got := clientIP(request, networks); got != tt.expected {
+				t.Errorf("Expected client IP %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestClientInfoFromRequest(t *testing.T) {
+	request := httptest.NewRequest("POST", "/api/games", nil)
+	request.RemoteAddr = "203.0.113.7:5000"
+	request.Header.Set("User-Agent", strings.Repeat("a", maxUserAgentLength+10))
+
+	info := clientInfoFromRequest(request, nil)
+	if info.IP != "203.0.113.7" {
+		t.Errorf("Expected IP 203.0.113.7, got %s", info.IP)
+	}
+	if len(info.UserAgent) != maxUserAgentLength {
+		t.Errorf("Expected user agent truncated to %d, got %d", maxUserAgentLength, len(info.UserAgent))
+	}
+}
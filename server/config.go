@@ -4,20 +4,79 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Environment names recognised by Config.Validate
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// Insecure defaults that must be overridden in production
+const (
+	defaultDBPassword = "wordle_password"
+	defaultDBHost     = "localhost"
+	defaultDBSSLMode  = "disable"
+)
+
+// Feature flag names accepted in FEATURES
+const (
+	FeatureHeatmap   = "heatmap"   // GET /api/stats/heatmap
+	FeatureByWord    = "by_word"   // GET /api/games/by-word
+	FeatureDaily     = "daily"     // GET /api/daily, the answer schedule admin endpoint and guesses on daily games
+	FeatureAnagram   = "anagram"   // GET /api/words/anagram
+	FeatureNormalize = "normalize" // GET /api/words/normalize
+	FeatureSolver    = "solver"    // GET /api/games/{id}/possible, letter-probabilities, rank-guesses and solution-path
+)
+
+// defaultFeatures are enabled when FEATURES is not set
+const defaultFeatures = FeatureHeatmap + "," + FeatureByWord + "," + FeatureDaily + "," + FeatureAnagram + "," + FeatureNormalize + "," + FeatureSolver
+
+// minResumeSecretLength is the shortest DAILY_RESUME_SECRET accepted
+const minResumeSecretLength = 16
+
+// Defaults for list endpoint page sizes
+const (
+	defaultMaxPageSize = 100
+	defaultPageSize    = 10
+)
+
+// Defaults bounding guess input; both are far longer than any real word
+const (
+	defaultMaxGuessInputLength = 64 // Bytes of raw input
+	defaultMaxGuessRuneLength  = 32 // Letters (runes) after normalization
+)
+
+// Handling of whitespace inside a trimmed guess, accepted in GUESS_INNER_WHITESPACE
+const (
+	InnerWhitespaceReject = "reject" // Reject the guess with an error saying why
+	InnerWhitespaceStrip  = "strip"  // Remove the whitespace, so "he  llo" is HELLO
+)
+
+// Isogram (no repeated letters) variant, accepted in ISOGRAM_MODE
+const (
+	IsogramModeOff     = "off"     // Any target word and any valid guess
+	IsogramModeTargets = "targets" // Only isograms are picked as target words
+	IsogramModeStrict  = "strict"  // Isogram targets, and guesses must be isograms too
+)
+
 // Config holds all configuration for the application
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	Game     GameConfig
+	Environment string
+	Features    map[string]bool
+	Database    DatabaseConfig
+	Server      ServerConfig
+	Game        GameConfig
 }
 
 // DatabaseConfig holds database connection configuration
 type DatabaseConfig struct {
+	Backend         string // Storage backend: "postgres" (default) or "sqlite"
+	Path            string // SQLite database file; empty or ":memory:" for an in-memory database
 	Host            string
 	Port            int
 	Name            string
@@ -32,14 +91,71 @@ type DatabaseConfig struct {
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Host string
-	Port int
+	Host       string
+	Port       int
+	AdminToken string // Required in X-Admin-Token for admin endpoints; empty disables them
+
+	RequireAPIKey bool // Require an X-API-Key from api_keys with daily quota left to create games
+
+	RequireJSONContentType bool // Reject POST bodies to JSON endpoints unless sent as application/json
+
+	LogSampleRate int // Access-log 1 in N successful requests; errors are always logged
+
+	Audit        bool   // Append a record of every game create, guess, update and delete to the audit log
+	AuditLogPath string // JSON-lines audit log file, separate from the access log
+
+	RecordClientInfo bool   // Store the client IP and user agent with each new game
+	TrustedProxies   string // Comma-separated proxy IPs/CIDRs whose X-Forwarded-For is honored
+
+	WebhookURL           string        // Receives a POST when a game completes; empty disables webhooks
+	WebhookIncludeTarget bool          // Include the target word in webhook payloads
+	WebhookTimeout       time.Duration // Per-attempt webhook timeout
+	WebhookMaxAttempts   int           // Delivery attempts before giving up
 }
 
 // GameConfig holds game-specific configuration
 type GameConfig struct {
-	MaxGuesses int
-	WordLength int
+	MaxGuesses      int
+	WordLength      int
+	AllowBulkCreate bool   // Enables GameService.CreateGames for load testing
+	Locale          string // BCP 47 locale for case mapping; empty uses Unicode defaults
+	DefinitionsFile string // Optional word<TAB>definition file revealed when a game ends
+	ResultFormat    string // Guess result persistence format: "json" or "compact"
+	MaxPageSize     int    // Upper bound on items returned by any list endpoint
+	DefaultPageSize int    // Items returned by list endpoints when no limit is given
+
+	MaxConcurrentGuesses int    // In-flight MakeGuess calls before shedding with 503; 0 is unlimited
+	MaxGuessInputLength  int    // Raw guesses longer than this many bytes are rejected before normalization
+	MaxGuessRuneLength   int    // Guesses with more letters (runes) than this are rejected as oversized, not as the wrong length
+	InnerWhitespace      string // Whitespace inside a guess: "reject" (default) or "strip"
+	LengthErrorDetails   bool   // Add the expected and received lengths to wrong-length guess errors as details
+	SuggestWords         bool   // Add the nearest valid words to not-in-dictionary guess errors as details
+
+	PartialCredit   bool // Score lost games by the correct letters in their best guess (classroom mode)
+	RevealAnagrams  bool // List the target word's anagrams among valid words once a game has ended
+	MaxHintsPerGame int  // Nudges allowed per game unless the game sets its own limit; 0 is unlimited
+	MaxBoards       int  // Most targets a multi-board (duet/quordle-style) game may have; below 2 disables them
+
+	DailyGamePerPlayer bool   // Give each player one daily game per date instead of a single shared one
+	AutoCreatePlayers  bool   // Create the player named by a new game's username when none exists yet
+	DailyResumeSecret  string // HMAC key signing daily game resume codes; empty disables them
+	DailyCreateRetries int    // Fetches of a daily game after losing the race to create it; 0 uses the default
+	DailyDerivedWords  bool   // Derive the daily word from the date when no answer is scheduled for it
+
+	RejectRepeatGuesses  bool // Reject a guess identical to an earlier guess in the same game
+	RejectAnagramGuesses bool // Reject a guess that rearranges an earlier guess's letters (not in anagram games)
+
+	IsogramMode string // No-repeat letters variant: "off" (default), "targets" or "strict"
+
+	RandomSeed int64 // Fixed seed for target selection; 0 seeds from crypto/rand at startup
+	IDSeed     int64 // Fixed seed for deterministic game and guess IDs; 0 generates random UUIDs
+
+	SplitMultiWordLines bool   // Split word-file lines containing spaces instead of skipping them
+	MaxWordLength       int    // Skip longer words when loading word files; 0 keeps all
+	MaxWordLineBytes    int    // Longest word-file line accepted, in bytes; 0 uses the default
+	CaseSensitiveWords  bool   // Preserve word case and match guesses exactly (e.g. proper nouns)
+	RequireTargetLength bool   // Fail startup when no target word has WordLength letters
+	TutorialFile        string // Optional JSON tutorial script; empty uses the built-in tutorial
 }
 
 // LoadConfig loads configuration from environment variables and .env file
@@ -48,31 +164,165 @@ func LoadConfig() (*Config, error) {
 	_ = godotenv.Load()
 
 	config := &Config{
+		Environment: getEnvString("ENV", EnvDevelopment),
+		Features:    parseFeatures(getEnvString("FEATURES", defaultFeatures)),
 		Database: DatabaseConfig{
-			Host:            getEnvString("DB_HOST", "localhost"),
+			Backend:         getEnvString("DB_BACKEND", BackendPostgres),
+			Path:            getEnvString("SQLITE_PATH", "wordle.db"),
+			Host:            getEnvString("DB_HOST", defaultDBHost),
 			Port:            getEnvInt("DB_PORT", 5432),
 			Name:            getEnvString("DB_NAME", "wordle"),
 			User:            getEnvString("DB_USER", "wordle_user"),
-			Password:        getEnvString("DB_PASSWORD", "wordle_password"),
-			SSLMode:         getEnvString("DB_SSLMODE", "disable"),
+			Password:        getEnvString("DB_PASSWORD", defaultDBPassword),
+			SSLMode:         getEnvString("DB_SSLMODE", defaultDBSSLMode),
 			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
 			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 10),
 			ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", "1h"),
 			ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", "15m"),
 		},
 		Server: ServerConfig{
-			Host: getEnvString("HOST", "localhost"),
-			Port: getEnvInt("PORT", 8080),
+			Host:       getEnvString("HOST", "localhost"),
+			Port:       getEnvInt("PORT", 8080),
+			AdminToken: getEnvString("ADMIN_TOKEN", ""),
+
+			RequireAPIKey: getEnvBool("REQUIRE_API_KEY", false),
+
+			RequireJSONContentType: getEnvBool("REQUIRE_JSON_CONTENT_TYPE", false),
+
+			LogSampleRate: getEnvInt("LOG_SAMPLE_RATE", 1),
+
+			Audit:        getEnvBool("AUDIT", false),
+			AuditLogPath: getEnvString("AUDIT_LOG_PATH", "audit.log"),
+
+			RecordClientInfo: getEnvBool("RECORD_CLIENT_INFO", false),
+			TrustedProxies:   getEnvString("TRUSTED_PROXIES", ""),
+
+			WebhookURL:           getEnvString("WEBHOOK_URL", ""),
+			WebhookIncludeTarget: getEnvBool("WEBHOOK_INCLUDE_TARGET", false),
+			WebhookTimeout:       getEnvDuration("WEBHOOK_TIMEOUT", "5s"),
+			WebhookMaxAttempts:   getEnvInt("WEBHOOK_MAX_ATTEMPTS", 3),
 		},
 		Game: GameConfig{
-			MaxGuesses: getEnvInt("MAX_GUESSES", 6),
-			WordLength: getEnvInt("WORD_LENGTH", 5),
+			MaxGuesses:      getEnvInt("MAX_GUESSES", 6),
+			WordLength:      getEnvInt("WORD_LENGTH", 5),
+			AllowBulkCreate: getEnvBool("ALLOW_BULK_CREATE", false),
+			Locale:          getEnvString("GAME_LOCALE", ""),
+			DefinitionsFile: getEnvString("DEFINITIONS_FILE", ""),
+			ResultFormat:    getEnvString("RESULT_FORMAT", ResultFormatJSON),
+			MaxPageSize:     getEnvInt("MAX_PAGE_SIZE", defaultMaxPageSize),
+			DefaultPageSize: getEnvInt("DEFAULT_PAGE_SIZE", defaultPageSize),
+
+			MaxConcurrentGuesses: getEnvInt("MAX_CONCURRENT_GUESSES", 0),
+			MaxGuessInputLength:  getEnvInt("MAX_GUESS_INPUT_LENGTH", defaultMaxGuessInputLength),
+			MaxGuessRuneLength:   getEnvInt("MAX_GUESS_RUNE_LENGTH", defaultMaxGuessRuneLength),
+			InnerWhitespace:      getEnvString("GUESS_INNER_WHITESPACE", InnerWhitespaceReject),
+			LengthErrorDetails:   getEnvBool("LENGTH_ERROR_DETAILS", true),
+			SuggestWords:         getEnvBool("GUESS_SUGGESTIONS", false),
+			PartialCredit:        getEnvBool("PARTIAL_CREDIT", false),
+			RevealAnagrams:       getEnvBool("REVEAL_ANAGRAMS", false),
+			DailyGamePerPlayer:   getEnvBool("DAILY_GAME_PER_PLAYER", true),
+			AutoCreatePlayers:    getEnvBool("AUTO_CREATE_PLAYERS", false),
+			DailyResumeSecret:    getEnvString("DAILY_RESUME_SECRET", ""),
+			DailyCreateRetries:   getEnvInt("DAILY_CREATE_RETRIES", defaultDailyCreateRetries),
+			DailyDerivedWords:    getEnvBool("DAILY_DERIVED_WORDS", false),
+			MaxHintsPerGame:      getEnvInt("MAX_HINTS_PER_GAME", 0),
+			MaxBoards:            getEnvInt("MAX_BOARDS", defaultMaxBoards),
+			RejectRepeatGuesses:  getEnvBool("REJECT_REPEAT_GUESSES", false),
+			RejectAnagramGuesses: getEnvBool("REJECT_ANAGRAM_GUESSES", false),
+			IsogramMode:          getEnvString("ISOGRAM_MODE", IsogramModeOff),
+			RandomSeed:           int64(getEnvInt("RANDOM_SEED", 0)),
+			IDSeed:               int64(getEnvInt("ID_SEED", 0)),
+			SplitMultiWordLines:  getEnvBool("WORDLIST_SPLIT_LINES", false),
+			MaxWordLength:        getEnvInt("MAX_WORD_LENGTH", 0),
+			MaxWordLineBytes:     getEnvInt("MAX_WORD_LINE_BYTES", defaultMaxLineBytes),
+			CaseSensitiveWords:   getEnvBool("CASE_SENSITIVE_WORDS", false),
+			RequireTargetLength:  getEnvBool("REQUIRE_TARGET_WORD_LENGTH", true),
+			TutorialFile:         getEnvString("TUTORIAL_FILE", ""),
 		},
 	}
 
 	return config, nil
 }
 
+// FeatureEnabled reports whether the named feature flag is enabled
+func (c *Config) FeatureEnabled(name string) bool {
+	return c.Features[strings.ToLower(name)]
+}
+
+// IsProduction reports whether the application is running in production mode
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(c.Environment, EnvProduction)
+}
+
+// Validate checks the configuration for settings that are invalid or unsafe to
+// run with. The game locale, result format, trusted proxies and database
+// backend must always be valid. In production mode with PostgreSQL the default
+// database password, disabled SSL and a localhost database host are rejected;
+// development mode allows them.
+func (c *Config) Validate() error {
+	if _, err := NewUpperCaser(c.Game.Locale); err != nil {
+		return fmt.Errorf("invalid GAME_LOCALE: %w", err)
+	}
+	if err := validateResultFormat(c.Game.ResultFormat); err != nil {
+		return fmt.Errorf("invalid RESULT_FORMAT: %w", err)
+	}
+	if err := validateInnerWhitespace(c.Game.InnerWhitespace); err != nil {
+		return fmt.Errorf("invalid GUESS_INNER_WHITESPACE: %w", err)
+	}
+	if err := validateIsogramMode(c.Game.IsogramMode); err != nil {
+		return fmt.Errorf("invalid ISOGRAM_MODE: %w", err)
+	}
+	if secret := c.Game.DailyResumeSecret; secret != "" && len(secret) < minResumeSecretLength {
+		return fmt.Errorf("invalid DAILY_RESUME_SECRET: must be at least %d characters", minResumeSecretLength)
+	}
+	if _, err := parseTrustedProxies(c.Server.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	dialect, err := NewDialect(c.Database.Backend)
+	if err != nil {
+		return fmt.Errorf("invalid DB_BACKEND: %w", err)
+	}
+
+	// The remaining checks concern PostgreSQL connection settings
+	if !c.IsProduction() || dialect.Name() != BackendPostgres {
+		return nil
+	}
+
+	var problems []string
+	if c.Database.Password == "" || c.Database.Password == defaultDBPassword {
+		problems = append(problems, "DB_PASSWORD must be set to a non-default value")
+	}
+	if c.Database.SSLMode == defaultDBSSLMode {
+		problems = append(problems, "DB_SSLMODE must not be 'disable'")
+	}
+	if c.Database.Host == defaultDBHost {
+		problems = append(problems, "DB_HOST must not be 'localhost'")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("insecure production configuration: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// validateInnerWhitespace checks an inner whitespace mode; empty means reject
+func validateInnerWhitespace(mode string) error {
+	if mode != "" && mode != InnerWhitespaceReject && mode != InnerWhitespaceStrip {
+		return fmt.Errorf("unknown inner whitespace mode %q (expected %q or %q)", mode, InnerWhitespaceReject, InnerWhitespaceStrip)
+	}
+	return nil
+}
+
+// validateIsogramMode checks an isogram mode; empty means off
+func validateIsogramMode(mode string) error {
+	switch mode {
+	case "", IsogramModeOff, IsogramModeTargets, IsogramModeStrict:
+		return nil
+	}
+	return fmt.Errorf("unknown isogram mode %q (expected %q, %q or %q)", mode, IsogramModeOff, IsogramModeTargets, IsogramModeStrict)
+}
+
 // ConnectionString returns a PostgreSQL connection string
 func (d *DatabaseConfig) ConnectionString() string {
 	return fmt.Sprintf(
@@ -96,6 +346,18 @@ func (s *ServerConfig) Address() string {
 
 // Helper functions for environment variable parsing
 
+// parseFeatures parses a comma-separated list of feature names into a set
+func parseFeatures(value string) map[string]bool {
+	features := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			features[name] = true
+		}
+	}
+	return features
+}
+
 func getEnvString(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -112,6 +374,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvDuration(key string, defaultValue string) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
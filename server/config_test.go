@@ -58,6 +58,18 @@ func TestLoadConfig(t *testing.T) {
 	if config.Game.WordLength != 5 {
 		t.Errorf("Expected default word length 5, got %d", config.Game.WordLength)
 	}
+	if config.Game.MaxGuessInputLength != defaultMaxGuessInputLength {
+		t.Errorf("Expected default max guess input length %d, got %d", defaultMaxGuessInputLength, config.Game.MaxGuessInputLength)
+	}
+	if config.Game.MaxGuessRuneLength != defaultMaxGuessRuneLength {
+		t.Errorf("Expected default max guess rune length %d, got %d", defaultMaxGuessRuneLength, config.Game.MaxGuessRuneLength)
+	}
+	if !config.Game.RequireTargetLength {
+		t.Error("Expected target word length to be required by default")
+	}
+	if config.Game.InnerWhitespace != InnerWhitespaceReject {
+		t.Errorf("Expected guesses with inner whitespace to be rejected by default, got %q", config.Game.InnerWhitespace)
+	}
 }
 
 func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
@@ -71,6 +83,8 @@ func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
 	os.Setenv("HOST", "0.0.0.0")
 	os.Setenv("MAX_GUESSES", "10")
 	os.Setenv("WORD_LENGTH", "7")
+	os.Setenv("PARTIAL_CREDIT", "true")
+	os.Setenv("RANDOM_SEED", "42")
 
 	defer func() {
 		os.Unsetenv("DB_HOST")
@@ -82,6 +96,8 @@ func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
 		os.Unsetenv("HOST")
 		os.Unsetenv("MAX_GUESSES")
 		os.Unsetenv("WORD_LENGTH")
+		os.Unsetenv("PARTIAL_CREDIT")
+		os.Unsetenv("RANDOM_SEED")
 	}()
 
 	config, err := LoadConfig()
@@ -117,6 +133,12 @@ func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
 	if config.Game.WordLength != 7 {
 		t.Errorf("Expected word length 7, got %d", config.Game.WordLength)
 	}
+	if !config.Game.PartialCredit {
+		t.Error("Expected partial credit to be enabled")
+	}
+	if config.Game.RandomSeed != 42 {
+		t.Errorf("Expected random seed 42, got %d", config.Game.RandomSeed)
+	}
 }
 
 func TestDatabaseConfigConnectionString(t *testing.T) {
@@ -212,6 +234,24 @@ func TestGetEnvInt(t *testing.T) {
 	}
 }
 
+func TestGetEnvBool(t *testing.T) {
+	os.Setenv("TEST_BOOL", "true")
+	defer os.Unsetenv("TEST_BOOL")
+
+	if !getEnvBool("TEST_BOOL", false) {
+		t.Error("Expected true from environment variable")
+	}
+
+	os.Setenv("TEST_BOOL", "not-a-bool")
+	if getEnvBool("TEST_BOOL", false) {
+		t.Error("Expected default value for invalid boolean")
+	}
+
+	if !getEnvBool("NON_EXISTENT_BOOL", true) {
+		t.Error("Expected default value for missing variable")
+	}
+}
+
 func TestGetEnvDuration(t *testing.T) {
 	// Test with valid duration env var
 	os.Setenv("TEST_ENV_DURATION", "30m")
@@ -247,3 +287,195 @@ func TestGetEnvDuration(t *testing.T) {
 		t.Errorf("Expected fallback value %v, got %v", expected, result)
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	defaults := DatabaseConfig{
+		Host:     "localhost",
+		Password: "wordle_password",
+		SSLMode:  "disable",
+	}
+	secure := DatabaseConfig{
+		Host:     "db.internal",
+		Password: "s3cret-value",
+		SSLMode:  "require",
+	}
+
+	tests := []struct {
+		name        string
+		environment string
+		database    DatabaseConfig
+		shouldFail  bool
+	}{
+		{"Development with defaults", EnvDevelopment, defaults, false},
+		{"Empty environment with defaults", "", defaults, false},
+		{"Production with defaults", EnvProduction, defaults, true},
+		{"Production with secure values", EnvProduction, secure, false},
+		{"Production case insensitive", "PRODUCTION", defaults, true},
+		{"Production with default password", EnvProduction, DatabaseConfig{Host: "db.internal", Password: "wordle_password", SSLMode: "require"}, true},
+		{"Production with empty password", EnvProduction, DatabaseConfig{Host: "db.internal", Password: "", SSLMode: "require"}, true},
+		{"Production with SSL disabled", EnvProduction, DatabaseConfig{Host: "db.internal", Password: "s3cret-value", SSLMode: "disable"}, true},
+		{"Production with localhost", EnvProduction, DatabaseConfig{Host: "localhost", Password: "s3cret-value", SSLMode: "require"}, true},
+		{"Production SQLite ignores PostgreSQL settings", EnvProduction, DatabaseConfig{Backend: BackendSQLite, Host: "localhost", Password: "wordle_password", SSLMode: "disable"}, false},
+		{"Unknown backend", EnvDevelopment, DatabaseConfig{Backend: "mysql"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Environment: tt.environment, Database: tt.database}
+			err := config.Validate()
+			if tt.shouldFail && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tt.shouldFail && err != nil {
+				t.Errorf("Expected no validation error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigValidateLocale(t *testing.T) {
+	config := &Config{Environment: EnvDevelopment, Game: GameConfig{Locale: "tr"}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected Turkish locale to be valid, got: %v", err)
+	}
+
+	config.Game.Locale = "not a locale!"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for invalid locale")
+	}
+}
+
+func TestConfigValidateResultFormat(t *testing.T) {
+	config := &Config{Environment: EnvDevelopment, Game: GameConfig{ResultFormat: ResultFormatCompact}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected compact result format to be valid, got: %v", err)
+	}
+
+	config.Game.ResultFormat = "xml"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for unknown result format")
+	}
+}
+
+func TestConfigValidateInnerWhitespace(t *testing.T) {
+	config := &Config{Environment: EnvDevelopment, Game: GameConfig{InnerWhitespace: InnerWhitespaceStrip}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected strip to be valid, got: %v", err)
+	}
+
+	config.Game.InnerWhitespace = "collapse"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for unknown inner whitespace mode")
+	}
+}
+
+func TestConfigValidateIsogramMode(t *testing.T) {
+	for _, mode := range []string{"", IsogramModeOff, IsogramModeTargets, IsogramModeStrict} {
+		config := &Config{Environment: EnvDevelopment, Game: GameConfig{IsogramMode: mode}}
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected isogram mode %q to be valid, got: %v", mode, err)
+		}
+	}
+
+	config := &Config{Environment: EnvDevelopment, Game: GameConfig{IsogramMode: "guesses"}}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for unknown isogram mode")
+	}
+}
+
+func TestConfigValidateDailyResumeSecret(t *testing.T) {
+	for _, secret := range []string{"", "a-long-enough-secret"} {
+		config := &Config{Environment: EnvDevelopment, Game: GameConfig{DailyResumeSecret: secret}}
+		if err := config.Validate(); err != nil {
+			t.Errorf("Expected resume secret %q to be valid, got: %v", secret, err)
+		}
+	}
+
+	config := &Config{Environment: EnvDevelopment, Game: GameConfig{DailyResumeSecret: "short"}}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for a short resume secret")
+	}
+}
+
+func TestConfigValidateTrustedProxies(t *testing.T) {
+	config := &Config{Environment: EnvDevelopment, Server: ServerConfig{TrustedProxies: "10.0.0.1, 192.168.0.0/16, ::1"}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected trusted proxies to be valid, got: %v", err)
+	}
+
+	config.Server.TrustedProxies = "10.0.0.1, proxy.internal"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected error for invalid trusted proxy")
+	}
+}
+
+func TestLoadConfigEnvironment(t *testing.T) {
+	original := os.Getenv("ENV")
+	defer os.Setenv("ENV", original)
+
+	os.Unsetenv("ENV")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig should not return error: %v", err)
+	}
+	if config.Environment != EnvDevelopment {
+		t.Errorf("Expected default environment '%s', got '%s'", EnvDevelopment, config.Environment)
+	}
+
+	os.Setenv("ENV", "production")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig should not return error: %v", err)
+	}
+	if !config.IsProduction() {
+		t.Error("Expected production environment")
+	}
+}
+
+func TestConfigFeatureEnabled(t *testing.T) {
+	config := &Config{Features: parseFeatures(" daily, Solver ,,")}
+
+	if !config.FeatureEnabled("daily") {
+		t.Error("Expected 'daily' to be enabled")
+	}
+	if !config.FeatureEnabled("SOLVER") {
+		t.Error("Expected 'solver' to be enabled (case-insensitive)")
+	}
+	if config.FeatureEnabled("heatmap") {
+		t.Error("Expected 'heatmap' to be disabled")
+	}
+	if len(config.Features) != 2 {
+		t.Errorf("Expected 2 features, got %d", len(config.Features))
+	}
+
+	empty := &Config{}
+	if empty.FeatureEnabled("daily") {
+		t.Error("Expected no features enabled on empty config")
+	}
+}
+
+func TestLoadConfigFeatures(t *testing.T) {
+	original := os.Getenv("FEATURES")
+	defer os.Setenv("FEATURES", original)
+
+	os.Unsetenv("FEATURES")
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig should not return error: %v", err)
+	}
+	if !config.FeatureEnabled(FeatureHeatmap) || !config.FeatureEnabled(FeatureByWord) || !config.FeatureEnabled(FeatureDaily) {
+		t.Errorf("Expected default features to be enabled, got %v", config.Features)
+	}
+
+	os.Setenv("FEATURES", "daily")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig should not return error: %v", err)
+	}
+	if config.FeatureEnabled(FeatureHeatmap) {
+		t.Error("Expected heatmap to be disabled when FEATURES overrides defaults")
+	}
+	if !config.FeatureEnabled("daily") {
+		t.Error("Expected 'daily' to be enabled")
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import "sort"
+
+// BoardConstraints summarises what a game's guesses reveal about the target word
+type BoardConstraints struct {
+	Fixed     map[int]string   `json:"fixed"`      // 1-based position -> known letter
+	Required  map[string]int   `json:"required"`   // Letter -> minimum number of occurrences
+	MaxCounts map[string]int   `json:"max_counts"` // Letter -> maximum occurrences, when capped by an absent tile
+	Excluded  []string         `json:"excluded"`   // Letters that do not appear at all
+	NotAt     map[int][]string `json:"not_at"`     // 1-based position -> letters known not to be there
+}
+
+// DeriveConstraints computes the board constraints implied by the given guesses.
+//
+// Within a single guess, the number of correct/present tiles for a letter is a
+// lower bound on its count in the target. If the same letter also has an absent
+// tile in that guess, the count is exact, which caps the letter.
+func DeriveConstraints(guesses []Guess) BoardConstraints {
+	constraints := BoardConstraints{
+		Fixed:     make(map[int]string),
+		Required:  make(map[string]int),
+		MaxCounts: make(map[string]int),
+		Excluded:  []string{},
+		NotAt:     make(map[int][]string),
+	}
+	notAt := make(map[int]map[string]bool)
+	addNotAt := func(position int, letter string) {
+		if notAt[position] == nil {
+			notAt[position] = make(map[string]bool)
+		}
+		notAt[position][letter] = true
+	}
+
+	for _, guess := range guesses {
+		found := make(map[string]int)
+		capped := make(map[string]bool)
+
+		for i, letter := range guess.Result {
+			position := i + 1
+			switch letter.Status {
+			case "correct":
+				constraints.Fixed[position] = letter.Letter
+				found[letter.Letter]++
+			case "present":
+				addNotAt(position, letter.Letter)
+				found[letter.Letter]++
+			case "absent":
+				addNotAt(position, letter.Letter)
+				capped[letter.Letter] = true
+			}
+		}
+
+		for letter, count := range found {
+			if count > constraints.Required[letter] {
+				constraints.Required[letter] = count
+			}
+		}
+		for letter := range capped {
+			count := found[letter]
+			if existing, ok := constraints.MaxCounts[letter]; !ok || count < existing {
+				constraints.MaxCounts[letter] = count
+			}
+		}
+	}
+
+	// Letters capped at zero are excluded entirely rather than reported as capped
+	for letter, count := range constraints.MaxCounts {
+		if count == 0 {
+			constraints.Excluded = append(constraints.Excluded, letter)
+			delete(constraints.MaxCounts, letter)
+		}
+	}
+	sort.Strings(constraints.Excluded)
+
+	for position, letters := range notAt {
+		for letter := range letters {
+			// A letter fixed at a position is trivially allowed there
+			if constraints.Fixed[position] == letter {
+				continue
+			}
+			constraints.NotAt[position] = append(constraints.NotAt[position], letter)
+		}
+		sort.Strings(constraints.NotAt[position])
+	}
+
+	return constraints
+}
+
+// BoardPosition is what a game's guesses reveal about one position of the target word
+type BoardPosition struct {
+	Position int      `json:"position"`          // 1-based
+	Correct  string   `json:"correct,omitempty"` // Confirmed letter, if any
+	RuledOut []string `json:"ruled_out"`         // Letters known not to be here, sorted
+	Unknown  bool     `json:"unknown"`           // No letter confirmed yet
+}
+
+// BuildBoard summarises the board position by position for a word of the
+// given length. A letter is ruled out at a position once it scored present or
+// absent there, or once it is known not to appear in the word at all.
+func BuildBoard(guesses []Guess, length int) []BoardPosition {
+	constraints := DeriveConstraints(guesses)
+
+	board := make([]BoardPosition, length)
+	for i := range board {
+		position := i + 1
+		correct := constraints.Fixed[position]
+
+		ruledOut := append([]string{}, constraints.NotAt[position]...)
+		for _, letter := range constraints.Excluded {
+			if letter != correct && !containsString(ruledOut, letter) {
+				ruledOut = append(ruledOut, letter)
+			}
+		}
+		sort.Strings(ruledOut)
+
+		board[i] = BoardPosition{
+			Position: position,
+			Correct:  correct,
+			RuledOut: ruledOut,
+			Unknown:  correct == "",
+		}
+	}
+	return board
+}
+
+// Allows reports whether word is consistent with the constraints. The word must
+// use the same case as the guessed letters (upper case).
+func (c BoardConstraints) Allows(word string) bool {
+	letters := []rune(word)
+	counts := make(map[string]int)
+	for _, r := range letters {
+		counts[string(r)]++
+	}
+
+	for position, letter := range c.Fixed {
+		if position > len(letters) || string(letters[position-1]) != letter {
+			return false
+		}
+	}
+	for position, excluded := range c.NotAt {
+		if position <= len(letters) && containsString(excluded, string(letters[position-1])) {
+			return false
+		}
+	}
+	for _, letter := range c.Excluded {
+		if counts[letter] > 0 {
+			return false
+		}
+	}
+	for letter, min := range c.Required {
+		if counts[letter] < min {
+			return false
+		}
+	}
+	for letter, max := range c.MaxCounts {
+		if counts[letter] > max {
+			return false
+		}
+	}
+	return true
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// guessesFor evaluates each word against target and returns them as guesses
+func guessesFor(target string, words ...string) []Guess {
+	guesses := make([]Guess, len(words))
+	for i, word := range words {
+		guesses[i] = Guess{
+			GuessWord:   word,
+			GuessNumber: i + 1,
+			Result:      EvaluateGuess(word, target),
+		}
+	}
+	return guesses
+}
+
+func TestDeriveConstraints(t *testing.T) {
+	// Target HELLO:
+	//   WORLD -> W absent, O present, R absent, L correct, D absent
+	//   LLAMA -> L present, L present, A absent, M absent, A absent
+	//   LOLLY -> L absent, O present, L correct, L correct, Y absent
+	constraints := DeriveConstraints(guessesFor("HELLO", "WORLD", "LLAMA", "LOLLY"))
+
+	expectedFixed := map[int]string{3: "L", 4: "L"}
+	if !reflect.DeepEqual(constraints.Fixed, expectedFixed) {
+		t.Errorf("Expected fixed %v, got %v", expectedFixed, constraints.Fixed)
+	}
+
+	expectedRequired := map[string]int{"L": 2, "O": 1}
+	if !reflect.DeepEqual(constraints.Required, expectedRequired) {
+		t.Errorf("Expected required %v, got %v", expectedRequired, constraints.Required)
+	}
+
+	// L is both required (at least 2) and capped (LOLLY's first L was absent)
+	expectedMax := map[string]int{"L": 2}
+	if !reflect.DeepEqual(constraints.MaxCounts, expectedMax) {
+		t.Errorf("Expected max counts %v, got %v", expectedMax, constraints.MaxCounts)
+	}
+
+	expectedExcluded := []string{"A", "D", "M", "R", "W", "Y"}
+	if !reflect.DeepEqual(constraints.Excluded, expectedExcluded) {
+		t.Errorf("Expected excluded %v, got %v", expectedExcluded, constraints.Excluded)
+	}
+
+	expectedNotAt := map[int][]string{
+		1: {"L", "W"},
+		2: {"L", "O"},
+		3: {"A", "R"},
+		4: {"M"},
+		5: {"A", "D", "Y"},
+	}
+	if !reflect.DeepEqual(constraints.NotAt, expectedNotAt) {
+		t.Errorf("Expected not-at %v, got %v", expectedNotAt, constraints.NotAt)
+	}
+}
+
+func TestBuildBoard(t *testing.T) {
+	// Target CRANE:
+	//   SLATE -> S absent, L absent, A correct, T absent, E correct
+	//   TRACE -> T absent, R correct, A correct, C present, E correct
+	first := BuildBoard(guessesFor("CRANE", "SLATE"), 5)
+	expected := []BoardPosition{
+		{Position: 1, RuledOut: []string{"L", "S", "T"}, Unknown: true},
+		{Position: 2, RuledOut: []string{"L", "S", "T"}, Unknown: true},
+		{Position: 3, Correct: "A", RuledOut: []string{"L", "S", "T"}},
+		{Position: 4, RuledOut: []string{"L", "S", "T"}, Unknown: true},
+		{Position: 5, Correct: "E", RuledOut: []string{"L", "S", "T"}},
+	}
+	if !reflect.DeepEqual(first, expected) {
+		t.Errorf("Expected board %+v, got %+v", expected, first)
+	}
+
+	// The second guess confirms R and rules C out at position 4 on top of
+	// what the first guess ruled out
+	second := BuildBoard(guessesFor("CRANE", "SLATE", "TRACE"), 5)
+	if second[1].Correct != "R" || second[1].Unknown {
+		t.Errorf("Expected R confirmed at position 2, got %+v", second[1])
+	}
+	if want := []string{"C", "L", "S", "T"}; !reflect.DeepEqual(second[3].RuledOut, want) || !second[3].Unknown {
+		t.Errorf("Expected %v ruled out at unknown position 4, got %+v", want, second[3])
+	}
+	if want := []string{"L", "S", "T"}; !reflect.DeepEqual(second[0].RuledOut, want) {
+		t.Errorf("Expected %v ruled out at position 1, got %+v", want, second[0])
+	}
+
+	// Without guesses every position is unknown with nothing ruled out
+	for _, position := range BuildBoard(nil, 5) {
+		if !position.Unknown || position.Correct != "" || position.RuledOut == nil || len(position.RuledOut) != 0 {
+			t.Errorf("Expected an empty unknown position, got %+v", position)
+		}
+	}
+}
+
+func TestDeriveConstraintsNoGuesses(t *testing.T) {
+	constraints := DeriveConstraints(nil)
+
+	if len(constraints.Fixed) != 0 || len(constraints.Required) != 0 ||
+		len(constraints.MaxCounts) != 0 || len(constraints.Excluded) != 0 || len(constraints.NotAt) != 0 {
+		t.Errorf("Expected empty constraints, got %+v", constraints)
+	}
+}
+
+func TestBoardConstraintsAllows(t *testing.T) {
+	// Target HELLO; WORLD leaves W, R, D excluded, O somewhere other than 2, L at 4
+	constraints := DeriveConstraints(guessesFor("HELLO", "WORLD"))
+
+	tests := []struct {
+		word     string
+		expected bool
+	}{
+		{"HELLO", true},
+		{"CELLO", true},  // Unaffected by the feedback
+		{"CRANE", false}, // R is excluded
+		{"LOLLY", false}, // O cannot be at position 2
+		{"HOTEL", false}, // L must be at position 4
+		{"BELLY", false}, // O is required
+	}
+
+	for _, tt := range tests {
+		if got := constraints.Allows(tt.word); got != tt.expected {
+			t.Errorf("Allows(%s) = %v, expected %v", tt.word, got, tt.expected)
+		}
+	}
+
+	// LOLLY against HELLO has an absent L, capping L at exactly two
+	capped := DeriveConstraints(guessesFor("HELLO", "LOLLY"))
+	if capped.Allows("LLLLO") {
+		t.Error("Expected word exceeding a capped letter count to be rejected")
+	}
+
+	if !DeriveConstraints(nil).Allows("QUICK") {
+		t.Error("Expected any word to be allowed without guesses")
+	}
+}
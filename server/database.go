@@ -1,33 +1,52 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	"syscall"
 	"time"
-
-	_ "github.com/lib/pq"
 )
 
 // DB wraps the database connection with additional functionality
 type DB struct {
 	*sql.DB
-	config *DatabaseConfig
+	config  *DatabaseConfig
+	dialect Dialect
 }
 
 // NewDB creates a new database connection with proper configuration
 func NewDB(config *DatabaseConfig) (*DB, error) {
+	dialect, err := NewDialect(config.Backend)
+	if err != nil {
+		return nil, err
+	}
+	if !driverRegistered(dialect.DriverName()) {
+		return nil, fmt.Errorf("database backend %q is not compiled in (build with -tags %s)", dialect.Name(), dialect.Name())
+	}
+
 	// Open database connection
-	db, err := sql.Open("postgres", config.ConnectionString())
+	db, err := sql.Open(dialect.DriverName(), dialect.DataSourceName(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(config.MaxOpenConns)
-	db.SetMaxIdleConns(config.MaxIdleConns)
-	db.SetConnMaxLifetime(config.ConnMaxLifetime)
-	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+	if dialect.SingleConnection() {
+		// Keep the one connection open for good: closing it would discard an
+		// in-memory database
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+	} else {
+		db.SetMaxOpenConns(config.MaxOpenConns)
+		db.SetMaxIdleConns(config.MaxIdleConns)
+		db.SetConnMaxLifetime(config.ConnMaxLifetime)
+		db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+	}
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
@@ -35,14 +54,29 @@ func NewDB(config *DatabaseConfig) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Printf("Connected to database: %s:%d/%s", config.Host, config.Port, config.Name)
+	if dialect.Name() == BackendSQLite {
+		log.Printf("Connected to SQLite database: %s", dialect.DataSourceName(config))
+	} else {
+		log.Printf("Connected to database: %s:%d/%s", config.Host, config.Port, config.Name)
+	}
 
 	return &DB{
-		DB:     db,
-		config: config,
+		DB:      db,
+		config:  config,
+		dialect: dialect,
 	}, nil
 }
 
+// driverRegistered reports whether a database/sql driver with the given name is linked in
+func driverRegistered(name string) bool {
+	for _, driver := range sql.Drivers() {
+		if driver == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	log.Println("Closing database connection")
@@ -64,6 +98,53 @@ func (db *DB) Config() *DatabaseConfig {
 	return db.config
 }
 
+// Dialect returns the SQL dialect of the storage backend
+func (db *DB) Dialect() Dialect {
+	return db.dialect
+}
+
+// Exec executes a query written with $N placeholders in the backend's dialect
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.Exec(db.dialect.Rebind(query), args...)
+}
+
+// Query executes a query written with $N placeholders in the backend's dialect
+func (db *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.Query(db.dialect.Rebind(query), args...)
+}
+
+// QueryRow executes a query written with $N placeholders in the backend's dialect
+func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRow(db.dialect.Rebind(query), args...)
+}
+
+// executor returns tx as a dbExecutor that rebinds queries for the backend's dialect
+func (db *DB) executor(tx *sql.Tx) dbExecutor {
+	return &dialectTx{Tx: tx, dialect: db.dialect}
+}
+
+// dialectTx is a transaction that rebinds queries for its backend's dialect
+type dialectTx struct {
+	*sql.Tx
+	dialect Dialect
+}
+
+func (tx *dialectTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.Rebind(query), args...)
+}
+
+func (tx *dialectTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.Rebind(query), args...)
+}
+
+func (tx *dialectTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.Rebind(query), args...)
+}
+
+func (tx *dialectTx) Dialect() Dialect {
+	return tx.dialect
+}
+
 // HealthCheck performs a comprehensive health check of the database
 func (db *DB) HealthCheck() error {
 	// Check basic connectivity
@@ -85,15 +166,45 @@ func (db *DB) HealthCheck() error {
 	return nil
 }
 
-// BeginTx starts a new transaction with the given options
-func (db *DB) BeginTx(opts *sql.TxOptions) (*sql.Tx, error) {
-	return db.DB.BeginTx(nil, opts)
+// BeginTx starts a new transaction with the given options. The transaction is
+// rolled back if ctx is cancelled before it is committed.
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return db.DB.BeginTx(ctx, opts)
+}
+
+// WithTransaction runs fn inside a transaction, committing if fn returns nil
+// and rolling back if it returns an error or panics
+func (db *DB) WithTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Printf("Failed to roll back transaction: %v", rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 // ExecContext executes a query without returning any rows with logging
 func (db *DB) ExecWithLog(query string, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
-	result, err := db.DB.Exec(query, args...)
+	result, err := db.Exec(query, args...)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -108,7 +219,7 @@ func (db *DB) ExecWithLog(query string, args ...interface{}) (sql.Result, error)
 // QueryWithLog executes a query that returns rows with logging
 func (db *DB) QueryWithLog(query string, args ...interface{}) (*sql.Rows, error) {
 	start := time.Now()
-	rows, err := db.DB.Query(query, args...)
+	rows, err := db.Query(query, args...)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -123,30 +234,32 @@ func (db *DB) QueryWithLog(query string, args ...interface{}) (*sql.Rows, error)
 // QueryRowWithLog executes a query that returns at most one row with logging
 func (db *DB) QueryRowWithLog(query string, args ...interface{}) *sql.Row {
 	start := time.Now()
-	row := db.DB.QueryRow(query, args...)
+	row := db.QueryRow(query, args...)
 	duration := time.Since(start)
 
 	log.Printf("Query executed (took %v): %s", duration, query)
 	return row
 }
 
-// Migrate runs database migrations (placeholder for future migration system)
+// Migrate applies the backend's built-in schema and upgrades, if it has them,
+// and verifies that the required tables exist
 func (db *DB) Migrate() error {
-	// This is a placeholder for a more sophisticated migration system
-	// For now, we'll just verify that the required tables exist
-	
-	tables := []string{"games", "guesses", "players", "game_stats"}
+	if schema := db.dialect.Schema(); schema != "" {
+		if _, err := db.DB.Exec(schema); err != nil {
+			return fmt.Errorf("failed to apply %s schema: %w", db.dialect.Name(), err)
+		}
+	}
+	if migrations := db.dialect.Migrations(); migrations != "" {
+		if _, err := db.DB.Exec(migrations); err != nil {
+			return fmt.Errorf("failed to apply %s migrations: %w", db.dialect.Name(), err)
+		}
+	}
+
+	tables := []string{"games", "guesses", "answers", "players", "game_stats"}
 	
 	for _, table := range tables {
 		var exists bool
-		query := `
-			SELECT EXISTS (
-				SELECT FROM information_schema.tables 
-				WHERE table_schema = 'public' 
-				AND table_name = $1
-			)`
-		
-		err := db.QueryRow(query, table).Scan(&exists)
+		err := db.QueryRow(db.dialect.TableExistsQuery(), table).Scan(&exists)
 		if err != nil {
 			return fmt.Errorf("failed to check if table %s exists: %w", table, err)
 		}
@@ -173,3 +286,23 @@ func (db *DB) LogConnectionStats() {
 		stats.MaxOpenConnections,
 	)
 }
+
+// isDatabaseUnavailable reports whether err means the database could not be
+// reached, as opposed to a failed query: network and connection errors,
+// closed connections, and backend-specific errors such as PostgreSQL
+// connection exceptions (class 08) or shutdown/startup errors.
+func isDatabaseUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return postgresDialect{}.IsUnavailable(err) || sqliteDialect{}.IsUnavailable(err)
+}
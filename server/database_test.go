@@ -1,8 +1,16 @@
 package main
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
 	"testing"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 func setupTestDB(t *testing.T) *DB {
@@ -51,7 +59,7 @@ func TestGameRepository(t *testing.T) {
 	repo := NewGameRepository(db)
 
 	// Test CreateGame
-	game, err := repo.CreateGame("HELLO", 6)
+	game, err := repo.CreateGame(UUIDGenerator{}.NewID(), "HELLO", 6)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
@@ -94,6 +102,7 @@ func TestGameRepository(t *testing.T) {
 	game.IsWon = true
 	game.CompletedAt = &now
 	game.GuessCount = 3
+	game.KeyboardState = KeyboardState{"H": "correct", "Z": "absent"}
 
 	err = repo.UpdateGame(game)
 	if err != nil {
@@ -114,6 +123,9 @@ func TestGameRepository(t *testing.T) {
 	if updatedGame.GuessCount != 3 {
 		t.Errorf("Expected guess count 3, got %d", updatedGame.GuessCount)
 	}
+	if updatedGame.KeyboardState["H"] != "correct" || updatedGame.KeyboardState["Z"] != "absent" {
+		t.Errorf("Expected keyboard state to be stored, got %v", updatedGame.KeyboardState)
+	}
 
 	// Test DeleteGame
 	err = repo.DeleteGame(game.ID)
@@ -136,7 +148,7 @@ func TestGuessRepository(t *testing.T) {
 	guessRepo := NewGuessRepository(db)
 
 	// Create a test game first
-	game, err := gameRepo.CreateGame("WORLD", 6)
+	game, err := gameRepo.CreateGame(UUIDGenerator{}.NewID(), "WORLD", 6)
 	if err != nil {
 		t.Fatalf("Failed to create test game: %v", err)
 	}
@@ -151,7 +163,7 @@ func TestGuessRepository(t *testing.T) {
 		{Letter: "O", Status: "correct"},
 	}
 
-	guess, err := guessRepo.CreateGuess(game.ID, "HELLO", 1, result)
+	guess, err := guessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "HELLO", 1, result)
 	if err != nil {
 		t.Fatalf("Failed to create guess: %v", err)
 	}
@@ -201,7 +213,7 @@ func TestGuessRepository(t *testing.T) {
 		{Letter: "D", Status: "correct"},
 	}
 
-	guess2, err := guessRepo.CreateGuess(game.ID, "WORLD", 2, result2)
+	guess2, err := guessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "WORLD", 2, result2)
 	if err != nil {
 		t.Fatalf("Failed to create second guess: %v", err)
 	}
@@ -251,7 +263,7 @@ func TestGameWithGuessesIntegration(t *testing.T) {
 	gameRepo := NewGameRepository(db)
 
 	// Create a game
-	game, err := gameRepo.CreateGame("CRANE", 6)
+	game, err := gameRepo.CreateGame(UUIDGenerator{}.NewID(), "CRANE", 6)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
@@ -261,13 +273,13 @@ func TestGameWithGuessesIntegration(t *testing.T) {
 
 	// Add some guesses
 	result1 := EvaluateGuess("HELLO", "CRANE")
-	_, err = guessRepo.CreateGuess(game.ID, "HELLO", 1, result1)
+	_, err = guessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "HELLO", 1, result1)
 	if err != nil {
 		t.Fatalf("Failed to create first guess: %v", err)
 	}
 
 	result2 := EvaluateGuess("CRANE", "CRANE")
-	_, err = guessRepo.CreateGuess(game.ID, "CRANE", 2, result2)
+	_, err = guessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "CRANE", 2, result2)
 	if err != nil {
 		t.Fatalf("Failed to create second guess: %v", err)
 	}
@@ -294,3 +306,143 @@ func TestGameWithGuessesIntegration(t *testing.T) {
 		t.Error("Second guess should have guess number 2")
 	}
 }
+
+func TestTransactorRollback(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	transactor := NewTransactor(db)
+	gameRepo := NewGameRepository(db)
+
+	var gameID string
+	err := transactor.WithinTransaction(func(txGameRepo GameRepositoryInterface, txGuessRepo GuessRepositoryInterface) error {
+		game, err := txGameRepo.CreateGame(UUIDGenerator{}.NewID(), "HELLO", 6)
+		if err != nil {
+			return err
+		}
+		gameID = game.ID
+		return errors.New("force rollback")
+	})
+	if err == nil {
+		t.Fatal("Expected error from transaction")
+	}
+
+	if _, err := gameRepo.GetGame(gameID); err == nil {
+		t.Error("Game created in a rolled back transaction should not exist")
+	}
+
+	// A successful transaction commits both writes
+	err = transactor.WithinTransaction(func(txGameRepo GameRepositoryInterface, txGuessRepo GuessRepositoryInterface) error {
+		game, err := txGameRepo.CreateGame(UUIDGenerator{}.NewID(), "HELLO", 6)
+		if err != nil {
+			return err
+		}
+		gameID = game.ID
+		_, err = txGuessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "WORLD", 1, EvaluateGuess("WORLD", "HELLO"))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Transaction should commit: %v", err)
+	}
+	defer gameRepo.DeleteGame(gameID)
+
+	gameWithGuesses, err := gameRepo.GetGameWithGuesses(gameID)
+	if err != nil {
+		t.Fatalf("Committed game should exist: %v", err)
+	}
+	if len(gameWithGuesses.Guesses) != 1 {
+		t.Errorf("Expected 1 committed guess, got %d", len(gameWithGuesses.Guesses))
+	}
+}
+
+func TestGameRepositoryGetStatsByDifficulty(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewGameRepository(db)
+
+	before, err := repo.GetStatsByDifficulty()
+	if err != nil {
+		t.Fatalf("Failed to get difficulty stats: %v", err)
+	}
+	baseline := make(map[string]DifficultyTierStats)
+	for _, tier := range before {
+		baseline[tier.Tier] = tier
+	}
+
+	seed := func(difficulty float64, won bool) {
+		game, err := repo.CreateGame(UUIDGenerator{}.NewID(), "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		t.Cleanup(func() { repo.DeleteGame(game.ID) })
+
+		now := time.Now()
+		game.IsCompleted = true
+		game.IsWon = won
+		game.CompletedAt = &now
+		if err := repo.UpdateGame(game); err != nil {
+			t.Fatalf("Failed to update game: %v", err)
+		}
+		if _, err := db.Exec("INSERT INTO game_stats (game_id, word_difficulty) VALUES ($1, $2)", game.ID, difficulty); err != nil {
+			t.Fatalf("Failed to insert game stats: %v", err)
+		}
+	}
+
+	seed(0.1, true)
+	seed(0.5, true)
+	seed(0.5, false)
+	seed(0.9, false)
+
+	after, err := repo.GetStatsByDifficulty()
+	if err != nil {
+		t.Fatalf("Failed to get difficulty stats: %v", err)
+	}
+
+	added := map[string][2]int{
+		DifficultyEasy:   {1, 1},
+		DifficultyMedium: {2, 1},
+		DifficultyHard:   {1, 0},
+	}
+	for _, tier := range after {
+		want := added[tier.Tier]
+		if tier.GamesPlayed-baseline[tier.Tier].GamesPlayed != want[0] {
+			t.Errorf("Tier %s: expected %d more games played", tier.Tier, want[0])
+		}
+		if tier.GamesWon-baseline[tier.Tier].GamesWon != want[1] {
+			t.Errorf("Tier %s: expected %d more games won", tier.Tier, want[1])
+		}
+		delete(added, tier.Tier)
+	}
+	if len(added) != 0 {
+		t.Errorf("Missing tiers in difficulty stats: %v", added)
+	}
+}
+
+func TestIsDatabaseUnavailable(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Nil", nil, false},
+		{"Connection refused", fmt.Errorf("failed to get game: %w", dialErr), true},
+		{"Bad connection", fmt.Errorf("failed to create game: %w", driver.ErrBadConn), true},
+		{"Connection done", sql.ErrConnDone, true},
+		{"Postgres connection exception", &pq.Error{Code: "08006"}, true},
+		{"Postgres shutting down", &pq.Error{Code: "57P01"}, true},
+		{"Postgres unique violation", &pq.Error{Code: "23505"}, false},
+		{"No rows", sql.ErrNoRows, false},
+		{"Game not found", errors.New("game not found: abc"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDatabaseUnavailable(tt.err); got != tt.expected {
+				t.Errorf("isDatabaseUnavailable(%v) = %v, expected %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FileDefinitionProvider serves word definitions loaded from a local file.
+// Each line has the form "word<TAB>definition"; blank lines and lines without
+// a tab are ignored.
+type FileDefinitionProvider struct {
+	definitions map[string]string // Lowercase word -> definition
+	filePath    string
+}
+
+// NewFileDefinitionProvider loads definitions from the given file
+func NewFileDefinitionProvider(filePath string) (*FileDefinitionProvider, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open definitions file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	provider := &FileDefinitionProvider{
+		definitions: make(map[string]string),
+		filePath:    filePath,
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word, definition, found := strings.Cut(scanner.Text(), "\t")
+		word = strings.ToLower(strings.TrimSpace(word))
+		definition = strings.TrimSpace(definition)
+		if !found || word == "" || definition == "" {
+			continue
+		}
+		provider.definitions[word] = definition
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading definitions file: %w", err)
+	}
+
+	return provider, nil
+}
+
+// Definition returns the definition for word (case-insensitive)
+func (p *FileDefinitionProvider) Definition(word string) (string, bool) {
+	definition, ok := p.definitions[strings.ToLower(word)]
+	return definition, ok
+}
+
+// Size returns the number of loaded definitions
+func (p *FileDefinitionProvider) Size() int {
+	return len(p.definitions)
+}
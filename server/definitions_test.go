@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileDefinitionProvider(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "definitions.txt")
+
+	content := "hello\tA greeting\n  CRANE \t A large bird \n\nno-tab-line\nempty\t\n"
+	err := os.WriteFile(testFile, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	provider, err := NewFileDefinitionProvider(testFile)
+	if err != nil {
+		t.Fatalf("Failed to create FileDefinitionProvider: %v", err)
+	}
+
+	if provider.Size() != 2 {
+		t.Errorf("Expected 2 definitions, got %d", provider.Size())
+	}
+
+	tests := []struct {
+		word       string
+		definition string
+		found      bool
+	}{
+		{"hello", "A greeting", true},
+		{"HELLO", "A greeting", true},
+		{"crane", "A large bird", true},
+		{"empty", "", false},
+		{"no-tab-line", "", false},
+		{"world", "", false},
+	}
+
+	for _, tt := range tests {
+		definition, found := provider.Definition(tt.word)
+		if found != tt.found || definition != tt.definition {
+			t.Errorf("Definition(%q) = (%q, %v), expected (%q, %v)", tt.word, definition, found, tt.definition, tt.found)
+		}
+	}
+}
+
+func TestFileDefinitionProviderMissingFile(t *testing.T) {
+	_, err := NewFileDefinitionProvider(filepath.Join(t.TempDir(), "missing.txt"))
+	if err == nil {
+		t.Error("Expected error for missing definitions file")
+	}
+}
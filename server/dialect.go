@@ -0,0 +1,251 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Storage backends accepted in DB_BACKEND
+const (
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+)
+
+// sqliteDriverName is the database/sql driver used for the SQLite backend. It
+// is registered by sqlite_driver.go, which is only built with -tags sqlite.
+const sqliteDriverName = "sqlite3"
+
+//go:embed sqlite_schema.sql
+var sqliteSchema string
+
+//go:embed postgres_migrations.sql
+var postgresMigrations string
+
+// Dialect captures what differs between storage backends: how to connect,
+// how queries are written and how driver errors are classified. Repositories
+// write queries with PostgreSQL-style $N placeholders and portable SQL
+// (CURRENT_TIMESTAMP, RETURNING, ON CONFLICT); the dialect rebinds them.
+type Dialect interface {
+	// Name returns the backend name, as accepted in DB_BACKEND
+	Name() string
+	// DriverName returns the database/sql driver name
+	DriverName() string
+	// DataSourceName returns the connection string for config
+	DataSourceName(config *DatabaseConfig) string
+	// Rebind rewrites $N placeholders into the backend's placeholder syntax
+	Rebind(query string) string
+	// TableExistsQuery returns a query taking a table name and selecting whether it exists
+	TableExistsQuery() string
+	// TileCountsQuery returns a query taking a board length and selecting
+	// (position, status, count) rows over the tiles of every stored guess
+	TileCountsQuery() string
+	// ForUpdate returns the clause that makes a SELECT lock the rows it reads
+	// until the transaction ends; empty when writers never run concurrently
+	ForUpdate() string
+	// Schema returns DDL that Migrate applies before checking tables; empty
+	// when the schema is managed externally
+	Schema() string
+	// Migrations returns idempotent DDL that Migrate applies after Schema to
+	// upgrade a database created by an earlier version; empty when Schema
+	// always yields the current schema
+	Migrations() string
+	// SingleConnection reports whether the pool must be limited to one connection
+	SingleConnection() bool
+	// IsUniqueViolation reports whether err is a unique constraint violation
+	IsUniqueViolation(err error) bool
+	// IsUnavailable reports whether err means the database could not be reached
+	IsUnavailable(err error) bool
+}
+
+// NewDialect returns the dialect for the named backend. An empty name means PostgreSQL.
+func NewDialect(backend string) (Dialect, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", BackendPostgres:
+		return postgresDialect{}, nil
+	case BackendSQLite:
+		return sqliteDialect{}, nil
+	default:
+		return nil, fmt.Errorf("unknown database backend %q (expected %q or %q)", backend, BackendPostgres, BackendSQLite)
+	}
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation from
+// any supported backend. Each driver has its own error type, so checking every
+// dialect cannot misclassify an error.
+func isUniqueViolation(err error) bool {
+	return postgresDialect{}.IsUniqueViolation(err) || sqliteDialect{}.IsUniqueViolation(err)
+}
+
+// tileCountsQuery tallies tile statuses per position in the database, for
+// results stored in either format. Its verbs are the dialect's expressions
+// for the stored result's JSON type, the status of the tile at p.position in
+// a JSON result, and the text of a compact result.
+const tileCountsQuery = `
+	WITH RECURSIVE positions(position) AS (
+		SELECT 1
+		UNION ALL
+		SELECT position + 1 FROM positions WHERE position < $1
+	),
+	tiles AS (
+		SELECT p.position,
+			CASE %[1]s
+				WHEN 'array' THEN %[2]s
+				ELSE CASE substr(%[3]s, 2 * p.position, 1)
+					WHEN 'G' THEN 'correct'
+					WHEN 'Y' THEN 'present'
+					WHEN 'B' THEN 'absent'
+				END
+			END AS status
+		FROM guesses g
+		CROSS JOIN positions p
+	)
+	SELECT position, status, COUNT(*)
+	FROM tiles
+	WHERE status IS NOT NULL
+	GROUP BY position, status`
+
+// postgresDialect is the default PostgreSQL backend
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string       { return BackendPostgres }
+func (postgresDialect) DriverName() string { return "postgres" }
+
+func (postgresDialect) DataSourceName(config *DatabaseConfig) string {
+	return config.ConnectionString()
+}
+
+func (postgresDialect) Rebind(query string) string { return query }
+
+func (postgresDialect) TableExistsQuery() string {
+	return `
+		SELECT EXISTS (
+			SELECT FROM information_schema.tables
+			WHERE table_schema = 'public'
+			AND table_name = $1
+		)`
+}
+
+func (postgresDialect) TileCountsQuery() string {
+	return fmt.Sprintf(tileCountsQuery,
+		"jsonb_typeof(g.result)",
+		"g.result -> (p.position - 1) ->> 'status'",
+		"g.result #>> '{}'")
+}
+
+func (postgresDialect) ForUpdate() string { return " FOR UPDATE" }
+
+// Schema is empty: the PostgreSQL schema is created by db/init
+func (postgresDialect) Schema() string { return "" }
+
+// Migrations adds the columns, tables and indexes introduced since a database
+// was initialized, which db/init never revisits
+func (postgresDialect) Migrations() string { return postgresMigrations }
+
+func (postgresDialect) SingleConnection() bool { return false }
+
+func (postgresDialect) IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" // unique_violation
+}
+
+// IsUnavailable matches connection exceptions (class 08) and shutdown/startup errors
+func (postgresDialect) IsUnavailable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "57P01", "57P02", "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+		return true
+	}
+	return pqErr.Code.Class() == "08"
+}
+
+// sqliteDialect is an embedded SQLite backend for lightweight local deployments
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string       { return BackendSQLite }
+func (sqliteDialect) DriverName() string { return sqliteDriverName }
+
+// DataSourceName enables foreign keys, which SQLite leaves off by default, so
+// deleting a game cascades to its guesses
+func (sqliteDialect) DataSourceName(config *DatabaseConfig) string {
+	path := config.Path
+	if path == "" {
+		path = ":memory:"
+	}
+	return fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", path)
+}
+
+// Rebind rewrites $N as ?N, SQLite's numbered placeholder, so arguments that
+// are referenced more than once or out of order still bind correctly. Text
+// inside string literals and quoted identifiers is left alone; a doubled quote
+// within them is an escaped quote and does not end them.
+func (sqliteDialect) Rebind(query string) string {
+	if !strings.Contains(query, "$") {
+		return query
+	}
+
+	var rebound strings.Builder
+	var quote byte // The quote character of the literal or identifier being copied; 0 outside
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0 // A doubled quote closes and at once reopens it
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '$' && i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9':
+			rebound.WriteByte('?')
+			continue
+		}
+		rebound.WriteByte(c)
+	}
+	return rebound.String()
+}
+
+func (sqliteDialect) TableExistsQuery() string {
+	return `SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = $1)`
+}
+
+// TileCountsQuery reads results as text: the driver stores them as BLOBs,
+// which SQLite's JSON functions would otherwise parse as binary JSONB
+func (sqliteDialect) TileCountsQuery() string {
+	return fmt.Sprintf(tileCountsQuery,
+		"json_type(CAST(g.result AS TEXT))",
+		"json_extract(CAST(g.result AS TEXT), '$[' || (p.position - 1) || '].status')",
+		"json_extract(CAST(g.result AS TEXT), '$')")
+}
+
+// ForUpdate is empty: SQLite has no row locks, and with a single connection
+// one transaction runs at a time
+func (sqliteDialect) ForUpdate() string { return "" }
+
+func (sqliteDialect) Schema() string { return sqliteSchema }
+
+// Migrations is empty: the SQLite backend has only ever had the current schema
+func (sqliteDialect) Migrations() string { return "" }
+
+// SingleConnection is true because every connection to an in-memory database
+// opens a separate, empty database, and SQLite serializes writers anyway
+func (sqliteDialect) SingleConnection() bool { return true }
+
+// SQLite drivers report constraint failures in the error text rather than a
+// portable code, so errors are classified by message
+func (sqliteDialect) IsUniqueViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+func (sqliteDialect) IsUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "unable to open database file") || strings.Contains(message, "database is locked")
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestNewDialect(t *testing.T) {
+	tests := []struct {
+		backend    string
+		want       string
+		shouldFail bool
+	}{
+		{"", BackendPostgres, false},
+		{"postgres", BackendPostgres, false},
+		{"SQLite", BackendSQLite, false},
+		{"mysql", "", true},
+	}
+
+	for _, tt := range tests {
+		dialect, err := NewDialect(tt.backend)
+		if tt.shouldFail {
+			if err == nil {
+				t.Errorf("Expected error for backend %q", tt.backend)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for backend %q: %v", tt.backend, err)
+			continue
+		}
+		if dialect.Name() != tt.want {
+			t.Errorf("NewDialect(%q) = %s, want %s", tt.backend, dialect.Name(), tt.want)
+		}
+	}
+}
+
+func TestDialectRebind(t *testing.T) {
+	query := "INSERT INTO games (target_word, max_guesses) VALUES ($1, $2), ($3, $1) RETURNING id"
+
+	if got := (postgresDialect{}).Rebind(query); got != query {
+		t.Errorf("Postgres should leave queries unchanged, got %q", got)
+	}
+
+	want := "INSERT INTO games (target_word, max_guesses) VALUES (?1, ?2), (?3, ?1) RETURNING id"
+	if got := (sqliteDialect{}).Rebind(query); got != want {
+		t.Errorf("SQLite Rebind = %q, want %q", got, want)
+	}
+
+	// A $ that is not a placeholder is kept
+	if got := (sqliteDialect{}).Rebind("SELECT '$' || $10"); got != "SELECT '$' || ?10" {
+		t.Errorf("Unexpected rebind: %q", got)
+	}
+
+	// Placeholders inside string literals and quoted identifiers are text
+	quoted := map[string]string{
+		"SELECT 'costs $1' FROM games WHERE id = $1":    "SELECT 'costs $1' FROM games WHERE id = ?1",
+		"SELECT 'it''s $2', $1":                         "SELECT 'it''s $2', ?1",
+		`SELECT "col$1" FROM games WHERE id = $2`:       `SELECT "col$1" FROM games WHERE id = ?2`,
+		"SELECT '' || $1 || 'a \"$2\" b' || \"x'$3\"": "SELECT '' || ?1 || 'a \"$2\" b' || \"x'$3\"",
+	}
+	for query, want := range quoted {
+		if got := (sqliteDialect{}).Rebind(query); got != want {
+			t.Errorf("Rebind(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestSQLiteDataSourceName(t *testing.T) {
+	dialect := sqliteDialect{}
+	if got := dialect.DataSourceName(&DatabaseConfig{}); got != "file::memory:?_foreign_keys=on&_busy_timeout=5000" {
+		t.Errorf("Unexpected in-memory DSN: %s", got)
+	}
+	if got := dialect.DataSourceName(&DatabaseConfig{Path: "/tmp/wordle.db"}); got != "file:/tmp/wordle.db?_foreign_keys=on&_busy_timeout=5000" {
+		t.Errorf("Unexpected file DSN: %s", got)
+	}
+}
+
+func TestDialectErrorClassification(t *testing.T) {
+	pqUnique := &pq.Error{Code: "23505"}
+	sqliteUnique := errors.New("UNIQUE constraint failed: guesses.game_id, guesses.guess_number")
+
+	if !(postgresDialect{}).IsUniqueViolation(fmt.Errorf("insert: %w", pqUnique)) {
+		t.Error("Expected wrapped pq 23505 to be a unique violation")
+	}
+	if (postgresDialect{}).IsUniqueViolation(sqliteUnique) {
+		t.Error("Postgres dialect should not classify SQLite errors")
+	}
+	if !(sqliteDialect{}).IsUniqueViolation(sqliteUnique) {
+		t.Error("Expected SQLite unique violation to be classified")
+	}
+	if !isUniqueViolation(pqUnique) || !isUniqueViolation(sqliteUnique) {
+		t.Error("isUniqueViolation should accept errors from every backend")
+	}
+	if isUniqueViolation(errors.New("syntax error")) || isUniqueViolation(nil) {
+		t.Error("Unrelated errors are not unique violations")
+	}
+
+	if !(sqliteDialect{}).IsUnavailable(errors.New("unable to open database file: no such file or directory")) {
+		t.Error("Expected unopenable SQLite file to be unavailable")
+	}
+	if !isDatabaseUnavailable(errors.New("database is locked")) {
+		t.Error("Expected locked SQLite database to be unavailable")
+	}
+	if (sqliteDialect{}).IsUnavailable(sqliteUnique) {
+		t.Error("Constraint failures are not unavailability")
+	}
+}
+
+// The columns and tables of db/init/01-create-tables.sql before migrations
+// existed; everything added since must also be in postgres_migrations.sql
+var initialPostgresSchema = map[string][]string{
+	"players":    {"id", "username", "email", "created_at", "games_played", "games_won", "current_streak", "max_streak"},
+	"games":      {"id", "target_word", "created_at", "completed_at", "is_completed", "is_won", "guess_count", "max_guesses"},
+	"guesses":    {"id", "game_id", "guess_word", "guess_number", "result", "created_at"},
+	"game_stats": {"id", "game_id", "player_id", "word_difficulty", "solve_time_seconds", "created_at"},
+}
+
+func TestPostgresMigrationsCoverSchema(t *testing.T) {
+	schema, err := os.ReadFile(filepath.Join("..", "db", "init", "01-create-tables.sql"))
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	migrations := (postgresDialect{}).Migrations()
+
+	tablePattern := regexp.MustCompile(`(?s)CREATE TABLE IF NOT EXISTS (\w+) \((.*?)\n\);`)
+	columnPattern := regexp.MustCompile(`(?m)^\s+(\w+) `)
+	for _, table := range tablePattern.FindAllStringSubmatch(string(schema), -1) {
+		name, body := table[1], table[2]
+		initial, existed := initialPostgresSchema[name]
+		if !existed {
+			if !strings.Contains(migrations, "CREATE TABLE IF NOT EXISTS "+name+" (") {
+				t.Errorf("Migrations do not create table %s", name)
+			}
+			continue
+		}
+		for _, column := range columnPattern.FindAllStringSubmatch(body, -1) {
+			if column[1] == "UNIQUE" || column[1] == "PRIMARY" || slices.Contains(initial, column[1]) {
+				continue
+			}
+			if !strings.Contains(migrations, "ALTER TABLE "+name+" ADD COLUMN IF NOT EXISTS "+column[1]+" ") {
+				t.Errorf("Migrations do not add column %s.%s", name, column[1])
+			}
+		}
+	}
+
+	for _, index := range regexp.MustCompile(`CREATE (?:UNIQUE )?INDEX IF NOT EXISTS (\w+)`).FindAllStringSubmatch(string(schema), -1) {
+		if !strings.Contains(migrations, index[0]+" ") && !initialPostgresIndex(index[1]) {
+			t.Errorf("Migrations do not create index %s", index[1])
+		}
+	}
+
+	if (sqliteDialect{}).Migrations() != "" {
+		t.Error("SQLite schema is always current and has no migrations")
+	}
+}
+
+func initialPostgresIndex(name string) bool {
+	switch name {
+	case "idx_games_created_at", "idx_games_target_word", "idx_guesses_game_id", "idx_guesses_created_at",
+		"idx_players_username", "idx_game_stats_game_id", "idx_game_stats_player_id":
+		return true
+	}
+	return false
+}
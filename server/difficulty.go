@@ -0,0 +1,145 @@
+package main
+
+import "sort"
+
+// Bounds on the solver simulation behind ExpectedGuesses, so estimating a
+// target's difficulty stays cheap enough to run on every game creation
+const (
+	// difficultyGuessSample is how many remaining candidates are tried as the
+	// next guess at each turn
+	difficultyGuessSample = 100
+	// maxDifficultyEvaluations caps the feedback patterns computed per estimate
+	maxDifficultyEvaluations = 100000
+)
+
+// ExpectedGuesses estimates how many guesses a solver playing near-optimally
+// needs to find target among candidates. The simulated solver only guesses
+// remaining candidates and picks the one that splits them into the most
+// feedback groups (ties: smallest largest group, then alphabetical). Once no
+// guess tells the remaining words apart other than by being the answer, or
+// the evaluation budget runs out, they can only be tried in turn, which takes
+// (n+1)/2 guesses on average. The estimate is capped at maxGuesses+1, a loss.
+func ExpectedGuesses(target string, candidates []string, maxGuesses int) float64 {
+	targetRunes := []rune(target)
+	remaining := make([][]rune, 0, len(candidates)+1)
+	seen := make(map[string]bool)
+	for _, word := range candidates {
+		runes := []rune(word)
+		if len(runes) != len(targetRunes) || seen[word] {
+			continue
+		}
+		seen[word] = true
+		remaining = append(remaining, runes)
+	}
+	if !seen[target] {
+		remaining = append(remaining, targetRunes)
+	}
+	sort.Slice(remaining, func(i, j int) bool {
+		return string(remaining[i]) < string(remaining[j])
+	})
+
+	limit := float64(maxGuesses + 1)
+	budget := maxDifficultyEvaluations
+	guesses := 0.0
+	for {
+		if len(remaining) == 1 {
+			return min(guesses+1, limit)
+		}
+
+		guess, largest, ok := bestSplit(remaining, &budget)
+		if !ok || largest >= len(remaining)-1 {
+			return min(guesses+float64(len(remaining)+1)/2, limit)
+		}
+
+		guesses++
+		if string(guess) == target {
+			return min(guesses, limit)
+		}
+		if guesses >= limit {
+			return limit
+		}
+
+		// Keep the words that would have given the same feedback as the target
+		pattern := feedbackPattern(guess, targetRunes)
+		next := remaining[:0:0]
+		for _, word := range remaining {
+			if feedbackPattern(guess, word) == pattern {
+				next = append(next, word)
+			}
+		}
+		remaining = next
+	}
+}
+
+// bestSplit returns the sampled candidate that partitions words into the most
+// feedback groups, with the size of its largest group. It reports false when
+// the budget cannot cover a full turn.
+func bestSplit(words [][]rune, budget *int) ([]rune, int, bool) {
+	step := 1
+	if len(words) > difficultyGuessSample {
+		step = (len(words) + difficultyGuessSample - 1) / difficultyGuessSample
+	}
+	if cost := (len(words) + step - 1) / step * len(words); cost > *budget {
+		return nil, 0, false
+	}
+
+	var best []rune
+	bestGroups, bestLargest := 0, 0
+	for i := 0; i < len(words); i += step {
+		guess := words[i]
+		sizes := make(map[int]int)
+		largest := 0
+		for _, word := range words {
+			pattern := feedbackPattern(guess, word)
+			sizes[pattern]++
+			largest = max(largest, sizes[pattern])
+		}
+		*budget -= len(words)
+
+		if len(sizes) > bestGroups || (len(sizes) == bestGroups && largest < bestLargest) {
+			best, bestGroups, bestLargest = guess, len(sizes), largest
+		}
+	}
+	return best, bestLargest, true
+}
+
+// feedbackPattern encodes the tiles EvaluateGuess would give guess against
+// target as a base-3 number (0 absent, 1 present, 2 correct), without
+// allocating, so candidates can be grouped by feedback cheaply
+func feedbackPattern(guess, target []rune) int {
+	var used [32]bool
+	var correct [32]bool
+	for i := range guess {
+		if i < len(used) && guess[i] == target[i] {
+			correct[i], used[i] = true, true
+		}
+	}
+
+	pattern := 0
+	for i, char := range guess {
+		status := 0
+		if i < len(correct) && correct[i] {
+			status = 2
+		} else {
+			for j, targetChar := range target {
+				if j < len(used) && !used[j] && char == targetChar {
+					status, used[j] = 1, true
+					break
+				}
+			}
+		}
+		pattern = pattern*3 + status
+	}
+	return pattern
+}
+
+// difficultyScore maps an expected guess count onto the 0-1 word difficulty
+// scale used by the difficulty tiers: solving in one guess scores 0 and
+// needing more than maxGuesses scores 1
+func difficultyScore(expectedGuesses float64, maxGuesses int) float64 {
+	if maxGuesses <= 0 {
+		return 1
+	}
+	score := (expectedGuesses - 1) / float64(maxGuesses)
+	return min(max(score, 0), 1)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// latchFamily has one word that a single guess isolates and a family that
+// differs in one letter, so the only way through it is to try each word
+var latchFamily = []string{"BATCH", "CATCH", "CRANE", "HATCH", "LATCH", "MATCH", "PATCH", "WATCH"}
+
+func TestExpectedGuesses(t *testing.T) {
+	easy := ExpectedGuesses("CRANE", latchFamily, 6)
+	hard := ExpectedGuesses("WATCH", latchFamily, 6)
+
+	if easy != 2 {
+		t.Errorf("Expected CRANE to take 2 guesses, got %v", easy)
+	}
+	// One guess, then on average (6+1)/2 tries through the remaining family
+	if hard != 4.5 {
+		t.Errorf("Expected WATCH to take 4.5 guesses, got %v", hard)
+	}
+	if easy >= hard {
+		t.Errorf("Expected easy word to need fewer guesses than hard word: %v >= %v", easy, hard)
+	}
+
+	if got := ExpectedGuesses("WATCH", latchFamily, 3); got != 4 {
+		t.Errorf("Expected estimate capped at a loss (4), got %v", got)
+	}
+	if got := ExpectedGuesses("ZEBRA", nil, 6); got != 1 {
+		t.Errorf("Expected the only candidate to take 1 guess, got %v", got)
+	}
+}
+
+func TestExpectedGuessesBounded(t *testing.T) {
+	wordList, err := NewWordList("")
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+	candidates := wordList.TargetWordsOfLength(5)
+	if len(candidates) == 0 {
+		t.Skip("No five-letter target words available")
+	}
+
+	start := time.Now()
+	estimate := ExpectedGuesses(candidates[0], candidates, 6)
+	elapsed := time.Since(start)
+
+	if estimate < 1 || estimate > 7 {
+		t.Errorf("Expected estimate between 1 and 7, got %v", estimate)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected the bounded simulation to be fast, took %v", elapsed)
+	}
+}
+
+func TestFeedbackPatternMatchesEvaluateGuess(t *testing.T) {
+	statusCodes := map[string]int{"absent": 0, "present": 1, "correct": 2}
+	pairs := [][2]string{
+		{"CRANE", "CRANE"},
+		{"WORLD", "HELLO"},
+		{"LLAMA", "HELLO"},
+		{"SPEED", "ABIDE"},
+		{"EERIE", "THREE"},
+	}
+
+	for _, pair := range pairs {
+		want := 0
+		for _, letter := range EvaluateGuess(pair[0], pair[1]) {
+			want = want*3 + statusCodes[letter.Status]
+		}
+		if got := feedbackPattern([]rune(pair[0]), []rune(pair[1])); got != want {
+			t.Errorf("feedbackPattern(%s, %s) = %d, want %d", pair[0], pair[1], got, want)
+		}
+	}
+}
+
+func TestDifficultyScore(t *testing.T) {
+	tests := []struct {
+		expected float64
+		want     float64
+	}{
+		{1, 0},
+		{4, 0.5},
+		{7, 1},
+		{10, 1},
+	}
+
+	for _, tt := range tests {
+		if got := difficultyScore(tt.expected, 6); got != tt.want {
+			t.Errorf("difficultyScore(%v, 6) = %v, want %v", tt.expected, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+)
+
+// GuessResultsCSV renders guesses as CSV for spreadsheet analysis: a header
+// row, then one row per guess with the word followed by each position's status
+func GuessResultsCSV(guesses []Guess, wordLength int) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := make([]string, wordLength+1)
+	header[0] = "guess_word"
+	for i := 1; i <= wordLength; i++ {
+		header[i] = fmt.Sprintf("position_%d", i)
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, guess := range guesses {
+		row := make([]string, wordLength+1)
+		row[0] = guess.GuessWord
+		for i, letter := range guess.Result {
+			if i < wordLength {
+				row[i+1] = letter.Status
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
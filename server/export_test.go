@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestGuessResultsCSV(t *testing.T) {
+	guesses := []Guess{
+		{GuessWord: "WORLD", GuessNumber: 1, Result: EvaluateGuess("WORLD", "HELLO")},
+		{GuessWord: "HELLO", GuessNumber: 2, Result: EvaluateGuess("HELLO", "HELLO")},
+	}
+
+	data, err := GuessResultsCSV(guesses, 5)
+	if err != nil {
+		t.Fatalf("GuessResultsCSV should not return error: %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"guess_word", "position_1", "position_2", "position_3", "position_4", "position_5"},
+		{"WORLD", "absent", "present", "absent", "correct", "absent"},
+		{"HELLO", "correct", "correct", "correct", "correct", "correct"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("Expected %d rows, got %d: %v", len(want), len(records), records)
+	}
+	for i := range want {
+		if len(records[i]) != len(want[i]) {
+			t.Fatalf("Row %d: expected %d columns, got %v", i, len(want[i]), records[i])
+		}
+		for j := range want[i] {
+			if records[i][j] != want[i][j] {
+				t.Errorf("Row %d column %d: expected %q, got %q", i, j, want[i][j], records[i][j])
+			}
+		}
+	}
+}
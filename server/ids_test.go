@@ -0,0 +1,45 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+// uuidV4Pattern matches canonical version 4, RFC 4122 variant UUIDs
+var uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestUUIDGenerator(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := UUIDGenerator{}.NewID()
+		if !uuidV4Pattern.MatchString(id) {
+			t.Fatalf("Expected a version 4 UUID, got %q", id)
+		}
+		if seen[id] {
+			t.Fatalf("Generated duplicate ID %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestSeededIDGenerator(t *testing.T) {
+	first, second := NewSeededIDGenerator(42), NewSeededIDGenerator(42)
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := first.NewID()
+		if !uuidV4Pattern.MatchString(id) {
+			t.Fatalf("Expected a version 4 UUID, got %q", id)
+		}
+		if other := second.NewID(); other != id {
+			t.Fatalf("Expected the same seed to give the same IDs, got %s and %s", id, other)
+		}
+		if seen[id] {
+			t.Fatalf("Generated duplicate ID %s", id)
+		}
+		seen[id] = true
+	}
+
+	if NewSeededIDGenerator(43).NewID() == NewSeededIDGenerator(42).NewID() {
+		t.Error("Expected different seeds to give different IDs")
+	}
+}
@@ -1,24 +1,100 @@
 package main
 
+import (
+	"io"
+	"time"
+)
+
 // Interfaces for dependency injection and testing
 
 // GameRepositoryInterface defines the interface for game repository operations
 type GameRepositoryInterface interface {
-	CreateGame(targetWord string, maxGuesses int) (*Game, error)
+	CreateGame(id, targetWord string, maxGuesses int) (*Game, error)
+	CreateGames(ids, targetWords []string, maxGuesses int) error
 	GetGame(gameID string) (*Game, error)
+	GetGameForUpdate(gameID string) (*Game, error)
 	UpdateGame(game *Game) error
+	SetClientInfo(gameID string, client ClientInfo) error
+	SetWordDifficulty(gameID string, difficulty float64) error
+	SetMaxHints(gameID string, maxHints int) error
+	UseHint(gameID string, defaultLimit int) (int, error)
 	DeleteGame(gameID string) error
 	GetGameWithGuesses(gameID string) (*GameWithGuesses, error)
 	GetRecentGames(limit int) ([]Game, error)
+	GetRecentGamesByMode(mode string, limit int) ([]Game, error)
+	GetRecentCompletedGames(limit int) ([]Game, error)
+	GetCompletedGamesByTargetWord(targetWord string, limit int) ([]Game, error)
+	GetGamesByTargetWord(targetWord string, limit int) ([]Game, error)
+	GetGamesWonInGuesses(guesses int, limit int) ([]Game, error)
+	GetAtRiskGames(maxRemaining int, limit int) ([]Game, error)
+	GetBestWonGameForPlayer(playerID string) (*Game, error)
+	GetCompletedGamesForPlayer(playerID string, limit int) ([]Game, error)
+	GetPlayedTargetWords(playerID string) ([]string, error)
+	GetPlayerForGame(gameID string) (*Player, error)
+	SetGamePlayer(gameID, playerID string) error
+	RecordPlayerResult(gameID string, won bool) error
+	GetStatsByDifficulty() ([]DifficultyTierStats, error)
+	GetGameTallies() ([]GameTally, error)
+	CreateDailyGame(id, targetWord string, maxGuesses int, date time.Time) (*Game, error)
+	GetDailyGame(date time.Time) (*Game, error)
+	CreatePlayerDailyGame(id, playerID, targetWord string, maxGuesses int, date time.Time) (*Game, error)
+	GetPlayerDailyGame(playerID string, date time.Time) (*Game, error)
+	CreateChallengeGame(id, targetWord string, maxGuesses int) (*Game, error)
+	CreateTutorialGame(id, targetWord string, maxGuesses int) (*Game, error)
+	CreateAnagramGame(id, targetWord, scramble string, maxGuesses int) (*Game, error)
+	CreateMultiBoardGame(id string, targets []string, maxGuesses int) (*Game, error)
 }
 
 // GuessRepositoryInterface defines the interface for guess repository operations
 type GuessRepositoryInterface interface {
-	CreateGuess(gameID, guessWord string, guessNumber int, result GuessResult) (*Guess, error)
+	CreateGuess(id, gameID, guessWord string, guessNumber int, result GuessResult) (*Guess, error)
 	GetGuess(guessID string) (*Guess, error)
 	GetGuessesByGameID(gameID string) ([]Guess, error)
 	DeleteGuess(guessID string) error
 	GetLatestGuess(gameID string) (*Guess, error)
+	GetPositionTallies(length int) ([]PositionTally, error)
+	GetTopOpeners(limit int) ([]WordCount, error)
+	GetDistinctGuessTallies() ([]DistinctGuessTally, error)
+}
+
+// AnswerRepositoryInterface defines the interface for the daily answer schedule
+type AnswerRepositoryInterface interface {
+	GetAnswer(date time.Time) (string, error)
+	GetAnswers(from, to time.Time) ([]ScheduledAnswer, error)
+	SaveAnswers(answers []ScheduledAnswer) error
+}
+
+// APIKeyRepositoryInterface defines the interface for API keys and their usage
+type APIKeyRepositoryInterface interface {
+	UseGameQuota(key string, date time.Time) error
+	RefundGameQuota(key string, date time.Time) error
+}
+
+// PlayerRepositoryInterface defines the interface for player repository operations
+type PlayerRepositoryInterface interface {
+	GetPlayerByUsername(username string) (*Player, error)
+	CreatePlayer(id, username string) (*Player, error)
+	GetPlayerRank(playerID, metric string) (*PlayerRank, error)
+}
+
+// IDGenerator defines the interface for generating game and guess IDs
+type IDGenerator interface {
+	NewID() string
+}
+
+// AuditLogger defines the interface for recording mutations for compliance
+type AuditLogger interface {
+	// Record writes one audit record. The mutation has already been committed,
+	// so implementations report their own failures instead of returning them.
+	Record(record AuditRecord)
+}
+
+// TransactorInterface defines the interface for running repository operations atomically
+type TransactorInterface interface {
+	WithinTransaction(fn func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error) error
+	// WithinPlayerTransaction is WithinTransaction with a player repository
+	// bound to the same transaction
+	WithinPlayerTransaction(fn func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, playerRepo PlayerRepositoryInterface) error) error
 }
 
 // WordListInterface defines the interface for word list operations
@@ -28,6 +104,22 @@ type WordListInterface interface {
 	RandomValidWord() string
 	FiveLetterWords() []string
 	FiveLetterTargetWords() []string
+	DailyWord(date time.Time) string
+	WordsOfLength(length int) []string
+	TargetWordsOfLength(length int) []string
+	ImportTargetWords(r io.Reader, wordLength int, persist bool) (*ImportStats, error)
 	Size() int
 	TargetWordsSize() int
 }
+
+// DefinitionProvider defines the interface for looking up dictionary definitions
+type DefinitionProvider interface {
+	// Definition returns the definition for word and whether one was found
+	Definition(word string) (string, bool)
+}
+
+// CompletionNotifier defines the interface for announcing finished games
+type CompletionNotifier interface {
+	// NotifyGameCompleted is called once a game is won or lost. It must not block.
+	NotifyGameCompleted(game Game)
+}
@@ -0,0 +1,28 @@
+package main
+
+import "unicode"
+
+// IsIsogram reports whether no letter appears more than once in word,
+// ignoring case
+func IsIsogram(word string) bool {
+	seen := make(map[rune]bool)
+	for _, letter := range word {
+		letter = unicode.ToLower(letter)
+		if seen[letter] {
+			return false
+		}
+		seen[letter] = true
+	}
+	return true
+}
+
+// filterIsograms returns the words of words that are isograms
+func filterIsograms(words []string) []string {
+	var isograms []string
+	for _, word := range words {
+		if IsIsogram(word) {
+			isograms = append(isograms, word)
+		}
+	}
+	return isograms
+}
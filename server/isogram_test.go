@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsIsogram(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"CRANE", true},
+		{"HELLO", false},
+		{"Anna", false}, // Letters repeat regardless of case
+		{"ÉCLAT", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsIsogram(tt.word); got != tt.want {
+			t.Errorf("IsIsogram(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestFilterIsograms(t *testing.T) {
+	got := filterIsograms([]string{"HELLO", "WORLD", "SPEED", "CRANE"})
+	if want := []string{"WORLD", "CRANE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
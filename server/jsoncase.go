@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// JSON key casing for API responses. Structs are tagged in snake_case; clients
+// can ask for camelCase with ?case=camel or an Accept parameter such as
+// "application/json; case=camel", and keys are remapped at the encoding boundary.
+
+// wantsCamelCase reports whether the request asked for camelCase JSON keys
+func wantsCamelCase(r *http.Request) bool {
+	return wantsJSONOption(r, "case", "camel")
+}
+
+// wantsJSONOption reports whether the request selected a response
+// representation with ?name=value or an Accept parameter such as
+// "application/json; name=value"
+func wantsJSONOption(r *http.Request, name, want string) bool {
+	if strings.EqualFold(r.URL.Query().Get(name), want) {
+		return true
+	}
+	for _, accept := range r.Header.Values("Accept") {
+		for _, param := range strings.Split(accept, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(key, name) && strings.EqualFold(strings.TrimSpace(value), want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// snakeToCamel converts a snake_case key to camelCase (e.g. "is_won" -> "isWon")
+func snakeToCamel(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// rewriteJSON decodes a JSON document, applies rewrite to it and re-encodes it.
+// Numbers are kept as written.
+func rewriteJSON(data []byte, rewrite func(interface{}) interface{}) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(rewrite(value)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renameKeys recursively applies transform to the keys of decoded JSON objects
+func renameKeys(value interface{}, transform func(string) string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			renamed[transform(key)] = renameKeys(child, transform)
+		}
+		return renamed
+	case []interface{}:
+		for i, child := range v {
+			v[i] = renameKeys(child, transform)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// bufferedResponseWriter holds back a response so its body can be rewritten
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+// rewriteJSONResponse returns middleware that, for requests selected by
+// wanted, buffers the response and applies rewrite to its decoded JSON
+// document. Other responses, and bodies that are not valid JSON, pass through
+// unchanged.
+func rewriteJSONResponse(wanted func(*http.Request) bool, rewrite func(interface{}) interface{}) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !wanted(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buffered := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(buffered, r)
+
+			body := buffered.body.Bytes()
+			if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+				if transformed, err := rewriteJSON(body, rewrite); err == nil {
+					body = transformed
+				}
+			}
+
+			w.WriteHeader(buffered.status)
+			w.Write(body)
+		})
+	}
+}
+
+// withJSONCase wraps a handler so JSON responses use camelCase keys when requested
+var withJSONCase = rewriteJSONResponse(wantsCamelCase, func(value interface{}) interface{} {
+	return renameKeys(value, snakeToCamel)
+})
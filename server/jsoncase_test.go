@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSnakeToCamel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"is_won", "isWon"},
+		{"guess_word", "guessWord"},
+		{"max_guesses", "maxGuesses"},
+		{"id", "id"},
+		{"solve_time_seconds", "solveTimeSeconds"},
+		{"trailing_", "trailing"},
+	}
+
+	for _, tt := range tests {
+		if got := snakeToCamel(tt.input); got != tt.expected {
+			t.Errorf("snakeToCamel(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestWantsCamelCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		accept   string
+		expected bool
+	}{
+		{"Default", "/api/games/A", "", false},
+		{"Query parameter", "/api/games/A?case=camel", "", true},
+		{"Query parameter snake", "/api/games/A?case=snake", "", false},
+		{"Accept parameter", "/api/games/A", "application/json; case=camel", true},
+		{"Plain accept", "/api/games/A", "application/json", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				request.Header.Set("Accept", tt.accept)
+			}
+			if got := wantsCamelCase(request); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithJSONCase(t *testing.T) {
+	game := Game{
+		ID:          "A",
+		TargetWord:  "HELLO",
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsWon:       true,
+		IsCompleted: true,
+		GuessCount:  3,
+		MaxGuesses:  6,
+	}
+	handler := withJSONCase(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusCreated, GameResponse{Game: game})
+	}))
+
+	decode := func(recorder *httptest.ResponseRecorder) map[string]interface{} {
+		var body map[string]interface{}
+		if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return body["game"].(map[string]interface{})
+	}
+
+	// snake_case by default
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/A", nil))
+	snake := decode(recorder)
+	for _, key := range []string{"is_won", "guess_count", "max_guesses", "target_word"} {
+		if _, ok := snake[key]; !ok {
+			t.Errorf("Expected snake_case key %q in default response", key)
+		}
+	}
+
+	// camelCase when requested, with status and values preserved
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/A?case=camel", nil))
+	if recorder.Code != http.StatusCreated {
+		t.Errorf("Expected status 201 to be preserved, got %d", recorder.Code)
+	}
+	camel := decode(recorder)
+	for _, key := range []string{"isWon", "guessCount", "maxGuesses", "targetWord"} {
+		if _, ok := camel[key]; !ok {
+			t.Errorf("Expected camelCase key %q in camel response", key)
+		}
+	}
+	if _, ok := camel["is_won"]; ok {
+		t.Error("Did not expect snake_case key in camel response")
+	}
+	if camel["guessCount"] != float64(3) || camel["isWon"] != true {
+		t.Errorf("Expected values to be preserved, got %v", camel)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import "net/http"
+
+// Numeric tile statuses for compact clients. Guess results are stored and
+// encoded with string statuses; clients can ask for integers instead with
+// ?status=numeric or an Accept parameter such as
+// "application/json; status=numeric", and statuses are remapped at the
+// encoding boundary like JSON key casing.
+var numericStatusCodes = map[string]int{
+	"absent":  0,
+	"present": 1,
+	"correct": 2,
+}
+
+// wantsNumericStatus reports whether the request asked for numeric tile statuses
+func wantsNumericStatus(r *http.Request) bool {
+	return wantsJSONOption(r, "status", "numeric")
+}
+
+// numericStatuses recursively replaces tile "status" values in decoded JSON
+// with their numeric codes. Other "status" fields (e.g. a game summary's
+// status) don't use tile statuses and are left alone.
+func numericStatuses(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if status, ok := child.(string); ok && key == "status" {
+				if code, ok := numericStatusCodes[status]; ok {
+					v[key] = code
+				}
+				continue
+			}
+			v[key] = numericStatuses(child)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = numericStatuses(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// withNumericStatus wraps a handler so JSON responses use numeric tile
+// statuses when requested
+var withNumericStatus = rewriteJSONResponse(wantsNumericStatus, numericStatuses)
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithNumericStatus(t *testing.T) {
+	response := GameResponse{
+		Game: Game{ID: "A", TargetWord: "CRANE", GuessCount: 1, MaxGuesses: 6},
+		Guesses: []Guess{
+			{GameID: "A", GuessWord: "CARTS", GuessNumber: 1, Result: EvaluateGuess("CARTS", "CRANE")},
+		},
+	}
+	handler := withNumericStatus(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, response)
+	}))
+
+	statuses := func(request *http.Request) []interface{} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", recorder.Code)
+		}
+
+		var decoded struct {
+			Guesses []struct {
+				Result []struct {
+					Status interface{} `json:"status"`
+				} `json:"result"`
+			} `json:"guesses"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		var result []interface{}
+		for _, letter := range decoded.Guesses[0].Result {
+			result = append(result, letter.Status)
+		}
+		return result
+	}
+
+	// CARTS vs CRANE: C correct, A and R present, T and S absent
+	defaultStatuses := statuses(httptest.NewRequest(http.MethodGet, "/api/games/A", nil))
+	expectedStrings := []interface{}{"correct", "present", "present", "absent", "absent"}
+	for i, status := range defaultStatuses {
+		if status != expectedStrings[i] {
+			t.Errorf("Default mode: position %d expected %v, got %v", i, expectedStrings[i], status)
+		}
+	}
+
+	expectedCodes := []interface{}{2.0, 1.0, 1.0, 0.0, 0.0}
+	request := httptest.NewRequest(http.MethodGet, "/api/games/A", nil)
+	request.Header.Set("Accept", "application/json; status=numeric")
+	for _, request := range []*http.Request{httptest.NewRequest(http.MethodGet, "/api/games/A?status=numeric", nil), request} {
+		for i, status := range statuses(request) {
+			if status != expectedCodes[i] {
+				t.Errorf("Numeric mode: position %d expected %v, got %v", i, expectedCodes[i], status)
+			}
+		}
+	}
+}
+
+func TestNumericStatusesLeavesOtherStatuses(t *testing.T) {
+	value := map[string]interface{}{
+		"status":  "won",
+		"correct": "present", // only "status" keys are remapped
+		"result":  []interface{}{map[string]interface{}{"letter": "A", "status": "absent"}},
+	}
+	numericStatuses(value)
+
+	if value["status"] != "won" || value["correct"] != "present" {
+		t.Errorf("Expected non-tile fields to be unchanged, got %v", value)
+	}
+	letter := value["result"].([]interface{})[0].(map[string]interface{})
+	if letter["status"] != 0 {
+		t.Errorf("Expected absent to map to 0, got %v", letter["status"])
+	}
+}
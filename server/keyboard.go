@@ -0,0 +1,103 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// KeyboardState maps each guessed letter to the best status it has shown:
+// correct beats present, which beats absent
+type KeyboardState map[string]string
+
+// keyboardPrecedence ranks statuses for merging; higher wins
+var keyboardPrecedence = map[string]int{
+	"absent":  1,
+	"present": 2,
+	"correct": 3,
+}
+
+// Merge returns a copy of the state updated with the letters of result
+func (k KeyboardState) Merge(result GuessResult) KeyboardState {
+	merged := make(KeyboardState, len(k)+len(result))
+	for letter, status := range k {
+		merged[letter] = status
+	}
+	for _, tile := range result {
+		if keyboardPrecedence[tile.Status] > keyboardPrecedence[merged[tile.Letter]] {
+			merged[tile.Letter] = tile.Status
+		}
+	}
+	return merged
+}
+
+// Equal reports whether both states have the same letters and statuses.
+// A nil state equals an empty one.
+func (k KeyboardState) Equal(other KeyboardState) bool {
+	if len(k) != len(other) {
+		return false
+	}
+	for letter, status := range k {
+		if other[letter] != status {
+			return false
+		}
+	}
+	return true
+}
+
+// AbsentLetters returns the letters known not to be in the word at all, sorted.
+// A letter shown present or correct anywhere is not absent, even if a repeat
+// of it was marked absent.
+func (k KeyboardState) AbsentLetters() []string {
+	letters := []string{}
+	for letter, status := range k {
+		if status == "absent" {
+			letters = append(letters, letter)
+		}
+	}
+	sort.Strings(letters)
+	return letters
+}
+
+// BuildKeyboardState computes the keyboard state from scratch for the given guesses
+func BuildKeyboardState(guesses []Guess) KeyboardState {
+	state := KeyboardState{}
+	for _, guess := range guesses {
+		state = state.Merge(guess.Result)
+	}
+	return state
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (k KeyboardState) Value() (driver.Value, error) {
+	if k == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(k)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (k *KeyboardState) Scan(value interface{}) error {
+	if value == nil {
+		*k = KeyboardState{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("cannot scan KeyboardState from non-string/[]byte")
+	}
+
+	state := KeyboardState{}
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return err
+	}
+	*k = state
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyboardStateMerge(t *testing.T) {
+	// Target HELLO: LLAMA marks L present, then WORLD marks it correct
+	state := KeyboardState{}.Merge(EvaluateGuess("LLAMA", "HELLO"))
+	if state["L"] != "present" || state["A"] != "absent" {
+		t.Errorf("Unexpected state after LLAMA: %v", state)
+	}
+
+	merged := state.Merge(EvaluateGuess("WORLD", "HELLO"))
+	if merged["L"] != "correct" {
+		t.Errorf("Expected correct to take precedence over present, got %s", merged["L"])
+	}
+
+	// A later absent tile never downgrades a letter
+	merged = merged.Merge(GuessResult{{Letter: "L", Status: "absent"}, {Letter: "O", Status: "absent"}})
+	if merged["L"] != "correct" || merged["O"] != "present" {
+		t.Errorf("Expected absent not to downgrade letters, got L=%s O=%s", merged["L"], merged["O"])
+	}
+
+	// Merge does not modify the receiver
+	if state["L"] != "present" {
+		t.Errorf("Merge should not modify the original state, got L=%s", state["L"])
+	}
+}
+
+func TestKeyboardStateAbsentLetters(t *testing.T) {
+	// Target HELLO: WORLD rules out W, R and D, and shows O present
+	state := KeyboardState{}.Merge(EvaluateGuess("WORLD", "HELLO"))
+	if absent := state.AbsentLetters(); !reflect.DeepEqual(absent, []string{"D", "R", "W"}) {
+		t.Errorf("Expected D, R, W absent, got %v", absent)
+	}
+
+	// An absent tile for a letter shown present in another guess doesn't make it absent
+	state = state.Merge(GuessResult{{Letter: "O", Status: "absent"}, {Letter: "X", Status: "absent"}})
+	if absent := state.AbsentLetters(); !reflect.DeepEqual(absent, []string{"D", "R", "W", "X"}) {
+		t.Errorf("Expected O to stay out of the absent set, got %v", absent)
+	}
+
+	// Nor does a repeated letter's absent tile within the same guess
+	state = KeyboardState{}.Merge(EvaluateGuess("LLAMA", "PLANT"))
+	if absent := state.AbsentLetters(); !reflect.DeepEqual(absent, []string{"M"}) {
+		t.Errorf("Expected only M absent, got %v", absent)
+	}
+
+	if absent := (KeyboardState{}).AbsentLetters(); absent == nil || len(absent) != 0 {
+		t.Errorf("Expected an empty, non-nil set for an empty keyboard, got %v", absent)
+	}
+}
+
+func TestBuildKeyboardState(t *testing.T) {
+	state := BuildKeyboardState(guessesFor("HELLO", "CRANE", "WORLD"))
+
+	expected := KeyboardState{
+		"C": "absent", "R": "absent", "A": "absent", "N": "absent", "E": "present",
+		"W": "absent", "O": "present", "L": "correct", "D": "absent",
+	}
+	if !reflect.DeepEqual(state, expected) {
+		t.Errorf("Expected %v, got %v", expected, state)
+	}
+
+	if len(BuildKeyboardState(nil)) != 0 {
+		t.Error("Expected empty state without guesses")
+	}
+}
+
+func TestKeyboardStateValueScan(t *testing.T) {
+	var empty KeyboardState
+	value, err := empty.Value()
+	if err != nil {
+		t.Fatalf("Value should not return error: %v", err)
+	}
+	if string(value.([]byte)) != "{}" {
+		t.Errorf("Expected nil state to be stored as {}, got %s", value)
+	}
+
+	state := KeyboardState{"A": "correct", "B": "absent"}
+	value, err = state.Value()
+	if err != nil {
+		t.Fatalf("Value should not return error: %v", err)
+	}
+
+	var scanned KeyboardState
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Scan should not return error: %v", err)
+	}
+	if !reflect.DeepEqual(scanned, state) {
+		t.Errorf("Expected %v after round trip, got %v", state, scanned)
+	}
+
+	if err := scanned.Scan(nil); err != nil || len(scanned) != 0 {
+		t.Errorf("Expected empty state from NULL, got %v, %v", scanned, err)
+	}
+	if err := scanned.Scan(42); err == nil {
+		t.Error("Expected error scanning a non-string value")
+	}
+}
+
+func TestKeyboardStateEqual(t *testing.T) {
+	var empty KeyboardState
+	if !empty.Equal(KeyboardState{}) {
+		t.Error("Expected nil state to equal an empty state")
+	}
+
+	state := KeyboardState{"A": "correct"}
+	if !state.Equal(KeyboardState{"A": "correct"}) {
+		t.Error("Expected identical states to be equal")
+	}
+	if state.Equal(KeyboardState{"A": "present"}) || state.Equal(KeyboardState{"B": "correct"}) || state.Equal(empty) {
+		t.Error("Expected differing states not to be equal")
+	}
+}
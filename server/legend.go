@@ -0,0 +1,37 @@
+package main
+
+import "net/http"
+
+// LegendEntry describes one tile status so clients needn't hardcode it
+type LegendEntry struct {
+	Code    int    `json:"code"`    // Value used with ?status=numeric
+	Meaning string `json:"meaning"` // What the status says about the letter
+	Color   string `json:"color"`   // Suggested tile color
+}
+
+// statusLegend maps each tile status to its legend entry
+var statusLegend = map[string]LegendEntry{
+	"correct": {Code: numericStatusCodes["correct"], Meaning: "The letter is in the word and in this position", Color: "#6aaa64"},
+	"present": {Code: numericStatusCodes["present"], Meaning: "The letter is in the word but in another position", Color: "#c9b458"},
+	"absent":  {Code: numericStatusCodes["absent"], Meaning: "The letter is not in the word, or not this many times", Color: "#787c7e"},
+}
+
+// wantsLegend reports whether the request asked for the status legend with
+// ?legend=true or an Accept parameter such as "application/json; legend=true"
+func wantsLegend(r *http.Request) bool {
+	return wantsJSONOption(r, "legend", "true")
+}
+
+// addLegend adds the status legend to a decoded JSON object. Other documents,
+// such as arrays, are left alone.
+func addLegend(value interface{}) interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		object["legend"] = statusLegend
+	}
+	return value
+}
+
+// withLegend wraps a handler so JSON responses carry a "legend" object
+// describing the tile statuses when requested. It is off by default to keep
+// responses small.
+var withLegend = rewriteJSONResponse(wantsLegend, addLegend)
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithLegend(t *testing.T) {
+	handler := withLegend(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, map[string]string{"id": "A"})
+	}))
+
+	legend := func(request *http.Request) (map[string]LegendEntry, bool) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", recorder.Code)
+		}
+
+		var decoded map[string]json.RawMessage
+		if err := json.Unmarshal(recorder.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if string(decoded["id"]) != `"A"` {
+			t.Errorf("Expected the response to be kept, got %s", recorder.Body.String())
+		}
+		raw, ok := decoded["legend"]
+		if !ok {
+			return nil, false
+		}
+		var entries map[string]LegendEntry
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			t.Fatalf("Failed to decode legend: %v", err)
+		}
+		return entries, true
+	}
+
+	// Absent by default
+	if _, ok := legend(httptest.NewRequest(http.MethodGet, "/api/games/A", nil)); ok {
+		t.Error("Expected no legend by default")
+	}
+
+	accept := httptest.NewRequest(http.MethodGet, "/api/games/A", nil)
+	accept.Header.Set("Accept", "application/json; legend=true")
+	for _, request := range []*http.Request{httptest.NewRequest(http.MethodGet, "/api/games/A?legend=true", nil), accept} {
+		entries, ok := legend(request)
+		if !ok {
+			t.Fatal("Expected a legend when requested")
+		}
+		for _, status := range []string{"correct", "present", "absent"} {
+			entry, ok := entries[status]
+			if !ok {
+				t.Errorf("Expected legend entry for %s", status)
+				continue
+			}
+			if entry.Color == "" || entry.Meaning == "" {
+				t.Errorf("Expected %s to have a color and meaning, got %+v", status, entry)
+			}
+			if entry.Code != numericStatusCodes[status] {
+				t.Errorf("Expected %s code %d, got %d", status, numericStatusCodes[status], entry.Code)
+			}
+		}
+		if len(entries) != 3 {
+			t.Errorf("Expected 3 legend entries, got %d", len(entries))
+		}
+	}
+}
+
+func TestWithLegendNumericStatus(t *testing.T) {
+	// The legend's status names survive numeric statuses, which only rewrite "status" values
+	handler := withNumericStatus(withLegend(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, map[string]string{"status": "correct"})
+	})))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?legend=true&status=numeric", nil))
+
+	var decoded struct {
+		Status int                    `json:"status"`
+		Legend map[string]LegendEntry `json:"legend"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if decoded.Status != 2 || decoded.Legend["correct"].Code != decoded.Status {
+		t.Errorf("Expected numeric status matching the legend code, got %s", recorder.Body.String())
+	}
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"math/rand"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,6 +20,15 @@ var (
 	config      *Config
 )
 
+// Retry-After hints, in seconds
+const (
+	guessRetryAfterSeconds    = 1 // Guesses shed under load
+	databaseRetryAfterSeconds = 5 // Database unreachable
+)
+
+// maxImportBytes caps the body of a target word import
+const maxImportBytes = 10 << 20
+
 func main() {
 	// Load configuration
 	var err error
@@ -23,9 +36,30 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := config.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := SetGuessResultFormat(config.Game.ResultFormat); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	// A fixed seed makes target selection reproducible, e.g. for seeded challenges
+	if config.Game.RandomSeed != 0 {
+		SetRandomSeed(config.Game.RandomSeed)
+	}
 
 	// Initialize word list
-	wordList, err := NewWordList("")
+	wordListOptions := WordListOptions{
+		SplitMultiWordLines: config.Game.SplitMultiWordLines,
+		MaxWordLength:       config.Game.MaxWordLength,
+		MaxLineBytes:        config.Game.MaxWordLineBytes,
+		CaseSensitive:       config.Game.CaseSensitiveWords,
+		Locale:              config.Game.Locale,
+	}
+	if config.Game.RequireTargetLength {
+		wordListOptions.RequireTargetLength = config.Game.WordLength
+	}
+	wordList, err := NewWordListWithOptions("", wordListOptions)
 	if err != nil {
 		log.Fatalf("Failed to initialize word list: %v", err)
 	}
@@ -50,47 +84,257 @@ func main() {
 
 	// Initialize game service
 	gameService = NewGameService(db, wordList, &config.Game)
+	gameService.SetFeatures(config.Features)
+
+	// A fixed ID seed makes game and guess IDs reproducible across runs and backends
+	if config.Game.IDSeed != 0 {
+		gameService.SetIDGenerator(NewSeededIDGenerator(config.Game.IDSeed))
+	}
+
+	// Load optional word definitions revealed at the end of a game
+	if config.Game.DefinitionsFile != "" {
+		definitions, err := NewFileDefinitionProvider(config.Game.DefinitionsFile)
+		if err != nil {
+			log.Printf("Warning: definitions unavailable: %v", err)
+		} else {
+			gameService.SetDefinitionProvider(definitions)
+			log.Printf("Definitions loaded: %d words", definitions.Size())
+		}
+	}
+
+	// Announce completed games to the configured webhook
+	if config.Server.WebhookURL != "" {
+		gameService.SetCompletionNotifier(NewWebhookNotifier(
+			config.Server.WebhookURL,
+			config.Server.WebhookIncludeTarget,
+			config.Server.WebhookTimeout,
+			config.Server.WebhookMaxAttempts,
+		))
+	}
+
+	// Record every game mutation in an append-only audit log for compliance
+	if config.Server.Audit {
+		auditor, err := NewFileAuditLogger(config.Server.AuditLogPath)
+		if err != nil {
+			log.Fatalf("Failed to initialize audit log: %v", err)
+		}
+		defer auditor.Close()
+		gameService.SetAuditLogger(auditor)
+	}
+
+	// Replace the built-in tutorial script when one is configured
+	if config.Game.TutorialFile != "" {
+		tutorial, err := LoadTutorial(config.Game.TutorialFile)
+		if err != nil {
+			log.Printf("Warning: using built-in tutorial: %v", err)
+		} else {
+			gameService.SetTutorial(tutorial)
+		}
+	}
 
 	// Setup HTTP handlers
-	setupRoutes()
+	mux := http.NewServeMux()
+	setupRoutes(mux)
 
 	// Start server
 	address := config.Server.Address()
 	log.Printf("Wordle API server starting on %s...", address)
-	log.Printf("Database connected: %s", config.Database.DatabaseURL())
+	if db.Dialect().Name() == BackendPostgres {
+		log.Printf("Database connected: %s", config.Database.DatabaseURL())
+	} else {
+		log.Printf("Database connected: %s", db.Dialect().DataSourceName(&config.Database))
+	}
 	log.Printf("Word lists loaded: %d validation words, %d target words", wordList.Size(), wordList.TargetWordsSize())
 	
-	log.Fatal(http.ListenAndServe(address, nil))
+	log.Fatal(http.ListenAndServe(address, withAccessLog(withJSONCase(withNumericStatus(withLegend(mux))), config.Server.LogSampleRate)))
+}
+
+func setupRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/", rootHandler)
+	mux.HandleFunc("/health", healthHandler)
+	mux.HandleFunc("/api/version", versionHandler)
+	mux.HandleFunc("/api/games", requireJSONBody(gamesHandler))
+	mux.HandleFunc("/api/games/", requireJSONBody(gameHandler)) // for /api/games/{id}
+	mux.HandleFunc("/api/games/public", publicGamesHandler)
+	mux.HandleFunc("/api/games/recent-results", recentResultsHandler)
+	mux.HandleFunc("/api/games/won", gamesWonHandler)
+	mux.HandleFunc("/api/stats", statsHandler)
+	mux.HandleFunc("/api/players/", playerHandler) // for /api/players/{id}/...
+
+	// Feature-flagged endpoints
+	handleFeature(mux, FeatureByWord, "/api/games/by-word", gamesByWordHandler)
+	handleFeature(mux, FeatureHeatmap, "/api/stats/heatmap", heatmapHandler)
+	mux.HandleFunc("/api/stats/by-difficulty", statsByDifficultyHandler)
+	mux.HandleFunc("/api/stats/recent", recentStatsHandler)
+	mux.HandleFunc("/api/stats/snapshot", statsSnapshotHandler)
+	mux.HandleFunc("/api/stats/at-risk", atRiskGamesHandler)
+	mux.HandleFunc("/api/stats/distinct-guesses", distinctGuessesHandler)
+	mux.HandleFunc("/api/evaluate", evaluateHandler)
+	handleFeature(mux, FeatureDaily, "/api/daily", dailyGameHandler)
+	handleFeature(mux, FeatureDaily, "/api/daily/resume", dailyResumeHandler)
+	handleFeature(mux, FeatureAnagram, "/api/words/anagram", anagramHandler)
+	mux.HandleFunc("/api/words/neighbors", wordNeighborsHandler)
+	handleFeature(mux, FeatureNormalize, "/api/words/normalize", normalizeWordHandler)
+	handleFeature(mux, FeatureDaily, "/api/admin/answers/schedule", requireAdmin(requireJSONBody(scheduleAnswersHandler)))
+	mux.HandleFunc("/api/admin/games/", requireAdmin(adminGameHandler)) // for /api/admin/games/{id}/...
+	mux.HandleFunc("/api/admin/words/", requireAdmin(adminWordHandler)) // for /api/admin/words/{word}/...
+	mux.HandleFunc("/api/admin/target-words/import", requireAdmin(importTargetWordsHandler))
+}
+
+// requireAdmin only lets requests through that carry the configured admin token
+// in the X-Admin-Token header. Admin endpoints are disabled when no token is set.
+func requireAdmin(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if message := adminTokenError(r); message != "" {
+			writeErrorResponse(w, http.StatusForbidden, message)
+			return
+		}
+		handler(w, r)
+	}
 }
 
-func setupRoutes() {
-	http.HandleFunc("/", rootHandler)
-	http.HandleFunc("/health", healthHandler)
-	http.HandleFunc("/api/games", gamesHandler)
-	http.HandleFunc("/api/games/", gameHandler) // for /api/games/{id}
-	http.HandleFunc("/api/stats", statsHandler)
+// requireJSONBody rejects POST requests with a body that isn't declared as
+// JSON with 415 when REQUIRE_JSON_CONTENT_TYPE is set, rather than decoding
+// form or text bodies that happen to look like JSON. Bodiless POSTs, such as
+// creating a plain game, need no content type.
+func requireJSONBody(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if config.Server.RequireJSONContentType && r.Method == http.MethodPost && r.ContentLength != 0 && !isJSONContentType(r.Header.Get("Content-Type")) {
+			writeErrorResponse(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// isJSONContentType reports whether a Content-Type header declares JSON,
+// either application/json or a +json type, with any parameters
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// adminTokenError returns why r is not authorized as an admin request, or ""
+// when it carries the configured admin token
+func adminTokenError(r *http.Request) string {
+	token := config.Server.AdminToken
+	if token == "" {
+		return "Admin endpoints are disabled"
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Admin-Token")), []byte(token)) != 1 {
+		return "Invalid admin token"
+	}
+	return ""
+}
+
+// auditActor identifies the client making a request for the audit log
+func auditActor(r *http.Request) AuditActor {
+	trusted, _ := parseTrustedProxies(config.Server.TrustedProxies) // Checked by Config.Validate
+	return AuditActor{
+		PlayerID: r.URL.Query().Get("player_id"),
+		IP:       clientIP(r, trusted),
+		APIKey:   apiKeyFingerprint(r.Header.Get("X-API-Key")),
+	}
+}
+
+// handleFeature registers handler for pattern when the feature is enabled.
+// Disabled features answer 404 so their paths don't fall through to other routes.
+func handleFeature(mux *http.ServeMux, feature, pattern string, handler http.HandlerFunc) {
+	if config.FeatureEnabled(feature) {
+		mux.HandleFunc(pattern, handler)
+		return
+	}
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(w, http.StatusNotFound, (&ErrFeatureDisabled{Feature: feature}).Error())
+	})
+}
+
+// isFeatureDisabled reports whether err is a service path refusing a disabled
+// feature. Endpoints answer 404 for it, like a disabled feature's routes, and
+// actions on an existing game 403.
+func isFeatureDisabled(err error) bool {
+	featureErr := (*ErrFeatureDisabled)(nil)
+	return errors.As(err, &featureErr)
 }
 
 func rootHandler(w http.ResponseWriter, r *http.Request) {
 	response := map[string]interface{}{
 		"message": "Welcome to the Wordle API!",
-		"version": "1.0.0",
+		"version": buildVersion,
 		"endpoints": map[string]string{
-			"POST /api/games":      "Create a new game",
-			"GET /api/games/{id}":  "Get game state",
-			"POST /api/games/{id}": "Make a guess",
-			"GET /api/stats":       "Get game statistics",
-			"GET /health":          "Health check",
+			"GET /api/version":                                   "Get the server build version, git commit, build time and Go version",
+			"POST /api/games":                                    "Create a new game (optional guess_word submits a first guess; seed starts a challenge game; word_length, dictionary and target_word customize it)",
+			"GET /api/games/{id}":                                "Get game state (?order=desc lists the newest guess first, ?include=constraints,candidates,keyboard adds analysis)",
+			"GET /api/games/{id}/constraints":                    "Get known letter constraints from the guesses so far",
+			"GET /api/games/{id}/board":                          "Get each position's confirmed letter, ruled-out letters and whether it is still unknown",
+			"GET /api/games/{id}/share?stats={bool}":             "Get share text for a finished game (stats=true adds streak and score)",
+			"GET /api/games/{id}/timeline":                       "Get the game's creation, guesses and completion as ordered events",
+			"GET /api/games/{id}/csv":                            "Download a finished game's guess results as CSV (word, then each position's status)",
+			"GET /api/games/{id}/letter-probabilities":           "Top letters per position among the remaining candidate answers",
+			"GET /api/games/{id}/rank-guesses":                   "Rank allowed guesses by the expected number of candidate answers they leave",
+			"GET /api/games/{id}/solution-path":                  "Greedy guess sequence from the current board to the answer (admin only)",
+			"GET /api/games/{id}/possible?word={word}":           "Check whether a word could still be the answer",
+			"GET /api/games/{id}/nudge":                          "Get a position where the latest guess is wrong",
+			"POST /api/games/{id}":                               "Make a guess (?delta=true returns only the new guess, ?annotate=true adds tile codes and descriptions, ?remaining=true counts dictionary words still possible)",
+			"GET /api/games/by-word?word={word}":                 "List completed games with the given target word",
+			"GET /api/games/won?guesses={n}":                     "List recent games won in exactly n guesses",
+			"GET /api/games/public":                              "List recent game summaries without target words",
+			"GET /api/games/recent-results":                      "List recently completed games with emoji share grids, without target words",
+			"GET /api/players/{id}/best-game":                    "Get the player's fewest-guess win",
+			"GET /api/players/{id}/replayable":                   "List the player's completed games that can be replayed (not daily)",
+			"GET /api/players/{id}/next-word":                    "Get a challenge seed for a word the player hasn't played",
+			"GET /api/players/{id}/rank?by={metric}":             "Get the player's rank and percentile among all players (max_streak, current_streak, games_won or games_played)",
+			"GET /api/daily?date={date}":                         "Get or create the daily game",
+			"GET /api/daily/resume?code={code}":                  "Resume a daily game from its resume code",
+			"POST /api/admin/target-words/import?persist={bool}": "Import newline-delimited target words (admin)",
+			"POST /api/admin/answers/schedule":                   "Regenerate the daily answer schedule (admin)",
+			"GET /api/admin/words/{word}/games":                  "List every game with the target word and its outcome (admin)",
+			"GET /api/stats":                                     "Get game statistics",
+			"GET /api/stats/heatmap":                             "Get per-position guess result counts",
+			"GET /api/evaluate?guess={word}&target={word}":       "Evaluate a guess against a target word without a game",
+			"GET /api/words/neighbors?word={word}":               "List dictionary words differing from the word in exactly one letter",
+			"GET /api/words/normalize?word={word}":               "Show how a word is normalized for matching and whether it is valid",
+			"GET /api/stats/by-difficulty":                       "Get games played and win rate per difficulty tier",
+			"GET /api/stats/recent?group=outcome":                "Recent games grouped into won/lost/in-progress buckets with counts and sample ids",
+			"GET /api/stats/at-risk?threshold={n}":               "In-progress games with at most n guesses left (default 1), without target words",
+			"GET /api/stats/distinct-guesses":                    "Completed games bucketed by how many distinct words were guessed",
+			"GET /api/stats/snapshot":                            "Get totals, win rate, guess distribution, average guesses and top openers in one document",
+			"GET /health":                                        "Health check",
 		},
 	}
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
+func versionHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, CurrentVersion())
+}
+
 func healthHandler(w http.ResponseWriter, r *http.Request) {
+	// Games can't be created without words, even if the database is healthy
+	validWords, targetWords := gameService.WordListSizes()
+	healthy := validWords > 0 && targetWords > 0
+
 	status := map[string]interface{}{
 		"status":    "healthy",
 		"timestamp": time.Now().UTC(),
 		"database":  "connected",
+		"wordlist": map[string]int{
+			"valid":  validWords,
+			"target": targetWords,
+		},
+	}
+
+	if !healthy {
+		status["status"] = "unhealthy"
+		writeJSONResponse(w, http.StatusServiceUnavailable, status)
+		return
 	}
 	writeJSONResponse(w, http.StatusOK, status)
 }
@@ -109,13 +353,20 @@ func gamesHandler(w http.ResponseWriter, r *http.Request) {
 func gameHandler(w http.ResponseWriter, r *http.Request) {
 	// Extract game ID from URL path
 	path := strings.TrimPrefix(r.URL.Path, "/api/games/")
-	gameID := strings.Split(path, "/")[0]
-	
+	parts := strings.Split(path, "/")
+	gameID := parts[0]
+
 	if gameID == "" {
 		writeErrorResponse(w, http.StatusBadRequest, "Game ID is required")
 		return
 	}
 
+	// Sub-resources such as /api/games/{id}/nudge
+	if len(parts) > 1 && parts[1] != "" {
+		gameSubresourceHandler(w, r, gameID, parts[1])
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		getGameHandler(w, r, gameID)
@@ -128,137 +379,1333 @@ func gameHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func createGameHandler(w http.ResponseWriter, r *http.Request) {
-	game, err := gameService.CreateNewGame()
+func playerHandler(w http.ResponseWriter, r *http.Request) {
+	// Extract player ID and sub-resource from URL path
+	path := strings.TrimPrefix(r.URL.Path, "/api/players/")
+	parts := strings.Split(path, "/")
+	playerID := parts[0]
+
+	if playerID == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Player ID is required")
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "best-game" && r.Method == http.MethodGet {
+		getPlayerBestGameHandler(w, r, playerID)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "replayable" && r.Method == http.MethodGet {
+		getPlayerReplayableHandler(w, r, playerID)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "next-word" && r.Method == http.MethodGet {
+		getPlayerNextWordHandler(w, r, playerID)
+		return
+	}
+
+	if len(parts) == 2 && parts[1] == "rank" && r.Method == http.MethodGet {
+		getPlayerRankHandler(w, r, playerID)
+		return
+	}
+
+	writeErrorResponse(w, http.StatusNotFound, "Not found")
+}
+
+func getPlayerBestGameHandler(w http.ResponseWriter, r *http.Request, playerID string) {
+	best, err := gameService.GetPlayerBestGame(playerID)
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to create game: %v", err))
+		if strings.Contains(err.Error(), "no won games") {
+			writeErrorResponse(w, http.StatusNotFound, "No won games found for player")
+		} else {
+			writeInternalErrorResponse(w, "Failed to get best game", err)
+		}
 		return
 	}
 
 	response := GameResponse{
-		Game:    *game,
-		Message: fmt.Sprintf("New game created! You have %d guesses to find the word.", game.MaxGuesses),
+		Game:    best.Game,
+		Guesses: best.Guesses,
 	}
+	response.SetBestProgress(best.Guesses)
+	writeJSONResponse(w, http.StatusOK, response)
+}
 
-	writeJSONResponse(w, http.StatusCreated, response)
+func getPlayerNextWordHandler(w http.ResponseWriter, r *http.Request, playerID string) {
+	seed, err := gameService.SuggestNextSeed(playerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no unplayed") {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to suggest next word", err)
+		}
+		return
+	}
+
+	// Only the seed is returned; POST /api/games with it starts the game
+	response := map[string]interface{}{
+		"seed": seed,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
-func getGameHandler(w http.ResponseWriter, r *http.Request, gameID string) {
-	gameWithGuesses, err := gameService.GetGameWithGuesses(gameID)
+func getPlayerRankHandler(w http.ResponseWriter, r *http.Request, playerID string) {
+	rank, err := gameService.GetPlayerRank(playerID, r.URL.Query().Get("by"))
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		if strings.Contains(err.Error(), "invalid rank metric") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "not configured") {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
 		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get game: %v", err))
+			writeInternalErrorResponse(w, "Failed to get player rank", err)
 		}
 		return
 	}
 
-	response := GameResponse{
-		Game:    gameWithGuesses.Game,
-		Guesses: gameWithGuesses.Guesses,
+	writeJSONResponse(w, http.StatusOK, rank)
+}
+
+func getPlayerReplayableHandler(w http.ResponseWriter, r *http.Request, playerID string) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	games, err := gameService.GetReplayableGames(playerID, limit)
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get replayable games", err)
+		return
 	}
 
+	response := map[string]interface{}{
+		"games": games,
+		"count": len(games),
+	}
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
-func makeGuessHandler(w http.ResponseWriter, r *http.Request, gameID string) {
-	var request MakeGuessRequest
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+func gameSubresourceHandler(w http.ResponseWriter, r *http.Request, gameID, resource string) {
+	switch {
+	case resource == "nudge" && r.Method == http.MethodGet:
+		getNudgeHandler(w, r, gameID)
+	case resource == "constraints" && r.Method == http.MethodGet:
+		getConstraintsHandler(w, r, gameID)
+	case resource == "board" && r.Method == http.MethodGet:
+		getBoardHandler(w, r, gameID)
+	case resource == "possible" && r.Method == http.MethodGet:
+		getPossibleHandler(w, r, gameID)
+	case resource == "share" && r.Method == http.MethodGet:
+		getShareHandler(w, r, gameID)
+	case resource == "timeline" && r.Method == http.MethodGet:
+		getTimelineHandler(w, r, gameID)
+	case resource == "csv" && r.Method == http.MethodGet:
+		getResultsCSVHandler(w, r, gameID)
+	case resource == "letter-probabilities" && r.Method == http.MethodGet:
+		getLetterProbabilitiesHandler(w, r, gameID)
+	case resource == "rank-guesses" && r.Method == http.MethodGet:
+		getRankGuessesHandler(w, r, gameID)
+	case resource == "solution-path" && r.Method == http.MethodGet:
+		// The path spells out the answer, so it is an admin coaching/debug tool
+		requireAdmin(func(w http.ResponseWriter, r *http.Request) {
+			getSolutionPathHandler(w, r, gameID)
+		})(w, r)
+	default:
+		writeErrorResponse(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func getLetterProbabilitiesHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	positions, candidates, err := gameService.LetterProbabilities(gameID)
+	if err != nil {
+		if isFeatureDisabled(err) {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else {
+			writeInternalErrorResponse(w, "Failed to compute letter probabilities", err)
+		}
 		return
 	}
 
-	if request.GuessWord == "" {
-		writeErrorResponse(w, http.StatusBadRequest, "Guess word is required")
+	response := map[string]interface{}{
+		"positions":  positions,
+		"candidates": candidates,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func getRankGuessesHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	// ?limit= is how many of the best guesses to return, clamped like other lists
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	ranking, err := gameService.RankGuesses(gameID, limit)
+	if err != nil {
+		if isFeatureDisabled(err) {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else if strings.Contains(err.Error(), "already completed") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to rank guesses", err)
+		}
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, ranking)
+}
+
+func getSolutionPathHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	path, err := gameService.SolutionPath(gameID)
+	if err != nil {
+		if isFeatureDisabled(err) {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else if strings.Contains(err.Error(), "already completed") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to compute solution path", err)
+		}
 		return
 	}
 
-	response, err := gameService.MakeGuess(gameID, request.GuessWord)
+	writeJSONResponse(w, http.StatusOK, path)
+}
+
+func getResultsCSVHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	data, err := gameService.GetResultsCSV(gameID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			writeErrorResponse(w, http.StatusNotFound, "Game not found")
-		} else if strings.Contains(err.Error(), "not a valid word") || 
-		          strings.Contains(err.Error(), "must be") ||
-		          strings.Contains(err.Error(), "already completed") ||
-		          strings.Contains(err.Error(), "no remaining") {
+		} else if strings.Contains(err.Error(), "not completed") {
 			writeErrorResponse(w, http.StatusBadRequest, err.Error())
 		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to process guess: %v", err))
+			writeInternalErrorResponse(w, "Failed to export game", err)
 		}
 		return
 	}
 
-	writeJSONResponse(w, http.StatusOK, response)
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "wordle-"+gameID+".csv"))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
 }
 
-func deleteGameHandler(w http.ResponseWriter, r *http.Request, gameID string) {
-	err := gameService.DeleteGame(gameID)
+func getTimelineHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	events, err := gameService.GetTimeline(gameID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			writeErrorResponse(w, http.StatusNotFound, "Game not found")
 		} else {
-			writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to delete game: %v", err))
+			writeInternalErrorResponse(w, "Failed to get timeline", err)
 		}
 		return
 	}
 
-	response := map[string]string{
-		"message": "Game deleted successfully",
+	response := map[string]interface{}{
+		"events": events,
+		"count":  len(events),
 	}
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
-func getRecentGamesHandler(w http.ResponseWriter, r *http.Request) {
-	games, err := gameService.GetRecentGames(10)
+func getNudgeHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	nudge, err := gameService.WithActor(auditActor(r)).GetNudge(gameID)
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get recent games: %v", err))
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else if strings.Contains(err.Error(), "no guesses") ||
+			strings.Contains(err.Error(), "already completed") ||
+			strings.Contains(err.Error(), "no incorrect") ||
+			strings.Contains(err.Error(), "hint limit") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to get nudge", err)
+		}
 		return
 	}
 
-	response := map[string]interface{}{
-		"games": games,
-		"count": len(games),
+	writeJSONResponse(w, http.StatusOK, nudge)
+}
+
+func getShareHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	// ?stats=true adds the player's streak and the game's score
+	includeStats, _ := strconv.ParseBool(r.URL.Query().Get("stats"))
+
+	text, err := gameService.GetShareText(gameID, includeStats)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else if strings.Contains(err.Error(), "not completed") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to get share text", err)
+		}
+		return
 	}
-	writeJSONResponse(w, http.StatusOK, response)
+
+	writeJSONResponse(w, http.StatusOK, map[string]string{"text": text})
 }
 
-func statsHandler(w http.ResponseWriter, r *http.Request) {
-	stats, err := gameService.GetGameStats()
+func getConstraintsHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	constraints, err := gameService.GetConstraints(gameID)
 	if err != nil {
-		writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Failed to get stats: %v", err))
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else {
+			writeInternalErrorResponse(w, "Failed to get constraints", err)
+		}
 		return
 	}
 
-	writeJSONResponse(w, http.StatusOK, stats)
+	writeJSONResponse(w, http.StatusOK, constraints)
 }
 
-// Helper functions
+func getBoardHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	board, err := gameService.GetBoard(gameID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else {
+			writeInternalErrorResponse(w, "Failed to get board", err)
+		}
+		return
+	}
 
-func writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		log.Printf("Failed to encode JSON response: %v", err)
+	response := map[string]interface{}{
+		"positions": board,
 	}
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
-func writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
-	response := ErrorResponse{
-		Error: message,
-		Code:  statusCode,
+func getPossibleHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	word := r.URL.Query().Get("word")
+	possible, err := gameService.IsStillPossible(gameID, word)
+	if err != nil {
+		if isFeatureDisabled(err) {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else if strings.Contains(err.Error(), "required") ||
+			strings.Contains(err.Error(), "must be") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to check word", err)
+		}
+		return
 	}
-	writeJSONResponse(w, statusCode, response)
+
+	response := map[string]interface{}{
+		"word":     gameService.upper(strings.TrimSpace(word)),
+		"possible": possible,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
-// runWordListDemo runs the original word list demo when database is not available
-func runWordListDemo(wordList *WordList) {
-	fmt.Println("=== WordList Demo Mode ===")
-	fmt.Printf("Total words loaded: %d\n", wordList.Size())
+func createGameHandler(w http.ResponseWriter, r *http.Request) {
+	// The request body is optional; an empty body creates a plain game
+	var request CreateGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && err != io.EOF {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if request.MaxHints != nil && *request.MaxHints < 0 {
+		writeErrorResponse(w, http.StatusBadRequest, "max_hints must be at least 0")
+		return
+	}
+	// Regular games may allow other than the configured number of guesses
+	if _, err := gameService.newGameMaxGuesses(request.MaxGuesses); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	tutorial := request.Tutorial || r.URL.Query().Get("tutorial") == "true"
+	if request.Boards > 1 && (tutorial || request.Seed != nil || request.CustomChallenge() != nil || request.GuessWord != "") {
+		writeErrorResponse(w, http.StatusBadRequest, "Multi-board games cannot be tutorial or challenge games, or start with a guess")
+		return
+	}
+
+	// Tutorial games follow a fixed script, so they can't start with a guess
+	if tutorial && request.GuessWord != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Tutorial games cannot start with a guess")
+		return
+	}
+	if tutorial && request.CustomChallenge() != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Tutorial games cannot be custom challenges")
+		return
+	}
+	if !tutorial && (request.CustomChallenge() != nil || request.Seed != nil) && request.GuessWord != "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Challenge games cannot start with a guess")
+		return
+	}
+	// Tutorial and challenge games keep the configured guesses so results compare
+	if request.MaxGuesses != 0 && (tutorial || request.CustomChallenge() != nil || request.Seed != nil) {
+		writeErrorResponse(w, http.StatusBadRequest, "max_guesses only applies to regular and multi-board games")
+		return
+	}
+
+	// Multi-tenant hosting meters game creation per API key and day. Only
+	// created games count: the quota is charged here and refunded when the
+	// request fails later, whether it is rejected or the game can't be stored.
+	if config.Server.RequireAPIKey {
+		key, today := r.Header.Get("X-API-Key"), gameService.Today()
+		if err := gameService.UseGameQuota(key, today); err != nil {
+			if strings.Contains(err.Error(), "api key is required") || strings.Contains(err.Error(), "unknown api key") {
+				writeErrorResponse(w, http.StatusUnauthorized, err.Error())
+			} else if strings.Contains(err.Error(), "quota") {
+				writeErrorResponse(w, http.StatusTooManyRequests, err.Error())
+			} else {
+				writeInternalErrorResponse(w, "Failed to check api key", err)
+			}
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		w = recorder
+		defer func() {
+			if recorder.status == http.StatusCreated {
+				return
+			}
+			if err := gameService.RefundGameQuota(key, today); err != nil {
+				log.Printf("Warning: failed to refund game quota: %v", err)
+			}
+		}()
+	}
+
+	// Client IP and user agent are only kept when enabled, for abuse analysis
+	var client *ClientInfo
+	if config.Server.RecordClientInfo {
+		trusted, _ := parseTrustedProxies(config.Server.TrustedProxies) // Checked by Config.Validate
+		info := clientInfoFromRequest(r, trusted)
+		client = &info
+	}
+
+	// The target and its difficulty are settled first, so the solver
+	// simulation doesn't run inside the transaction below
+	draft, err := draftGameForRequest(gameService, request, tutorial)
+	if err != nil {
+		writeHandlerError(w, "Failed to create game", err)
+		return
+	}
+
+	// The player named by username, the game and the options set with it are
+	// created in one transaction, so a failure part way leaves none of them
+	actor := auditActor(r)
+	var response *GameResponse
+	err = gameService.withTransaction(func(tx *GameService) error {
+		var player *Player
+		var playerCreated bool
+		if request.Username != "" {
+			var err error
+			player, playerCreated, err = tx.ResolvePlayer(request.Username)
+			if err != nil {
+				if strings.Contains(err.Error(), "username") || strings.Contains(err.Error(), "not found") {
+					return badRequestError(err)
+				}
+				return internalError("Failed to get player", err)
+			}
+			if actor.PlayerID == "" {
+				actor.PlayerID = player.ID
+			}
+		}
 
-	// Seed random number generator
-	rand.Seed(time.Now().UnixNano())
+		// Game creation and the options set with it are audited as this client
+		service := tx.WithActor(actor)
+		var err error
+		if response, err = createGameForRequest(service, draft, request, tutorial, client); err != nil {
+			return err
+		}
+		if err := applyCreateOptions(service, response, request, player, playerCreated); err != nil {
+			return internalError("Failed to create game", err)
+		}
+		return nil
+	})
+	if err != nil {
+		writeHandlerError(w, "Failed to create game", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// draftGameForRequest picks the target of the kind of game request asks for
+// and estimates its difficulty, before any transaction opens. Errors are
+// *handlerErrors that write the response reporting them.
+func draftGameForRequest(service *GameService, request CreateGameRequest, tutorial bool) (*gameDraft, error) {
+	var draft *gameDraft
+	var err error
+	switch {
+	case tutorial:
+		if draft, err = service.draftTutorialGame(); err != nil {
+			return nil, internalError("Failed to create tutorial game", err)
+		}
+	case request.CustomChallenge() != nil:
+		// Custom challenges choose their word length, dictionary, and seed or target
+		if draft, err = service.draftCustomChallenge(*request.CustomChallenge()); err != nil {
+			if strings.Contains(err.Error(), "must") ||
+				strings.Contains(err.Error(), "unknown dictionary") ||
+				strings.Contains(err.Error(), "no target words") {
+				return nil, badRequestError(err)
+			}
+			return nil, internalError("Failed to create challenge game", err)
+		}
+	case request.Seed != nil:
+		// Seeded challenge games give everyone with the seed the same word
+		if draft, err = service.draftChallengeGame(*request.Seed); err != nil {
+			return nil, internalError("Failed to create challenge game", err)
+		}
+	case request.Boards > 1:
+		// Multi-board games play every guess against several targets at once
+		if draft, err = service.draftMultiBoardGame(request.Boards, request.MaxGuesses); err != nil {
+			if errors.Is(err, ErrBoardCount) || errors.Is(err, ErrMultiBoardDisabled) {
+				return nil, badRequestError(err)
+			}
+			return nil, internalError("Failed to create multi-board game", err)
+		}
+	default:
+		if draft, err = service.draftNewGame(request.MaxGuesses); err != nil {
+			return nil, internalError("Failed to create game", err)
+		}
+	}
+	return draft, nil
+}
+
+// createGameForRequest creates the game drafted for request, with its first
+// guess when the request has one. Errors are *handlerErrors that write the
+// response reporting them.
+func createGameForRequest(service *GameService, draft *gameDraft, request CreateGameRequest, tutorial bool, client *ClientInfo) (*GameResponse, error) {
+	// An initial guess is created together with the game
+	if request.GuessWord != "" {
+		response, err := service.createDraftWithGuess(draft, request.GuessWord, client)
+		if err != nil {
+			return nil, &handlerError{err: err, write: func(w http.ResponseWriter) { writeGuessErrorResponse(w, err) }}
+		}
+		return response, nil
+	}
+
+	// Only regular games record the client
+	regular := !tutorial && request.CustomChallenge() == nil && request.Seed == nil && request.Boards <= 1
+	if !regular {
+		client = nil
+	}
+	game, err := service.createDraft(draft, client)
+	if err != nil {
+		return nil, internalError("Failed to create game", err)
+	}
+
+	var message string
+	switch {
+	case tutorial:
+		return service.tutorialResponse(game), nil
+	case request.CustomChallenge() != nil:
+		message = fmt.Sprintf("Custom challenge created! You have %d guesses to find the %d-letter word.", game.MaxGuesses, game.WordLength())
+	case request.Seed != nil:
+		message = fmt.Sprintf("Challenge game created! You have %d guesses to find the word.", game.MaxGuesses)
+	case request.Boards > 1:
+		message = fmt.Sprintf("Multi-board game created! You have %d guesses to find all %d words.", game.MaxGuesses, len(game.Boards))
+	default:
+		message = fmt.Sprintf("New game created! You have %d guesses to find the word.", game.MaxGuesses)
+	}
+	return &GameResponse{Game: *game, Message: message}, nil
+}
+
+// handlerError is an error that carries the response reporting it, so a
+// handler can roll back a transaction and still answer the way the failing
+// step decided
+type handlerError struct {
+	err   error
+	write func(w http.ResponseWriter)
+}
+
+func (e *handlerError) Error() string { return e.err.Error() }
+func (e *handlerError) Unwrap() error { return e.err }
+
+// badRequestError reports err to the client with a 400
+func badRequestError(err error) error {
+	return &handlerError{err: err, write: func(w http.ResponseWriter) {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	}}
+}
+
+// internalError reports err as an internal error described by message
+func internalError(message string, err error) error {
+	return &handlerError{err: err, write: func(w http.ResponseWriter) {
+		writeInternalErrorResponse(w, message, err)
+	}}
+}
+
+// writeHandlerError writes the response a *handlerError carries, or reports
+// any other error as an internal error described by message
+func writeHandlerError(w http.ResponseWriter, message string, err error) {
+	var handlerErr *handlerError
+	if errors.As(err, &handlerErr) {
+		handlerErr.write(w)
+		return
+	}
+	writeInternalErrorResponse(w, message, err)
+}
+
+// applyCreateOptions associates a newly created game with its player, if any,
+// stores the request's per-game hint limit, if any, and reports the hints the
+// game has left
+func applyCreateOptions(service *GameService, response *GameResponse, request CreateGameRequest, player *Player, playerCreated bool) error {
+	if player != nil {
+		if err := service.AssignPlayer(response.Game.ID, player.ID); err != nil {
+			return err
+		}
+		response.PlayerID = player.ID
+		response.PlayerCreated = playerCreated
+	}
+	if request.MaxHints != nil {
+		if err := service.SetMaxHints(&response.Game, *request.MaxHints); err != nil {
+			return err
+		}
+	}
+	response.HintsRemaining = service.HintsRemaining(&response.Game)
+	return nil
+}
+
+func getGameHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	// ?include=constraints,candidates,keyboard adds computed analysis sections
+	includes, err := ParseIncludes(r.URL.Query().Get("include"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// ?order=desc lists the newest guess first
+	gameWithGuesses, err := gameService.GetGameWithGuessesOrdered(gameID, r.URL.Query().Get("order"))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid order") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else {
+			writeInternalErrorResponse(w, "Failed to get game", err)
+		}
+		return
+	}
+
+	response := GameResponse{
+		Game:       gameWithGuesses.Game,
+		Guesses:    gameWithGuesses.Guesses,
+		Definition: gameService.RevealedDefinition(&gameWithGuesses.Game),
+		Anagrams:   gameService.RevealedAnagrams(&gameWithGuesses.Game),
+
+		HintsRemaining: gameService.HintsRemaining(&gameWithGuesses.Game),
+	}
+	response.SetBestProgress(gameWithGuesses.Guesses)
+	gameService.AddBoards(&response)
+	gameService.AddAnalysis(&response, includes)
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func makeGuessHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	var request MakeGuessRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if request.GuessWord == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Guess word is required")
+		return
+	}
+
+	// ?delta=true returns only the new guess rather than the full history
+	delta, _ := strconv.ParseBool(r.URL.Query().Get("delta"))
+	// ?annotate=true adds a status code and spelled-out description to each tile
+	annotate, _ := strconv.ParseBool(r.URL.Query().Get("annotate"))
+	// ?skip_dictionary=true accepts words outside the word list, for QA; admin only
+	skipDictionary, _ := strconv.ParseBool(r.URL.Query().Get("skip_dictionary"))
+	if skipDictionary {
+		if message := adminTokenError(r); message != "" {
+			writeErrorResponse(w, http.StatusForbidden, message)
+			return
+		}
+	}
+
+	// ?remaining=true counts the dictionary words still consistent with the board
+	countRemaining, _ := strconv.ParseBool(r.URL.Query().Get("remaining"))
+
+	opts := GuessOptions{Delta: delta, Annotate: annotate, SkipDictionary: skipDictionary, CountRemaining: countRemaining}
+	response, err := gameService.WithActor(auditActor(r)).MakeGuessWithOptions(gameID, request.GuessWord, opts)
+	if err != nil {
+		writeGuessErrorResponse(w, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func deleteGameHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	err := gameService.WithActor(auditActor(r)).DeleteGame(gameID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else {
+			writeInternalErrorResponse(w, "Failed to delete game", err)
+		}
+		return
+	}
+
+	response := map[string]string{
+		"message": "Game deleted successfully",
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func getRecentGamesHandler(w http.ResponseWriter, r *http.Request) {
+	// A missing limit uses the default page size
+	limit, err := parseListLimit(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	games, err := gameService.GetRecentGamesByMode(r.URL.Query().Get("mode"), limit)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid mode") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to get recent games", err)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"games": games,
+		"count": len(games),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func publicGamesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	summaries, err := gameService.GetRecentGameSummaries(limit)
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get recent games", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"games": summaries,
+		"count": len(summaries),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func recentResultsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	results, err := gameService.GetRecentResults(limit)
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get recent results", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"results": results,
+		"count":   len(results),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func gamesByWordHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	word := r.URL.Query().Get("word")
+	if word == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Query parameter 'word' is required")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	games, err := gameService.GetGamesByTargetWord(word, limit)
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get games", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"games": games,
+		"count": len(games),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func gamesWonHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	guesses, err := strconv.Atoi(r.URL.Query().Get("guesses"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Query parameter 'guesses' must be a number")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	games, err := gameService.GetGamesWonInGuesses(guesses, limit)
+	if err != nil {
+		if strings.Contains(err.Error(), "must be") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to get games", err)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"games": games,
+		"count": len(games),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// defaultAtRiskThreshold lists games on their last guess unless ?threshold= is given
+const defaultAtRiskThreshold = 1
+
+func atRiskGamesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	threshold := defaultAtRiskThreshold
+	if value := r.URL.Query().Get("threshold"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Query parameter 'threshold' must be a number")
+			return
+		}
+		threshold = parsed
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	games, err := gameService.GetAtRiskGames(threshold, limit)
+	if err != nil {
+		if strings.Contains(err.Error(), "must be") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to get at-risk games", err)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"games":     games,
+		"count":     len(games),
+		"threshold": threshold,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func dailyGameHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Defaults to today's puzzle (UTC); ?date=YYYY-MM-DD selects another day
+	date := gameService.Today()
+	if value := r.URL.Query().Get("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+			return
+		}
+		date = parsed
+	}
+
+	// ?player_id= gives the player their own daily game
+	game, err := gameService.WithActor(auditActor(r)).CreateOrGetDailyGame(r.URL.Query().Get("player_id"), date)
+	if err != nil {
+		if strings.Contains(err.Error(), "no answer scheduled") {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to get daily game", err)
+		}
+		return
+	}
+
+	response := GameResponse{
+		Game:    *game,
+		Message: fmt.Sprintf("Daily puzzle for %s", date.Format("2006-01-02")),
+	}
+	if response.ResumeCode, err = gameService.DailyResumeCode(game); err != nil {
+		writeInternalErrorResponse(w, "Failed to create resume code", err)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// dailyResumeHandler returns the daily game a resume code from GET /api/daily
+// refers to, so it can be continued on another device
+func dailyResumeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "code query parameter is required")
+		return
+	}
+
+	gameWithGuesses, err := gameService.ResumeDailyGame(code)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid resume code") {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid resume code")
+		} else if strings.Contains(err.Error(), "not configured") {
+			writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else {
+			writeInternalErrorResponse(w, "Failed to resume daily game", err)
+		}
+		return
+	}
+
+	response := GameResponse{
+		Game:       gameWithGuesses.Game,
+		Guesses:    gameWithGuesses.Guesses,
+		Definition: gameService.RevealedDefinition(&gameWithGuesses.Game),
+		Anagrams:   gameService.RevealedAnagrams(&gameWithGuesses.Game),
+		ResumeCode: code,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func anagramHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	length := config.Game.WordLength
+	if value := r.URL.Query().Get("length"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "Invalid length")
+			return
+		}
+		length = parsed
+	}
+
+	game, err := gameService.WithActor(auditActor(r)).CreateAnagramGame(length)
+	if err != nil {
+		if strings.Contains(err.Error(), "no target words") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to create anagram game", err)
+		}
+		return
+	}
+
+	// Only the scramble is returned; the original word is the answer
+	response := map[string]interface{}{
+		"game_id":     game.ID,
+		"scramble":    game.Scramble,
+		"length":      length,
+		"max_guesses": game.MaxGuesses,
+	}
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// normalizeWordHandler shows how ?word= is normalized for matching, for
+// debugging why a word does or doesn't match the word list
+func normalizeWordHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	if !r.URL.Query().Has("word") {
+		writeErrorResponse(w, http.StatusBadRequest, "word query parameter is required")
+		return
+	}
+
+	normalization, err := gameService.NormalizeWord(r.URL.Query().Get("word"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, normalization)
+}
+
+func wordNeighborsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	neighbors, err := gameService.WordNeighbors(r.URL.Query().Get("word"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := map[string]interface{}{
+		"neighbors": neighbors,
+		"count":     len(neighbors),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func scheduleAnswersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var request ScheduleAnswersRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	start, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid start_date, expected YYYY-MM-DD")
+		return
+	}
+
+	answers, err := gameService.ScheduleAnswers(start, request.Days)
+	if err != nil {
+		if strings.Contains(err.Error(), "must be between") || strings.Contains(err.Error(), "not enough") {
+			writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeInternalErrorResponse(w, "Failed to schedule answers", err)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"scheduled": len(answers),
+		"from":      answers[0].PuzzleDate.Format("2006-01-02"),
+		"to":        answers[len(answers)-1].PuzzleDate.Format("2006-01-02"),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func importTargetWordsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// ?persist=true also appends the added words to the target word file
+	persist, _ := strconv.ParseBool(r.URL.Query().Get("persist"))
+
+	body := http.MaxBytesReader(w, r.Body, maxImportBytes)
+	stats, err := gameService.ImportTargetWords(body, persist)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeErrorResponse(w, http.StatusRequestEntityTooLarge, "Import body too large")
+		} else {
+			writeInternalErrorResponse(w, "Failed to import target words", err)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"added":      stats.Added,
+		"skipped":    stats.Skipped(),
+		"duplicates": stats.Duplicates,
+		"invalid":    stats.Invalid,
+		"persisted":  stats.Persisted,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func adminGameHandler(w http.ResponseWriter, r *http.Request) {
+	// Extract game ID and action from URL path
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/games/")
+	parts := strings.Split(path, "/")
+
+	if len(parts) == 2 && parts[0] != "" && parts[1] == "reconcile" && r.Method == http.MethodPost {
+		reconcileGameHandler(w, r, parts[0])
+		return
+	}
+
+	writeErrorResponse(w, http.StatusNotFound, "Not found")
+}
+
+func adminWordHandler(w http.ResponseWriter, r *http.Request) {
+	// Extract word and action from URL path
+	path := strings.TrimPrefix(r.URL.Path, "/api/admin/words/")
+	parts := strings.Split(path, "/")
+
+	if len(parts) == 2 && parts[0] != "" && parts[1] == "games" && r.Method == http.MethodGet {
+		wordGamesHandler(w, r, parts[0])
+		return
+	}
+
+	writeErrorResponse(w, http.StatusNotFound, "Not found")
+}
+
+func wordGamesHandler(w http.ResponseWriter, r *http.Request, word string) {
+	// The service clamps the limit; missing or invalid values use the default page size
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	outcomes, err := gameService.GetWordGameOutcomes(word, limit)
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get games for word", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"games": outcomes,
+		"count": len(outcomes),
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func reconcileGameHandler(w http.ResponseWriter, r *http.Request, gameID string) {
+	game, changed, err := gameService.WithActor(auditActor(r)).ReconcileGame(gameID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeErrorResponse(w, http.StatusNotFound, "Game not found")
+		} else {
+			writeInternalErrorResponse(w, "Failed to reconcile game", err)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"game":    game,
+		"changed": changed,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func statsHandler(w http.ResponseWriter, r *http.Request) {
+	stats, err := gameService.GetGameStats()
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get stats", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, stats)
+}
+
+func heatmapHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	heatmap, err := gameService.GetGuessHeatmap()
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get heatmap", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"positions": heatmap,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func statsByDifficultyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	tiers, err := gameService.GetStatsByDifficulty()
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get difficulty stats", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"tiers": tiers,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+func statsSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	snapshot, err := gameService.GetStatsSnapshot()
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get stats snapshot", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, snapshot)
+}
+
+func distinctGuessesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	distribution, err := gameService.GetDistinctGuessDistribution()
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get distinct guess distribution", err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, distribution)
+}
+
+func recentStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	// Outcome is the only grouping so far, and the default
+	group := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("group")))
+	if group != "" && group != "outcome" {
+		writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid group %q (expected \"outcome\")", group))
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	buckets, total, err := gameService.GetRecentGamesByOutcome(limit)
+	if err != nil {
+		writeInternalErrorResponse(w, "Failed to get recent games", err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"group":   "outcome",
+		"buckets": buckets,
+		"total":   total,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// parseListLimit reads ?limit= for GET /api/games, the one list endpoint that
+// rejects a bad limit. A missing limit is 0, which the service replaces with
+// the default page size; anything but a number from 1 to the maximum page size
+// is an error. Every other list endpoint passes its limit straight to the
+// service, which clamps it with clampLimit.
+func parseListLimit(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.URL.Query().Get("limit"))
+	if value == "" {
+		return 0, nil
+	}
+	maxSize := gameService.maxPageSize()
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > maxSize {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxSize)
+	}
+	return limit, nil
+}
+
+func evaluateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	result, err := gameService.Evaluate(query.Get("guess"), query.Get("target"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, result)
+}
+
+// Helper functions
+
+func writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode JSON response: %v", err)
+	}
+}
+
+// writeGuessErrorResponse maps errors from guess processing to HTTP responses
+func writeGuessErrorResponse(w http.ResponseWriter, err error) {
+	if isDatabaseUnavailable(err) {
+		writeDatabaseUnavailableResponse(w)
+	} else if strings.Contains(err.Error(), "server busy") {
+		w.Header().Set("Retry-After", strconv.Itoa(guessRetryAfterSeconds))
+		writeErrorResponse(w, http.StatusServiceUnavailable, err.Error())
+	} else if isFeatureDisabled(err) {
+		// The game exists, but its mode is switched off
+		writeErrorResponse(w, http.StatusForbidden, err.Error())
+	} else if strings.Contains(err.Error(), "not found") {
+		writeErrorResponse(w, http.StatusNotFound, "Game not found")
+	} else if lengthErr := (*ErrWrongLength)(nil); errors.As(err, &lengthErr) && config.Game.LengthErrorDetails {
+		writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+			Details: lengthErr,
+		})
+	} else if invalidErr := (*ErrInvalidWord)(nil); errors.As(err, &invalidErr) && invalidErr.Suggestions != nil {
+		writeJSONResponse(w, http.StatusBadRequest, ErrorResponse{
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+			Details: invalidErr,
+		})
+	} else if strings.Contains(err.Error(), "not a valid word") ||
+		strings.Contains(err.Error(), "must be") ||
+		strings.Contains(err.Error(), "already completed") ||
+		strings.Contains(err.Error(), "no remaining") ||
+		strings.Contains(err.Error(), "already guessed") ||
+		strings.Contains(err.Error(), "only rearranges") {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	} else {
+		writeInternalErrorResponse(w, "Failed to process guess", err)
+	}
+}
+
+// writeInternalErrorResponse reports an unexpected error as a 500, or as a
+// retryable 503 without internal details when the database is unreachable
+func writeInternalErrorResponse(w http.ResponseWriter, message string, err error) {
+	if isDatabaseUnavailable(err) {
+		writeDatabaseUnavailableResponse(w)
+		return
+	}
+	writeErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("%s: %v", message, err))
+}
+
+// writeDatabaseUnavailableResponse sends a 503 with Retry-After and the
+// database_unavailable error code
+func writeDatabaseUnavailableResponse(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", strconv.Itoa(databaseRetryAfterSeconds))
+	writeJSONResponse(w, http.StatusServiceUnavailable, ErrorResponse{
+		Error:     "Database unavailable, please retry later",
+		Code:      http.StatusServiceUnavailable,
+		ErrorCode: ErrorCodeDatabaseUnavailable,
+	})
+}
+
+func writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	response := ErrorResponse{
+		Error: message,
+		Code:  statusCode,
+	}
+	writeJSONResponse(w, statusCode, response)
+}
+
+// runWordListDemo runs the original word list demo when database is not available
+func runWordListDemo(wordList *WordList) {
+	fmt.Println("=== WordList Demo Mode ===")
+	fmt.Printf("Total words loaded: %d\n", wordList.Size())
 
 	fmt.Printf("Random word: %s\n", wordList.RandomWord())
 	fmt.Printf("Random word: %s\n", wordList.RandomWord())
@@ -271,7 +1718,7 @@ func runWordListDemo(wordList *WordList) {
 	// Get sample five-letter words
 	sampleWords := make([]string, 0, 10)
 	for i := 0; i < 10 && i < len(fiveLetterWords); i++ {
-		idx := rand.Intn(len(fiveLetterWords))
+		idx := rng.Intn(len(fiveLetterWords))
 		sampleWords = append(sampleWords, fiveLetterWords[idx])
 	}
 	fmt.Printf("Sample five-letter words: %s\n", strings.Join(sampleWords, ", "))
@@ -304,7 +1751,7 @@ func runWordListDemo(wordList *WordList) {
 
 			sample := make([]string, 0, sampleSize)
 			for i := 0; i < sampleSize; i++ {
-				idx := rand.Intn(len(words))
+				idx := rng.Intn(len(words))
 				sample = append(sample, words[idx])
 			}
 			fmt.Printf("%d-letter words sample: %s\n", length, strings.Join(sample, ", "))
@@ -0,0 +1,1890 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// setupTestServer wires the global service and config to mocks and returns a routed mux
+func setupTestServer(t *testing.T, features string) *http.ServeMux {
+	originalService, originalConfig := gameService, config
+	t.Cleanup(func() {
+		gameService, config = originalService, originalConfig
+	})
+
+	gameConfig := GameConfig{MaxGuesses: 6, WordLength: 5}
+	config = &Config{Features: parseFeatures(features), Game: gameConfig}
+	gameService = NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), NewMockWordList(), &config.Game)
+
+	mux := http.NewServeMux()
+	setupRoutes(mux)
+	return mux
+}
+
+func TestFeatureFlaggedRoutes(t *testing.T) {
+	mux := setupTestServer(t, FeatureHeatmap)
+
+	// Enabled feature is served
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats/heatmap", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected enabled heatmap to return 200, got %d", recorder.Code)
+	}
+
+	// Disabled feature is not served
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/by-word?word=crane", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected disabled by-word endpoint to return 404, got %d", recorder.Code)
+	}
+}
+
+func TestFeatureFlaggedRoutesAllDisabled(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	for _, path := range []string{"/api/stats/heatmap", "/api/games/by-word?word=crane"} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected %s to return 404 when disabled, got %d", path, recorder.Code)
+		}
+	}
+
+	// Core routes are unaffected by feature flags
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected /api/stats to return 200, got %d", recorder.Code)
+	}
+}
+
+func TestFeatureFlaggedServicePaths(t *testing.T) {
+	mux := setupTestServer(t, FeatureHeatmap)
+	config.Game.DailyDerivedWords = true
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	daily, err := gameService.CreateOrGetDailyGame("", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Failed to create daily game: %v", err)
+	}
+	gameService.SetFeatures(config.Features)
+
+	// Solver paths under /api/games/{id} are refused like a disabled route
+	for _, path := range []string{"/possible?word=crane", "/letter-probabilities", "/rank-guesses"} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+path, nil))
+		if recorder.Code != http.StatusNotFound || !strings.Contains(recorder.Body.String(), "solver") {
+			t.Errorf("Expected %s to return 404 with solver disabled, got %d: %s", path, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	// An existing daily game can't be played with daily disabled
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games/"+daily.ID, strings.NewReader(`{"guess_word":"WORLD"}`)))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected a guess on a daily game to return 403 with daily disabled, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if _, err := gameService.CreateOrGetDailyGame("", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)); !isFeatureDisabled(err) {
+		t.Errorf("Expected the daily feature error, got %v", err)
+	}
+
+	// Practice games play on
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games/"+game.ID, strings.NewReader(`{"guess_word":"WORLD"}`)))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected a guess on a practice game to return 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestPossibleHandlerUsesLocaleCasing(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Game.Locale = "tr"
+	gameService = NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), NewMockWordList(), &config.Game)
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"/possible?word=pilot", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response["word"] != "PİLOT" {
+		t.Errorf("Expected the word uppercased for the tr locale, got %v", response["word"])
+	}
+}
+
+func TestHealthHandlerWordList(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200 with populated word list, got %d", recorder.Code)
+	}
+
+	var body struct {
+		Status   string         `json:"status"`
+		WordList map[string]int `json:"wordlist"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode health response: %v", err)
+	}
+	if body.Status != "healthy" || body.WordList["valid"] != 7 || body.WordList["target"] != 7 {
+		t.Errorf("Unexpected health response: %+v", body)
+	}
+
+	// An empty word list makes the service unavailable
+	gameService = NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), &MockWordList{words: []string{}}, &config.Game)
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 with empty word list, got %d", recorder.Code)
+	}
+}
+
+func TestVersionHandlerDefaults(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/version", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", recorder.Code)
+	}
+
+	var info VersionInfo
+	if err := json.NewDecoder(recorder.Body).Decode(&info); err != nil {
+		t.Fatalf("Failed to decode version response: %v", err)
+	}
+	want := VersionInfo{Version: "dev", Commit: "unknown", BuildTime: "unknown", GoVersion: runtime.Version()}
+	if info != want {
+		t.Errorf("Expected default build info %+v, got %+v", want, info)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/version", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", recorder.Code)
+	}
+}
+
+func TestResultsCSVEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	get := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"/csv", nil))
+		return recorder
+	}
+
+	if recorder := get(); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unfinished game, got %d", recorder.Code)
+	}
+
+	words := []string{"WORLD", "CRANE", "HELLO"}
+	for _, word := range words {
+		if _, err := gameService.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+
+	recorder := get()
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/csv") {
+		t.Errorf("Expected CSV content type, got %q", contentType)
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); !strings.Contains(disposition, "wordle-"+game.ID+".csv") {
+		t.Errorf("Expected filename in Content-Disposition, got %q", disposition)
+	}
+
+	records, err := csv.NewReader(recorder.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != len(words)+1 {
+		t.Fatalf("Expected a header and %d guess rows, got %v", len(words), records)
+	}
+	guesses, err := gameService.guessRepo.GetGuessesByGameID(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get guesses: %v", err)
+	}
+	for i, guess := range guesses {
+		row := records[guess.GuessNumber]
+		if row[0] != words[guess.GuessNumber-1] || len(row) != 6 {
+			t.Errorf("Guess %d: unexpected row %v", i+1, row)
+			continue
+		}
+		for position, letter := range guess.Result {
+			if row[position+1] != letter.Status {
+				t.Errorf("Guess %s position %d: expected %s, got %s", guess.GuessWord, position+1, letter.Status, row[position+1])
+			}
+		}
+	}
+}
+
+func TestWordGamesEndpointRequiresAdmin(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Server.AdminToken = "secret"
+
+	if _, err := gameService.CreateNewGame(0); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	get := func(token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/api/admin/words/hello/games", nil)
+		if token != "" {
+			request.Header.Set("X-Admin-Token", token)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if recorder := get(""); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without admin token, got %d", recorder.Code)
+	}
+
+	recorder := get("secret")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with admin token, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		Games []WordGameOutcome `json:"games"`
+		Count int               `json:"count"`
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.Count != 1 || len(body.Games) != 1 || body.Games[0].Outcome != OutcomeInProgress {
+		t.Errorf("Expected the in-progress HELLO game, got %+v", body)
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	setupTestServer(t, FeatureDaily)
+	handler := requireAdmin(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Disabled without a configured token
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/api/admin/answers/schedule", nil))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without admin token configured, got %d", recorder.Code)
+	}
+
+	config.Server.AdminToken = "secret"
+
+	request := httptest.NewRequest(http.MethodPost, "/api/admin/answers/schedule", nil)
+	request.Header.Set("X-Admin-Token", "wrong")
+	recorder = httptest.NewRecorder()
+	handler(recorder, request)
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 with wrong token, got %d", recorder.Code)
+	}
+
+	request.Header.Set("X-Admin-Token", "secret")
+	recorder = httptest.NewRecorder()
+	handler(recorder, request)
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected request to pass with correct token, got %d", recorder.Code)
+	}
+}
+
+// blockingGameRepository holds each GetGame call until it is released, so
+// tests can keep guesses in flight
+type blockingGameRepository struct {
+	*MockGameRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingGameRepository) GetGame(id string) (*Game, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return b.MockGameRepository.GetGame(id)
+}
+
+func TestMakeGuessBackpressure(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Game.MaxConcurrentGuesses = 2
+
+	gameRepo := NewMockGameRepository()
+	blocking := &blockingGameRepository{
+		MockGameRepository: gameRepo,
+		started:            make(chan struct{}),
+		release:            make(chan struct{}),
+	}
+	gameService = NewGameServiceWithInterfaces(blocking, NewMockGuessRepository(), NewMockWordList(), &config.Game)
+
+	postGuess := func(gameID string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/games/"+gameID, strings.NewReader(`{"guess_word":"WORLD"}`))
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Fill every slot with a guess that is held inside the service
+	results := make(chan *httptest.ResponseRecorder, 2)
+	for i := 0; i < 2; i++ {
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		go func(id string) { results <- postGuess(id) }(game.ID)
+		<-blocking.started
+	}
+
+	// Beyond the limit the request is shed instead of queued
+	recorder := postGuess("any-game")
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 beyond the limit, got %d", recorder.Code)
+	}
+	if recorder.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header on shed request")
+	}
+
+	// Requests within the limit succeed once released, one at a time
+	for i := 0; i < 2; i++ {
+		blocking.release <- struct{}{}
+		if recorder := <-results; recorder.Code != http.StatusOK {
+			t.Errorf("Expected in-flight guess to succeed, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+
+	// Freed slots accept new guesses again
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	go func() { <-blocking.started; blocking.release <- struct{}{} }()
+	if recorder := postGuess(game.ID); recorder.Code != http.StatusOK {
+		t.Errorf("Expected guess to succeed after slots freed, got %d", recorder.Code)
+	}
+}
+
+func TestPublicGamesOmitTargetWord(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	for i := 0; i < 3; i++ {
+		if _, err := gameService.CreateNewGame(0); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/public", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response struct {
+		Games []map[string]interface{} `json:"games"`
+		Count int                      `json:"count"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Count != 3 || len(response.Games) != 3 {
+		t.Errorf("Expected 3 summaries, got %d", len(response.Games))
+	}
+	for _, game := range response.Games {
+		if _, ok := game["target_word"]; ok {
+			t.Errorf("Summary must not include target_word: %v", game)
+		}
+		if _, ok := game["id"]; !ok {
+			t.Errorf("Summary should include id: %v", game)
+		}
+	}
+
+	// The target words of in-progress games never appear anywhere in the body
+	for _, word := range NewMockWordList().words {
+		if strings.Contains(recorder.Body.String(), strings.ToUpper(word)) {
+			t.Errorf("Response leaks target word %s", word)
+		}
+	}
+}
+
+func TestRecentResultsOmitTargetWord(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	won, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"WORLD", "HELLO"} {
+		if _, err := gameService.MakeGuess(won.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+	// An in-progress game is not listed
+	if _, err := gameService.CreateNewGame(0); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/recent-results", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response struct {
+		Results []map[string]interface{} `json:"results"`
+		Count   int                      `json:"count"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Count != 1 || len(response.Results) != 1 {
+		t.Fatalf("Expected only the completed game, got %d results", len(response.Results))
+	}
+
+	result := response.Results[0]
+	if result["id"] != won.ID || result["is_won"] != true {
+		t.Errorf("Expected summary of the won game, got %v", result)
+	}
+	if share, _ := result["share"].(string); !strings.Contains(share, "⬛🟨⬛🟩⬛\n🟩🟩🟩🟩🟩") {
+		t.Errorf("Expected the share grid, got %q", share)
+	}
+	if _, ok := result["target_word"]; ok {
+		t.Errorf("Result must not include target_word: %v", result)
+	}
+	for _, word := range []string{"HELLO", "WORLD"} {
+		if strings.Contains(recorder.Body.String(), word) {
+			t.Errorf("Response leaks %s", word)
+		}
+	}
+}
+
+func TestGetGameInvalidOrder(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"?order=random", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid order, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"?order=desc", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200 for desc order, got %d", recorder.Code)
+	}
+}
+
+func TestMakeGuessOversizedInput(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	body := strings.NewReader(fmt.Sprintf(`{"guess_word": %q}`, strings.Repeat("A", 10000)))
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games/"+game.ID, body))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for oversized guess, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestMakeGuessSkipDictionary(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Server.AdminToken = "secret"
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	guess := func(query, word, token string) *httptest.ResponseRecorder {
+		body := strings.NewReader(fmt.Sprintf(`{"guess_word": %q}`, word))
+		request := httptest.NewRequest(http.MethodPost, "/api/games/"+game.ID+query, body)
+		if token != "" {
+			request.Header.Set("X-Admin-Token", token)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// The normal path stays strict
+	if recorder := guess("", "HXLQO", ""); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for out-of-dictionary guess, got %d", recorder.Code)
+	}
+	// The bypass is never available to normal clients
+	if recorder := guess("?skip_dictionary=true", "HXLQO", ""); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without admin token, got %d", recorder.Code)
+	}
+	if recorder := guess("?skip_dictionary=true", "HXLQO", "wrong"); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 with wrong admin token, got %d", recorder.Code)
+	}
+	// Still only letters of the right length
+	if recorder := guess("?skip_dictionary=true", "H3LL0", "secret"); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for non-letter guess, got %d", recorder.Code)
+	}
+
+	recorder := guess("?skip_dictionary=true", "HXLQO", "secret")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200 with admin bypass, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response GameResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Game.GuessCount != 1 || len(response.Guesses) != 1 || response.Guesses[0].GuessWord != "HXLQO" {
+		t.Fatalf("Expected the guess to be stored, got %+v", response)
+	}
+	if status := response.Guesses[0].Result[2].Status; status != "correct" {
+		t.Errorf("Expected L to be evaluated as correct, got %s", status)
+	}
+}
+
+func TestGetGameIncludes(t *testing.T) {
+	mux := setupTestServer(t, "")
+	guessRepo := NewMockGuessRepository()
+	gameRepo := &guessHistoryGameRepository{MockGameRepository: NewMockGameRepository(), guessRepo: guessRepo}
+	gameService = NewGameServiceWithInterfaces(gameRepo, guessRepo, NewMockWordList(), &config.Game)
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := gameService.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	getGame := func(query string) map[string]json.RawMessage {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+query, nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected 200 for %q, got %d: %s", query, recorder.Code, recorder.Body.String())
+		}
+		var response map[string]json.RawMessage
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	sections := []string{IncludeConstraints, IncludeCandidates, IncludeKeyboard}
+	for _, include := range append([]string{""}, sections...) {
+		response := getGame("?include=" + include)
+		if _, ok := response["game"]; !ok {
+			t.Errorf("include=%q: expected game in response", include)
+		}
+		for _, section := range sections {
+			if _, ok := response[section]; ok != (section == include) {
+				t.Errorf("include=%q: section %s present = %v", include, section, ok)
+			}
+		}
+	}
+
+	response := getGame("?include=constraints,candidates,keyboard")
+	var candidates CandidateList
+	if err := json.Unmarshal(response[IncludeCandidates], &candidates); err != nil {
+		t.Fatalf("Failed to decode candidates: %v", err)
+	}
+	if candidates.Count == 0 || !containsString(candidates.Words, "HELLO") || containsString(candidates.Words, "WORLD") {
+		t.Errorf("Expected HELLO but not WORLD among candidates, got %+v", candidates)
+	}
+	var keyboard KeyboardState
+	if err := json.Unmarshal(response[IncludeKeyboard], &keyboard); err != nil {
+		t.Fatalf("Failed to decode keyboard: %v", err)
+	}
+	if keyboard["L"] != "correct" || keyboard["W"] != "absent" {
+		t.Errorf("Unexpected keyboard: %v", keyboard)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"?include=constraints,solution", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown include, got %d", recorder.Code)
+	}
+}
+
+func TestCreateGameRecordsClientInfo(t *testing.T) {
+	mux := setupTestServer(t, "")
+	gameRepo := NewMockGameRepository()
+	gameService = NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), &config.Game)
+
+	createGame := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/games", nil)
+		request.RemoteAddr = "10.0.0.1:4000"
+		request.Header.Set("X-Forwarded-For", "198.51.100.1")
+		request.Header.Set("User-Agent", "test-agent/1.0")
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("Expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		return recorder
+	}
+
+	// Nothing is recorded unless enabled
+	createGame()
+	if len(gameRepo.clientInfo) != 0 {
+		t.Errorf("Expected no client info when disabled, got %v", gameRepo.clientInfo)
+	}
+
+	config.Server.RecordClientInfo = true
+	config.Server.TrustedProxies = "10.0.0.1"
+	recorder := createGame()
+
+	var response GameResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	info, ok := gameRepo.clientInfo[response.Game.ID]
+	if !ok {
+		t.Fatal("Expected client info to be recorded")
+	}
+	if info.IP != "198.51.100.1" || info.UserAgent != "test-agent/1.0" {
+		t.Errorf("Unexpected client info: %+v", info)
+	}
+
+	// Client info is never returned to the client
+	if strings.Contains(recorder.Body.String(), "198.51.100.1") || strings.Contains(recorder.Body.String(), "test-agent") {
+		t.Errorf("Response leaks client info: %s", recorder.Body.String())
+	}
+}
+
+func TestReconcileGameEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Server.AdminToken = "secret"
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Admin token is required
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/admin/games/"+game.ID+"/reconcile", nil))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without admin token, got %d", recorder.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/admin/games/"+game.ID+"/reconcile", nil)
+	request.Header.Set("X-Admin-Token", "secret")
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	request = httptest.NewRequest(http.MethodPost, "/api/admin/games/missing/reconcile", nil)
+	request.Header.Set("X-Admin-Token", "secret")
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for missing game, got %d", recorder.Code)
+	}
+}
+
+// unreachableGameRepository fails every call as if the database were down
+type unreachableGameRepository struct {
+	*MockGameRepository
+}
+
+var errDatabaseDown = fmt.Errorf("dial: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED})
+
+func (r *unreachableGameRepository) CreateGame(id, targetWord string, maxGuesses int) (*Game, error) {
+	return nil, errDatabaseDown
+}
+
+func (r *unreachableGameRepository) GetGame(gameID string) (*Game, error) {
+	return nil, errDatabaseDown
+}
+
+func (r *unreachableGameRepository) GetGameWithGuesses(gameID string) (*GameWithGuesses, error) {
+	return nil, errDatabaseDown
+}
+
+func (r *unreachableGameRepository) GetRecentGames(limit int) ([]Game, error) {
+	return nil, errDatabaseDown
+}
+
+func TestDatabaseUnavailableResponses(t *testing.T) {
+	mux := setupTestServer(t, "")
+	gameService = NewGameServiceWithInterfaces(&unreachableGameRepository{NewMockGameRepository()}, NewMockGuessRepository(), NewMockWordList(), &config.Game)
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/api/games", nil),
+		httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(`{"guess_word":"WORLD"}`)),
+		httptest.NewRequest(http.MethodPost, "/api/games/A", strings.NewReader(`{"guess_word":"WORLD"}`)),
+		httptest.NewRequest(http.MethodGet, "/api/games/A", nil),
+		httptest.NewRequest(http.MethodGet, "/api/games", nil),
+	}
+
+	for _, request := range requests {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+
+		name := request.Method + " " + request.URL.Path
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: expected 503, got %d", name, recorder.Code)
+		}
+		if recorder.Header().Get("Retry-After") == "" {
+			t.Errorf("%s: expected Retry-After header", name)
+		}
+
+		var response ErrorResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", name, err)
+		}
+		if response.ErrorCode != ErrorCodeDatabaseUnavailable {
+			t.Errorf("%s: expected error code %s, got %q", name, ErrorCodeDatabaseUnavailable, response.ErrorCode)
+		}
+		if strings.Contains(response.Error, "dial") || strings.Contains(response.Error, "refused") {
+			t.Errorf("%s: response leaks the wrapped error: %q", name, response.Error)
+		}
+	}
+}
+
+func TestEvaluateEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	evaluate := func(query string) (*httptest.ResponseRecorder, GuessResult) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/evaluate?"+query, nil))
+		var result GuessResult
+		if recorder.Code == http.StatusOK {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Failed to decode result: %v", err)
+			}
+		}
+		return recorder, result
+	}
+
+	statuses := func(result GuessResult) string {
+		var codes []string
+		for _, letter := range result {
+			codes = append(codes, letter.Status)
+		}
+		return strings.Join(codes, ",")
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"Matching lengths", "guess=crane&target=slate", "absent,absent,correct,absent,correct"},
+		{"Duplicate guess letter, single in target", "guess=speed&target=abide", "absent,absent,present,absent,present"},
+		{"Duplicate letters in both", "guess=hello&target=level", "absent,correct,present,present,absent"},
+		{"Exact match", "guess=HELLO&target=hello", "correct,correct,correct,correct,correct"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, result := evaluate(tt.query)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+			if got := statuses(result); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	for _, query := range []string{"guess=crane&target=slates", "guess=cr4ne&target=slate", "guess=crane", "guess=cra-e&target=slate"} {
+		if recorder, _ := evaluate(query); recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %q, got %d", query, recorder.Code)
+		}
+	}
+}
+
+func TestImportTargetWordsEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+	wordList := newImportTestWordList(t)
+	gameService = NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), wordList, &config.Game)
+	config.Server.AdminToken = "secret"
+
+	importWords := func(token, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/admin/target-words/import", strings.NewReader(body))
+		request.Header.Set("X-Admin-Token", token)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if recorder := importWords("wrong", "slate\n"); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without admin token, got %d", recorder.Code)
+	}
+
+	recorder := importWords("secret", "slate\nslate\ncrane\nbanana\n")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Added     int  `json:"added"`
+		Skipped   int  `json:"skipped"`
+		Persisted bool `json:"persisted"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Added != 1 || response.Skipped != 3 || response.Persisted {
+		t.Errorf("Unexpected import response: %+v", response)
+	}
+	if len(gameService.wordList.TargetWordsOfLength(5)) != 2 {
+		t.Error("Expected the imported word to be an eligible target")
+	}
+}
+
+
+func TestMakeGuessWrongLengthDetails(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	guess := func() map[string]interface{} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games/"+game.ID, strings.NewReader(`{"guess_word": "CAT"}`)))
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for too-short guess, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode error response: %v", err)
+		}
+		return response
+	}
+
+	// Details are off unless configured
+	if response := guess(); response["details"] != nil {
+		t.Errorf("Expected no details when disabled, got %v", response["details"])
+	}
+
+	config.Game.LengthErrorDetails = true
+	response := guess()
+	details, ok := response["details"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structured details, got %v", response)
+	}
+	if details["expected"] != 5.0 || details["got"] != 3.0 {
+		t.Errorf("Expected expected=5 got=3, got %v", details)
+	}
+	if response["error"] != "guess must be 5 letters long" {
+		t.Errorf("Expected the prose message to be kept, got %v", response["error"])
+	}
+}
+
+func TestInvalidGuessSuggestions(t *testing.T) {
+	mux := setupTestServer(t, "")
+	gameService.wordList.(*MockWordList).words = append(NewMockWordList().words, "CRAVE")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	guess := func(word string) map[string]interface{} {
+		recorder := httptest.NewRecorder()
+		body := strings.NewReader(fmt.Sprintf(`{"guess_word": %q}`, word))
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games/"+game.ID, body))
+		if recorder.Code != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for %s, got %d: %s", word, recorder.Code, recorder.Body.String())
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode error response: %v", err)
+		}
+		return response
+	}
+
+	// Suggestions are off unless configured
+	if response := guess("crabe"); response["details"] != nil {
+		t.Errorf("Expected no details when disabled, got %v", response["details"])
+	}
+
+	config.Game.SuggestWords = true
+	response := guess("crabe")
+	details, ok := response["details"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structured details, got %v", response)
+	}
+	suggestions, _ := details["suggestions"].([]interface{})
+	if len(suggestions) < 2 || suggestions[0] != "CRANE" || suggestions[1] != "CRAVE" {
+		t.Errorf("Expected CRANE and CRAVE first, got %v", details["suggestions"])
+	}
+	if response["error"] != "'CRABE' is not a valid word" {
+		t.Errorf("Expected the prose message to be kept, got %v", response["error"])
+	}
+
+	// Nothing in the dictionary is close to an utterly invalid guess
+	response = guess("zzzzz")
+	details, ok = response["details"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structured details, got %v", response)
+	}
+	if suggestions, ok := details["suggestions"].([]interface{}); !ok || len(suggestions) != 0 {
+		t.Errorf("Expected an empty suggestion list, got %v", details["suggestions"])
+	}
+}
+
+func TestWordNeighborsEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	// The mock dictionary has no neighbors for HELLO apart from itself
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/words/neighbors?word=hello", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Neighbors []string `json:"neighbors"`
+		Count     int      `json:"count"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Neighbors == nil || response.Count != 0 {
+		t.Errorf("Expected an empty neighbor list, got %+v", response)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/words/neighbors", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a word, got %d", recorder.Code)
+	}
+}
+
+func TestGamesListModeFilter(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	create := func(body string) Game {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("Expected 201 creating %s, got %d: %s", body, recorder.Code, recorder.Body.String())
+		}
+		var response GameResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response.Game
+	}
+
+	practice := create(`{}`)
+	challenge := create(`{"seed": 7}`)
+	if practice.Mode != GameModePractice || challenge.Mode != GameModeChallenge {
+		t.Fatalf("Expected practice and challenge modes, got %q and %q", practice.Mode, challenge.Mode)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games?mode=challenge", nil))
+	var response struct {
+		Games []Game `json:"games"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Games) != 1 || response.Games[0].ID != challenge.ID {
+		t.Errorf("Expected only the challenge game, got %+v", response.Games)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games?mode=ranked", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown mode, got %d", recorder.Code)
+	}
+}
+
+func TestGamesWonEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	for _, query := range []string{"", "?guesses=two", "?guesses=0"} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/won"+query, nil))
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", query, recorder.Code)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/won?guesses=2", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestPlayerNextWordEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/players/player-1/next-word", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if _, ok := response["seed"].(float64); !ok || len(response) != 1 {
+		t.Errorf("Expected only a numeric seed, got %v", response)
+	}
+}
+
+func TestCreateGameMaxHints(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Game.MaxHintsPerGame = 3
+
+	create := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		return recorder
+	}
+
+	for body, expected := range map[string]int{`{}`: 3, `{"max_hints": 1}`: 1} {
+		recorder := create(body)
+		if recorder.Code != http.StatusCreated {
+			t.Fatalf("%s: expected 201, got %d: %s", body, recorder.Code, recorder.Body.String())
+		}
+		var response GameResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.HintsRemaining == nil || *response.HintsRemaining != expected {
+			t.Errorf("%s: expected %d hints remaining, got %v", body, expected, response.HintsRemaining)
+		}
+	}
+
+	if recorder := create(`{"max_hints": -1}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a negative max_hints, got %d", recorder.Code)
+	}
+}
+
+func TestCreateCustomChallengeEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	create := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		return recorder
+	}
+
+	recorder := create(`{"word_length": 5, "dictionary": "default", "target_word": "crane"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response GameResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Game.TargetWord != "CRANE" || response.Game.Mode != GameModeChallenge {
+		t.Errorf("Expected a CRANE challenge game, got %+v", response.Game)
+	}
+
+	for _, body := range []string{
+		`{"word_length": 6, "target_word": "crane"}`,
+		`{"word_length": 5, "seed": 1, "target_word": "crane"}`,
+		`{"dictionary": "klingon", "seed": 1}`,
+		`{"word_length": 5, "target_word": "crane", "guess_word": "hello"}`,
+		`{"word_length": 5, "tutorial": true}`,
+	} {
+		if recorder := create(body); recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", body, recorder.Code, recorder.Body.String())
+		}
+	}
+}
+
+func TestSolutionPathEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	get := func(token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"/solution-path", nil)
+		request.Header.Set("X-Admin-Token", token)
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// The path reveals the answer, so it needs the admin token
+	if recorder := get(""); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without admin token, got %d", recorder.Code)
+	}
+
+	config.Server.AdminToken = "secret"
+	recorder := get("secret")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var path SolutionPath
+	if err := json.Unmarshal(recorder.Body.Bytes(), &path); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !path.Solved || path.Steps[len(path.Steps)-1].Guess != game.TargetWord {
+		t.Errorf("Expected path to end at %s, got %+v", game.TargetWord, path)
+	}
+}
+
+func TestCreateGameAPIKeyQuota(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Server.RequireAPIKey = true
+	apiKeyRepo := NewMockAPIKeyRepository()
+	apiKeyRepo.quotas["tenant-key"] = 2
+	gameService.SetAPIKeyRepository(apiKeyRepo)
+
+	create := func(key string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/games", nil)
+		if key != "" {
+			request.Header.Set("X-API-Key", key)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if recorder := create(""); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without an API key, got %d", recorder.Code)
+	}
+	if recorder := create("unknown-key"); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unknown API key, got %d", recorder.Code)
+	}
+
+	for i := 0; i < 2; i++ {
+		if recorder := create("tenant-key"); recorder.Code != http.StatusCreated {
+			t.Fatalf("Expected game %d within quota to be created, got %d: %s", i+1, recorder.Code, recorder.Body.String())
+		}
+	}
+	if recorder := create("tenant-key"); recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 once the quota is used up, got %d", recorder.Code)
+	}
+
+	// Requests that create no game don't use up the quota
+	apiKeyRepo.quotas["tenant-key"] = 3
+	request := httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(`{"tutorial": true, "guess_word": "HELLO"}`))
+	request.Header.Set("X-API-Key", "tenant-key")
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an invalid request, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := create("tenant-key"); recorder.Code != http.StatusCreated {
+		t.Errorf("Expected the rejected request to be refunded, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := create("tenant-key"); recorder.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 once the raised quota is used up, got %d", recorder.Code)
+	}
+
+	// Keys are only checked when required
+	config.Server.RequireAPIKey = false
+	if recorder := create(""); recorder.Code != http.StatusCreated {
+		t.Errorf("Expected 201 when API keys are not required, got %d", recorder.Code)
+	}
+}
+
+func TestRecentStatsEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats/recent?group=mode", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown grouping, got %d", recorder.Code)
+	}
+
+	if _, err := gameService.CreateNewGame(0); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats/recent?group=outcome&limit=50", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response struct {
+		Total   int                       `json:"total"`
+		Buckets map[string]*OutcomeBucket `json:"buckets"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Total != 1 || response.Buckets[OutcomeInProgress].Count != 1 || response.Buckets[OutcomeWon].Count != 0 {
+		t.Errorf("Expected one in-progress game, got %+v", response)
+	}
+}
+
+func TestStatsSnapshotEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := gameService.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats/snapshot", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	for _, section := range []string{"generated_at", "totals", "win_rate", "guess_distribution", "average_guesses", "top_openers"} {
+		if _, ok := response[section]; !ok {
+			t.Errorf("Expected snapshot section %q in %s", section, recorder.Body.String())
+		}
+	}
+
+	var snapshot StatsSnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &snapshot); err != nil {
+		t.Fatalf("Failed to decode snapshot: %v", err)
+	}
+	if snapshot.Totals.Games != 1 || snapshot.Totals.InProgress != 1 {
+		t.Errorf("Expected one game in progress, got %+v", snapshot.Totals)
+	}
+	if len(snapshot.TopOpeners) != 1 || snapshot.TopOpeners[0] != (WordCount{"WORLD", 1}) {
+		t.Errorf("Expected WORLD as the only opener, got %v", snapshot.TopOpeners)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/stats/snapshot", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", recorder.Code)
+	}
+}
+
+func TestDistinctGuessesEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"WORLD", "CRANE"} {
+		if _, err := gameService.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess: %v", err)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats/distinct-guesses", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var distribution DistinctGuessDistribution
+	if err := json.Unmarshal(recorder.Body.Bytes(), &distribution); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if distribution.Games != 1 || distribution.Distribution[2] != 1 {
+		t.Errorf("Expected one game with 2 distinct guesses, got %+v", distribution)
+	}
+	if len(distribution.Distribution) != 6 {
+		t.Errorf("Expected a bucket per guess count up to 6, got %v", distribution.Distribution)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/stats/distinct-guesses", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", recorder.Code)
+	}
+}
+
+func TestAtRiskGamesEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"WORLD", "CRANE", "SLATE", "AUDIO", "QUICK"} {
+		if _, err := gameService.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess: %v", err)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats/at-risk", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if strings.Contains(recorder.Body.String(), "target_word") || strings.Contains(recorder.Body.String(), game.TargetWord) {
+		t.Errorf("Expected no target word in %s", recorder.Body.String())
+	}
+
+	var response struct {
+		Games     []AtRiskGame `json:"games"`
+		Count     int          `json:"count"`
+		Threshold int          `json:"threshold"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Threshold != 1 || response.Count != 1 || response.Games[0].ID != game.ID || response.Games[0].RemainingGuesses != 1 {
+		t.Errorf("Expected the game on its last guess, got %+v", response)
+	}
+
+	for _, query := range []string{"threshold=0", "threshold=many"} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/stats/at-risk?"+query, nil))
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, recorder.Code)
+		}
+	}
+}
+
+func TestCreateGameAuditActor(t *testing.T) {
+	mux := setupTestServer(t, "")
+	auditor := &MockAuditLogger{}
+	gameService.SetAuditLogger(auditor)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/games", nil)
+	request.Header.Set("X-API-Key", "tenant-key")
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if len(auditor.records) != 1 {
+		t.Fatalf("Expected one audit record, got %+v", auditor.records)
+	}
+	record := auditor.records[0]
+	if record.Action != AuditActionGameCreated || record.Actor.IP != "192.0.2.1" || record.Actor.APIKey != apiKeyFingerprint("tenant-key") {
+		t.Errorf("Expected a game creation by the request's client, got %+v", record)
+	}
+}
+
+func TestBoardEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := gameService.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"/board", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response struct {
+		Positions []BoardPosition `json:"positions"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// WORLD against HELLO: only the L at position 4 is confirmed
+	if len(response.Positions) != 5 || response.Positions[3].Correct != "L" || response.Positions[3].Unknown {
+		t.Fatalf("Expected L confirmed at position 4, got %+v", response.Positions)
+	}
+	if !response.Positions[1].Unknown || !containsString(response.Positions[1].RuledOut, "O") {
+		t.Errorf("Expected O ruled out at unknown position 2, got %+v", response.Positions[1])
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/missing/board", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing game, got %d", recorder.Code)
+	}
+}
+
+func TestCreateGameWithUsername(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Game.AutoCreatePlayers = true
+	gameRepo := NewMockGameRepository()
+	playerRepo := NewMockPlayerRepository()
+	gameService = NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), &config.Game)
+	gameService.SetPlayerRepository(playerRepo)
+
+	create := func(body string) (int, GameResponse) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		var response GameResponse
+		if recorder.Code == http.StatusCreated {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return recorder.Code, response
+	}
+
+	// The first game creates the player
+	code, first := create(`{"username": "alice"}`)
+	if code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", code)
+	}
+	if first.PlayerID == "" || !first.PlayerCreated {
+		t.Errorf("Expected a newly created player, got %q (created %v)", first.PlayerID, first.PlayerCreated)
+	}
+
+	// The second reuses it
+	code, second := create(`{"username": "alice"}`)
+	if code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d", code)
+	}
+	if second.PlayerID != first.PlayerID || second.PlayerCreated {
+		t.Errorf("Expected existing player %s, got %q (created %v)", first.PlayerID, second.PlayerID, second.PlayerCreated)
+	}
+	if playerRepo.created != 1 {
+		t.Errorf("Expected 1 player created, got %d", playerRepo.created)
+	}
+
+	// Both games belong to the player
+	for _, gameID := range []string{first.Game.ID, second.Game.ID} {
+		if gameRepo.playerGames[gameID] != first.PlayerID {
+			t.Errorf("Expected game %s to belong to %s, got %q", gameID, first.PlayerID, gameRepo.playerGames[gameID])
+		}
+	}
+
+	// Unknown usernames are rejected when players are not auto-created
+	config.Game.AutoCreatePlayers = false
+	if code, _ := create(`{"username": "bob"}`); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown player, got %d", code)
+	}
+}
+
+func TestCreateGameWithUsernameRollsBack(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Game.AutoCreatePlayers = true
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	playerRepo := NewMockPlayerRepository()
+	auditor := &MockAuditLogger{}
+	gameService = NewGameServiceWithInterfaces(gameRepo, guessRepo, NewMockWordList(), &config.Game)
+	gameService.SetPlayerRepository(playerRepo)
+	gameService.SetTransactor(&MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo, playerRepo: playerRepo})
+	gameService.SetAuditLogger(auditor)
+
+	// Assigning the game to its new player fails after both were created
+	gameRepo.shouldFailAssign = true
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(`{"username": "carol", "max_hints": 2}`)))
+	if recorder.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected 500, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(playerRepo.players) != 0 || len(gameRepo.games) != 0 {
+		t.Errorf("Expected neither the player nor the game to be kept, got %d players and %d games", len(playerRepo.players), len(gameRepo.games))
+	}
+	if len(auditor.records) != 0 {
+		t.Errorf("Expected nothing audited for a rolled back creation, got %v", auditor.actions())
+	}
+
+	// Once assignment works, everything is created and audited together
+	gameRepo.shouldFailAssign = false
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(`{"username": "carol", "max_hints": 2}`)))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var response GameResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !response.PlayerCreated || gameRepo.playerGames[response.Game.ID] != response.PlayerID {
+		t.Errorf("Expected the game to belong to the new player, got %+v", response)
+	}
+	if stored := gameRepo.games[response.Game.ID]; stored == nil || stored.MaxHints == nil || *stored.MaxHints != 2 {
+		t.Errorf("Expected the hint limit to be stored with the game, got %+v", stored)
+	}
+	if len(auditor.records) != 3 {
+		t.Errorf("Expected creation, assignment and hint limit to be audited, got %v", auditor.actions())
+	}
+}
+
+func TestPlayerRankEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+	playerRepo := NewMockPlayerRepository()
+	for i, username := range []string{"ann", "bob", "cat"} {
+		playerRepo.players[username] = &Player{ID: fmt.Sprintf("p%d", i+1), Username: username, MaxStreak: (i + 1) * 2}
+	}
+	gameService.SetPlayerRepository(playerRepo)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	recorder := get("/api/players/p2/rank?by=max_streak")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var rank PlayerRank
+	if err := json.Unmarshal(recorder.Body.Bytes(), &rank); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if rank.Rank != 2 || rank.Players != 3 || rank.Percentile != 50 || rank.Value != 4 {
+		t.Errorf("Expected rank 2 of 3 at the 50th percentile, got %+v", rank)
+	}
+
+	if recorder := get("/api/players/p2/rank?by=username"); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown metric, got %d", recorder.Code)
+	}
+	if recorder := get("/api/players/p9/rank"); recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown player, got %d", recorder.Code)
+	}
+}
+
+func TestDailyEndpointDerivedWord(t *testing.T) {
+	mux := setupTestServer(t, FeatureDaily)
+	config.Game.DailyDerivedWords = true
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	gameService.SetClock(func() time.Time { return now })
+
+	get := func() GameResponse {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/daily", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var response GameResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	first := get()
+	if !strings.Contains(first.Message, "2024-03-01") {
+		t.Errorf("Expected today's puzzle for 2024-03-01, got %q", first.Message)
+	}
+	now = now.Add(15 * time.Hour)
+	if second := get(); second.Game.ID != first.Game.ID {
+		t.Errorf("Expected the same game later that day, got %s and %s", first.Game.ID, second.Game.ID)
+	}
+
+	now = now.Add(time.Hour)
+	if next := get(); next.Game.ID == first.Game.ID || !strings.Contains(next.Message, "2024-03-02") {
+		t.Errorf("Expected a new puzzle after midnight UTC, got %+v", next)
+	}
+}
+
+func TestDailyResumeEndpoint(t *testing.T) {
+	mux := setupTestServer(t, FeatureDaily)
+	config.Game.DailyResumeSecret = "resume-test-secret"
+	answerRepo := NewMockAnswerRepository()
+	answerRepo.answers["2024-03-01"] = "slate"
+	gameService.SetAnswerRepository(answerRepo)
+
+	get := func(path string) (int, GameResponse) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		var response GameResponse
+		if recorder.Code == http.StatusOK {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return recorder.Code, response
+	}
+
+	code, daily := get("/api/daily?date=2024-03-01")
+	if code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if daily.ResumeCode == "" {
+		t.Fatal("Expected a resume code")
+	}
+
+	// A valid code resumes the same game
+	code, resumed := get("/api/daily/resume?code=" + daily.ResumeCode)
+	if code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if resumed.Game.ID != daily.Game.ID {
+		t.Errorf("Expected game %s, got %s", daily.Game.ID, resumed.Game.ID)
+	}
+
+	// A tampered code is rejected
+	number, token, _ := strings.Cut(daily.ResumeCode, "-")
+	for _, tampered := range []string{"1-" + token, number + "-" + strings.Repeat("a", len(token)), "nonsense", ""} {
+		if code, _ := get("/api/daily/resume?code=" + tampered); code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %q, got %d", tampered, code)
+		}
+	}
+
+	// Without a secret there are no resume codes
+	config.Game.DailyResumeSecret = ""
+	if _, daily := get("/api/daily?date=2024-03-01"); daily.ResumeCode != "" {
+		t.Errorf("Expected no resume code without a secret, got %q", daily.ResumeCode)
+	}
+	if code, _ := get("/api/daily/resume?code=" + daily.ResumeCode); code != http.StatusNotFound {
+		t.Errorf("Expected 404 without a secret, got %d", code)
+	}
+}
+
+func TestRecentGamesLimit(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Game.MaxPageSize = 20
+	config.Game.DefaultPageSize = 10
+	for i := 0; i < 15; i++ {
+		if _, err := gameService.CreateNewGame(0); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	get := func(query string) (int, int) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games"+query, nil))
+		var response struct {
+			Count int `json:"count"`
+		}
+		if recorder.Code == http.StatusOK {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return recorder.Code, response.Count
+	}
+
+	if code, count := get("?limit=5"); code != http.StatusOK || count > 5 {
+		t.Errorf("Expected at most 5 games, got %d (status %d)", count, code)
+	}
+	if code, count := get(""); code != http.StatusOK || count != 10 {
+		t.Errorf("Expected the default 10 games, got %d (status %d)", count, code)
+	}
+	if code, count := get("?limit=20"); code != http.StatusOK || count != 15 {
+		t.Errorf("Expected all 15 games within the maximum, got %d (status %d)", count, code)
+	}
+
+	for _, limit := range []string{"0", "-1", "21", "ten"} {
+		if code, _ := get("?limit=" + limit); code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for limit %s, got %d", limit, code)
+		}
+	}
+}
+
+func TestRequireJSONContentType(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Server.RequireJSONContentType = true
+
+	post := func(path, contentType, body string) int {
+		request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	guess := `{"guess_word": "WORLD"}`
+
+	// JSON content types are accepted
+	if code := post("/api/games/"+game.ID, "application/json; charset=utf-8", guess); code != http.StatusOK {
+		t.Errorf("Expected a JSON guess to be accepted, got %d", code)
+	}
+	if code := post("/api/games", "application/json", `{}`); code != http.StatusCreated {
+		t.Errorf("Expected a JSON game creation to be accepted, got %d", code)
+	}
+	// A bodiless POST needs no content type
+	if code := post("/api/games", "", ""); code != http.StatusCreated {
+		t.Errorf("Expected a bodiless game creation to be accepted, got %d", code)
+	}
+
+	// Anything else is 415, even when the body looks like JSON
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded", "not a type"} {
+		if code := post("/api/games/"+game.ID, contentType, guess); code != http.StatusUnsupportedMediaType {
+			t.Errorf("Expected 415 for a %q guess, got %d", contentType, code)
+		}
+		if code := post("/api/games", contentType, `{}`); code != http.StatusUnsupportedMediaType {
+			t.Errorf("Expected 415 for a %q game creation, got %d", contentType, code)
+		}
+	}
+
+	// Disabled, bodies are decoded whatever their content type
+	config.Server.RequireJSONContentType = false
+	if code := post("/api/games/"+game.ID, "text/plain", `{"guess_word": "SLATE"}`); code != http.StatusOK {
+		t.Errorf("Expected a text/plain guess to be accepted when not enforced, got %d", code)
+	}
+}
+
+func TestRankGuessesEndpoint(t *testing.T) {
+	mux := setupTestServer(t, "")
+	game, err := gameService.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"/rank-guesses?limit=3", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var ranking GuessRanking
+	if err := json.Unmarshal(recorder.Body.Bytes(), &ranking); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(ranking.Guesses) != 3 || ranking.Candidates == 0 {
+		t.Errorf("Expected 3 ranked guesses against the candidates, got %+v", ranking)
+	}
+	for i := 1; i < len(ranking.Guesses); i++ {
+		if ranking.Guesses[i].ExpectedRemaining < ranking.Guesses[i-1].ExpectedRemaining {
+			t.Errorf("Expected guesses best first, got %+v", ranking.Guesses)
+		}
+	}
+
+	// An out-of-range limit is clamped rather than rejected
+	config.Game.MaxPageSize = 2
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/"+game.ID+"/rank-guesses?limit=1000", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &ranking); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(ranking.Guesses) != 2 {
+		t.Errorf("Expected the limit clamped to 2 guesses, got %d", len(ranking.Guesses))
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/games/missing/rank-guesses", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", recorder.Code)
+	}
+}
+
+func TestCreateMultiBoardGame(t *testing.T) {
+	mux := setupTestServer(t, "")
+	config.Game.MaxBoards = 4
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(`{"boards": 2}`)))
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var created GameResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(created.Game.Boards) != 2 {
+		t.Fatalf("Expected 2 boards, got %+v", created.Game.Boards)
+	}
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games/"+created.Game.ID, strings.NewReader(`{"guess_word": "`+created.Game.Boards[1].Target+`"}`)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var guessed GameResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &guessed); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(guessed.BoardResults) != 2 || !guessed.BoardResults[1].Solved || guessed.Game.IsCompleted {
+		t.Errorf("Expected the second of two boards solved, got %+v", guessed)
+	}
+
+	for _, body := range []string{`{"boards": 5}`, `{"boards": 2, "guess_word": "WORLD"}`, `{"boards": 2, "tutorial": true}`} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d: %s", body, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	// ?tutorial=true asks for a tutorial just as the body field does
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games?tutorial=true", strings.NewReader(`{"boards": 2}`)))
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "Multi-board") {
+		t.Errorf("Expected 400 for a multi-board tutorial, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestCreateGameMaxGuesses(t *testing.T) {
+	mux := setupTestServer(t, "")
+
+	create := func(body string) (int, GameResponse) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		var response GameResponse
+		if recorder.Code == http.StatusCreated {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return recorder.Code, response
+	}
+
+	if code, response := create(`{"max_guesses": 4}`); code != http.StatusCreated || response.Game.MaxGuesses != 4 {
+		t.Errorf("Expected a 4-guess game, got %d with %d guesses", code, response.Game.MaxGuesses)
+	}
+	if code, response := create(`{"max_guesses": 3, "guess_word": "WORLD"}`); code != http.StatusCreated || response.Game.MaxGuesses != 3 {
+		t.Errorf("Expected a 3-guess game with a first guess, got %d with %d guesses", code, response.Game.MaxGuesses)
+	}
+
+	// An empty body or no max_guesses uses the default
+	for _, body := range []string{"", `{}`} {
+		if code, response := create(body); code != http.StatusCreated || response.Game.MaxGuesses != config.Game.MaxGuesses {
+			t.Errorf("Expected the default %d guesses for %q, got %d with %d", config.Game.MaxGuesses, body, code, response.Game.MaxGuesses)
+		}
+	}
+
+	for _, body := range []string{`{"max_guesses": 13}`, `{"max_guesses": -2}`} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "max_guesses must be between 1 and 12") {
+			t.Errorf("Expected 400 with the allowed range for %s, got %d: %s", body, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	// Tutorial and challenge games don't silently drop max_guesses
+	for _, body := range []string{
+		`{"max_guesses": 4, "tutorial": true}`,
+		`{"max_guesses": 4, "seed": 7}`,
+		`{"max_guesses": 4, "target_word": "WORLD"}`,
+	} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/games", strings.NewReader(body)))
+		if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), "max_guesses only applies") {
+			t.Errorf("Expected 400 for %s, got %d: %s", body, recorder.Code, recorder.Body.String())
+		}
+	}
+}
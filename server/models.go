@@ -4,8 +4,15 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 )
 
 // Game represents a Wordle game session
@@ -16,8 +23,20 @@ type Game struct {
 	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
 	IsCompleted bool      `json:"is_completed" db:"is_completed"`
 	IsWon       bool      `json:"is_won" db:"is_won"`
+	IsLoss      bool      `json:"is_loss" db:"-"` // Derived: completed without being won
 	GuessCount  int       `json:"guess_count" db:"guess_count"`
 	MaxGuesses  int       `json:"max_guesses" db:"max_guesses"`
+	DailyDate   *time.Time `json:"daily_date,omitempty" db:"daily_date"`
+	PlayerID    *string   `json:"player_id,omitempty" db:"player_id"` // Set for a player's own daily game
+	IsTutorial  bool      `json:"is_tutorial,omitempty" db:"is_tutorial"`
+	Scramble    string    `json:"scramble,omitempty" db:"scramble"` // Set for anagram games: the target's letters shuffled
+	Mode        string    `json:"mode" db:"mode"`                   // GameModePractice, GameModeDaily or GameModeChallenge
+	HintsUsed   int       `json:"hints_used" db:"hints_used"`
+	MaxHints    *int      `json:"max_hints,omitempty" db:"max_hints"` // Per-game hint limit; nil uses MAX_HINTS_PER_GAME
+	// Best status per guessed letter, kept up to date by MakeGuess
+	KeyboardState KeyboardState `json:"keyboard_state,omitempty" db:"keyboard_state"`
+	// Set for multi-board games: every board must be solved to win
+	Boards Boards `json:"boards,omitempty" db:"boards"`
 }
 
 // Guess represents a single guess in a game
@@ -28,23 +47,166 @@ type Guess struct {
 	GuessNumber int         `json:"guess_number" db:"guess_number"`
 	Result      GuessResult `json:"result" db:"result"`
 	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+
+	// The guess evaluated against each board of a multi-board game, in
+	// responses only; Result is against the first board
+	BoardResults []BoardResult `json:"board_results,omitempty" db:"-"`
+}
+
+// MarshalJSON adds the guess's computed progress to its stored fields
+func (g Guess) MarshalJSON() ([]byte, error) {
+	type storedGuess Guess
+	return json.Marshal(struct {
+		storedGuess
+		Progress float64 `json:"progress"` // Percentage of letters in the correct position
+	}{storedGuess(g), g.Result.Progress()})
 }
 
 // LetterResult represents the result for a single letter in a guess
 type LetterResult struct {
 	Letter string `json:"letter"`
 	Status string `json:"status"` // "correct", "present", "absent"
+
+	// Accessibility annotations, only set on annotated responses
+	Code        string `json:"code,omitempty"`        // Single-letter status code: G, Y or B
+	Description string `json:"description,omitempty"` // Spelled-out status, e.g. "H is correct"
 }
 
 // GuessResult represents the result of a guess (array of letter results)
 type GuessResult []LetterResult
 
+// Result persistence formats for GuessResult.Value
+const (
+	// ResultFormatJSON stores the full letter/status array as JSON
+	ResultFormatJSON = "json"
+	// ResultFormatCompact stores a JSON string of letter+status-code pairs, e.g. "HGEYLB"
+	ResultFormatCompact = "compact"
+)
+
+// Compact status codes, one per tile following its letter
+var compactStatusCodes = map[string]rune{
+	"correct": 'G',
+	"present": 'Y',
+	"absent":  'B',
+}
+
+// guessResultFormat selects the encoding used by GuessResult.Value.
+// Scan detects the format of stored values, so both can be read at any time.
+var guessResultFormat = ResultFormatJSON
+
+// SetGuessResultFormat sets the encoding used when persisting guess results.
+// An empty format selects the default JSON encoding.
+func SetGuessResultFormat(format string) error {
+	if format == "" {
+		format = ResultFormatJSON
+	}
+	if err := validateResultFormat(format); err != nil {
+		return err
+	}
+	guessResultFormat = format
+	return nil
+}
+
+// validateResultFormat checks that format is a supported result persistence
+// format; empty means the default
+func validateResultFormat(format string) error {
+	if format != "" && format != ResultFormatJSON && format != ResultFormatCompact {
+		return fmt.Errorf("unknown result format %q (expected %q or %q)", format, ResultFormatJSON, ResultFormatCompact)
+	}
+	return nil
+}
+
 // Value implements the driver.Valuer interface for database storage
 func (gr GuessResult) Value() (driver.Value, error) {
+	if guessResultFormat == ResultFormatCompact {
+		compact, err := gr.compact()
+		if err != nil {
+			return nil, err
+		}
+		// Stored as a JSON string so it still fits the JSONB column
+		return json.Marshal(compact)
+	}
 	return json.Marshal(gr)
 }
 
-// Scan implements the sql.Scanner interface for database retrieval
+// compact encodes the result as letter+status-code pairs
+func (gr GuessResult) compact() (string, error) {
+	var sb strings.Builder
+	for _, letter := range gr {
+		code, ok := compactStatusCodes[letter.Status]
+		if !ok {
+			return "", fmt.Errorf("cannot encode unknown status %q", letter.Status)
+		}
+		sb.WriteString(letter.Letter)
+		sb.WriteRune(code)
+	}
+	return sb.String(), nil
+}
+
+// Progress returns the percentage of tiles in the correct position, rounded to
+// one decimal place, for showing how close a guess came
+func (gr GuessResult) Progress() float64 {
+	if len(gr) == 0 {
+		return 0
+	}
+	correct := 0
+	for _, letter := range gr {
+		if letter.Status == "correct" {
+			correct++
+		}
+	}
+	return math.Round(float64(correct)/float64(len(gr))*1000) / 10
+}
+
+// BestProgress returns the highest progress of any of the guesses
+func BestProgress(guesses []Guess) float64 {
+	best := 0.0
+	for _, guess := range guesses {
+		best = max(best, guess.Result.Progress())
+	}
+	return best
+}
+
+// Annotated returns a copy of the result with a status code and spelled-out
+// description on every tile, so clients need not hardcode status mappings
+func (gr GuessResult) Annotated() GuessResult {
+	annotated := make(GuessResult, len(gr))
+	for i, letter := range gr {
+		annotated[i] = LetterResult{Letter: letter.Letter, Status: letter.Status}
+		if code, ok := compactStatusCodes[letter.Status]; ok {
+			annotated[i].Code = string(code)
+		}
+		annotated[i].Description = fmt.Sprintf("%s is %s", letter.Letter, letter.Status)
+	}
+	return annotated
+}
+
+// parseCompactGuessResult decodes letter+status-code pairs into a GuessResult
+func parseCompactGuessResult(compact string) (GuessResult, error) {
+	runes := []rune(compact)
+	if len(runes)%2 != 0 {
+		return nil, fmt.Errorf("invalid compact guess result %q", compact)
+	}
+
+	result := make(GuessResult, 0, len(runes)/2)
+	for i := 0; i < len(runes); i += 2 {
+		status := ""
+		for name, code := range compactStatusCodes {
+			if code == runes[i+1] {
+				status = name
+				break
+			}
+		}
+		if status == "" {
+			return nil, fmt.Errorf("invalid status code %q in compact guess result", runes[i+1])
+		}
+		result = append(result, LetterResult{Letter: string(runes[i]), Status: status})
+	}
+	return result, nil
+}
+
+// Scan implements the sql.Scanner interface for database retrieval.
+// It accepts both the JSON array and the compact encoding.
 func (gr *GuessResult) Scan(value interface{}) error {
 	if value == nil {
 		*gr = nil
@@ -61,7 +223,24 @@ func (gr *GuessResult) Scan(value interface{}) error {
 		return errors.New("cannot scan GuessResult from non-string/[]byte")
 	}
 
-	return json.Unmarshal(bytes, gr)
+	trimmed := strings.TrimSpace(string(bytes))
+	if strings.HasPrefix(trimmed, "[") || trimmed == "null" {
+		return json.Unmarshal(bytes, gr)
+	}
+
+	compact := trimmed
+	if strings.HasPrefix(trimmed, `"`) {
+		if err := json.Unmarshal(bytes, &compact); err != nil {
+			return err
+		}
+	}
+
+	result, err := parseCompactGuessResult(compact)
+	if err != nil {
+		return err
+	}
+	*gr = result
+	return nil
 }
 
 // Player represents a player with statistics
@@ -76,6 +255,50 @@ type Player struct {
 	MaxStreak     int       `json:"max_streak" db:"max_streak"`
 }
 
+// Player statistics players can be ranked by. Each is also the name of its
+// players column.
+const (
+	PlayerMetricMaxStreak     = "max_streak"
+	PlayerMetricCurrentStreak = "current_streak"
+	PlayerMetricGamesWon      = "games_won"
+	PlayerMetricGamesPlayed   = "games_played"
+)
+
+// PlayerRankMetrics lists the metrics accepted by GET /api/players/{id}/rank
+var PlayerRankMetrics = []string{
+	PlayerMetricMaxStreak,
+	PlayerMetricCurrentStreak,
+	PlayerMetricGamesWon,
+	PlayerMetricGamesPlayed,
+}
+
+// PlayerRank places a player among all players by one metric
+type PlayerRank struct {
+	PlayerID   string  `json:"player_id"`
+	Metric     string  `json:"metric"`
+	Value      int     `json:"value"`
+	Rank       int     `json:"rank"`       // 1-based; tied players share the best rank
+	Players    int     `json:"players"`    // All players, including this one
+	Percentile float64 `json:"percentile"` // Share of players below, counting ties as half
+}
+
+// NewPlayerRank ranks a player given how many players have a higher and a
+// lower value than theirs. The rest, the player included, are tied with them.
+func NewPlayerRank(playerID, metric string, value, higher, lower, players int) *PlayerRank {
+	rank := &PlayerRank{
+		PlayerID: playerID,
+		Metric:   metric,
+		Value:    value,
+		Rank:     higher + 1,
+		Players:  players,
+	}
+	if players > 0 {
+		tied := players - higher - lower
+		rank.Percentile = (float64(lower) + float64(tied)/2) / float64(players) * 100
+	}
+	return rank
+}
+
 // GameStats represents statistics for a game
 type GameStats struct {
 	ID               string     `json:"id" db:"id"`
@@ -86,12 +309,323 @@ type GameStats struct {
 	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
 }
 
+// Difficulty tiers bucket the stored game_stats.word_difficulty score
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+
+	// Upper bounds (exclusive) of the easy and medium tiers
+	difficultyEasyMax   = 0.4
+	difficultyMediumMax = 0.7
+)
+
+// DifficultyTier returns the tier for a word difficulty score
+func DifficultyTier(difficulty float64) string {
+	switch {
+	case difficulty < difficultyEasyMax:
+		return DifficultyEasy
+	case difficulty < difficultyMediumMax:
+		return DifficultyMedium
+	default:
+		return DifficultyHard
+	}
+}
+
+// DifficultyTierStats aggregates completed games within one difficulty tier
+type DifficultyTierStats struct {
+	Tier        string  `json:"tier"`
+	GamesPlayed int     `json:"games_played"`
+	GamesWon    int     `json:"games_won"`
+	WinRate     float64 `json:"win_rate"`
+}
+
+// ScheduledAnswer maps a daily puzzle date to its target word
+type ScheduledAnswer struct {
+	PuzzleDate time.Time `json:"puzzle_date" db:"puzzle_date"`
+	TargetWord string    `json:"target_word" db:"target_word"`
+}
+
+// ScheduleAnswersRequest represents a request to regenerate the answer schedule
+type ScheduleAnswersRequest struct {
+	StartDate string `json:"start_date"` // YYYY-MM-DD
+	Days      int    `json:"days"`
+}
+
+// GameSummary is a public view of a game for activity feeds. It deliberately
+// has no target word or guesses so it cannot leak answers.
+type GameSummary struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	IsCompleted bool      `json:"is_completed"`
+	IsWon       bool      `json:"is_won"`
+	GuessCount  int       `json:"guess_count"`
+}
+
+// Summary returns the public summary of the game
+func (g *Game) Summary() GameSummary {
+	return GameSummary{
+		ID:          g.ID,
+		CreatedAt:   g.CreatedAt,
+		IsCompleted: g.IsCompleted,
+		IsWon:       g.IsWon,
+		GuessCount:  g.GuessCount,
+	}
+}
+
+// AtRiskGame is a public view of an in-progress game that is close to being
+// lost. Like GameSummary it has no target word or guesses.
+type AtRiskGame struct {
+	GameSummary
+	MaxGuesses       int `json:"max_guesses"`
+	RemainingGuesses int `json:"remaining_guesses"`
+}
+
+// AtRisk returns the at-risk view of the game
+func (g *Game) AtRisk() AtRiskGame {
+	return AtRiskGame{
+		GameSummary:      g.Summary(),
+		MaxGuesses:       g.MaxGuesses,
+		RemainingGuesses: g.MaxGuesses - g.GuessCount,
+	}
+}
+
+// Game modes, set when a game is created so listings and analytics can keep
+// them apart. Tutorial and anagram games are practice games.
+const (
+	GameModePractice  = "practice"
+	GameModeDaily     = "daily"
+	GameModeChallenge = "challenge" // Seeded: everyone with the same seed gets the same word
+)
+
+// ValidateGameMode checks that mode is one of the game modes
+func ValidateGameMode(mode string) error {
+	switch mode {
+	case GameModePractice, GameModeDaily, GameModeChallenge:
+		return nil
+	}
+	return fmt.Errorf("invalid mode %q (expected %q, %q or %q)", mode, GameModePractice, GameModeDaily, GameModeChallenge)
+}
+
+// Game outcomes reported by WordGameOutcome
+const (
+	OutcomeWon        = "won"
+	OutcomeLost       = "lost"
+	OutcomeInProgress = "in_progress"
+)
+
+// WordGameOutcome is how one game with a given target word went, for auditing
+// how a word performs. It is admin-only since the word is the answer.
+type WordGameOutcome struct {
+	ID          string     `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Outcome     string     `json:"outcome"`
+	GuessCount  int        `json:"guess_count"`
+	MaxGuesses  int        `json:"max_guesses"`
+}
+
+// maxOutcomeSampleIDs caps the game IDs listed per outcome bucket
+const maxOutcomeSampleIDs = 5
+
+// OutcomeBucket counts recent games with one outcome and samples their IDs
+type OutcomeBucket struct {
+	Count     int      `json:"count"`
+	SampleIDs []string `json:"sample_ids"` // Most recent first, at most maxOutcomeSampleIDs
+}
+
+// GroupByOutcome partitions games into won, lost and in-progress buckets. All
+// three buckets are present, even when empty.
+func GroupByOutcome(games []Game) map[string]*OutcomeBucket {
+	buckets := map[string]*OutcomeBucket{
+		OutcomeWon:        {SampleIDs: []string{}},
+		OutcomeLost:       {SampleIDs: []string{}},
+		OutcomeInProgress: {SampleIDs: []string{}},
+	}
+	for i := range games {
+		bucket := buckets[games[i].Outcome()]
+		bucket.Count++
+		if len(bucket.SampleIDs) < maxOutcomeSampleIDs {
+			bucket.SampleIDs = append(bucket.SampleIDs, games[i].ID)
+		}
+	}
+	return buckets
+}
+
+// SetOutcome sets the completion and outcome flags after the game's guesses
+// are counted: won, lost once the guesses have run out, or still in progress
+func (g *Game) SetOutcome(isWon bool) {
+	g.IsWon = isWon
+	g.IsCompleted = isWon || g.GuessCount >= g.MaxGuesses
+	g.IsLoss = g.IsCompleted && !g.IsWon
+}
+
+// Outcome returns the game's outcome: won, lost or in progress
+func (g *Game) Outcome() string {
+	switch {
+	case g.IsWon:
+		return OutcomeWon
+	case g.IsCompleted:
+		return OutcomeLost
+	default:
+		return OutcomeInProgress
+	}
+}
+
+// GameTally counts the games sharing a completion state, outcome and guess
+// count. The statistics snapshot is built from one grouped query of these.
+type GameTally struct {
+	IsCompleted bool
+	IsWon       bool
+	GuessCount  int
+	Games       int
+}
+
+// DistinctGuessTally counts the completed games in which the same number of
+// distinct words was guessed
+type DistinctGuessTally struct {
+	DistinctGuesses int
+	Games           int
+}
+
+// DistinctGuessDistribution is how many distinct words completed games tried
+type DistinctGuessDistribution struct {
+	Games        int         `json:"games"`        // Completed games with at least one guess
+	Distribution map[int]int `json:"distribution"` // Distinct words guessed -> completed games
+}
+
+// BuildDistinctGuessDistribution buckets tallies by distinct words guessed.
+// The distribution has an entry for every count from 1 to maxGuesses, plus
+// any larger count a game actually reached.
+func BuildDistinctGuessDistribution(tallies []DistinctGuessTally, maxGuesses int) *DistinctGuessDistribution {
+	distribution := &DistinctGuessDistribution{Distribution: make(map[int]int)}
+	for guesses := 1; guesses <= maxGuesses; guesses++ {
+		distribution.Distribution[guesses] = 0
+	}
+	for _, tally := range tallies {
+		distribution.Games += tally.Games
+		distribution.Distribution[tally.DistinctGuesses] += tally.Games
+	}
+	return distribution
+}
+
+// WordCount is how many times a word was played
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// SnapshotTotals counts all games by outcome
+type SnapshotTotals struct {
+	Games      int `json:"games"`
+	Completed  int `json:"completed"`
+	Won        int `json:"won"`
+	Lost       int `json:"lost"`
+	InProgress int `json:"in_progress"`
+}
+
+// StatsSnapshot holds every key aggregate in a single document, for archiving
+type StatsSnapshot struct {
+	GeneratedAt       time.Time      `json:"generated_at"`
+	Totals            SnapshotTotals `json:"totals"`
+	WinRate           float64        `json:"win_rate"`           // Percentage of completed games won
+	GuessDistribution map[int]int    `json:"guess_distribution"` // Guesses taken -> games won
+	AverageGuesses    float64        `json:"average_guesses"`    // Over won games
+	TopOpeners        []WordCount    `json:"top_openers"`
+}
+
+// BuildStatsSnapshot combines game tallies and top openers into a snapshot.
+// The distribution has an entry for every guess count from 1 to maxGuesses,
+// plus any larger count a won game actually took.
+func BuildStatsSnapshot(tallies []GameTally, openers []WordCount, maxGuesses int) *StatsSnapshot {
+	snapshot := &StatsSnapshot{
+		GeneratedAt:       time.Now(),
+		GuessDistribution: make(map[int]int),
+		TopOpeners:        openers,
+	}
+	if snapshot.TopOpeners == nil {
+		snapshot.TopOpeners = []WordCount{}
+	}
+	for guesses := 1; guesses <= maxGuesses; guesses++ {
+		snapshot.GuessDistribution[guesses] = 0
+	}
+
+	totalGuesses := 0
+	for _, tally := range tallies {
+		snapshot.Totals.Games += tally.Games
+		switch {
+		case tally.IsWon:
+			snapshot.Totals.Won += tally.Games
+			snapshot.GuessDistribution[tally.GuessCount] += tally.Games
+			totalGuesses += tally.GuessCount * tally.Games
+		case tally.IsCompleted:
+			snapshot.Totals.Lost += tally.Games
+		default:
+			snapshot.Totals.InProgress += tally.Games
+		}
+	}
+	snapshot.Totals.Completed = snapshot.Totals.Won + snapshot.Totals.Lost
+
+	if snapshot.Totals.Completed > 0 {
+		snapshot.WinRate = float64(snapshot.Totals.Won) / float64(snapshot.Totals.Completed) * 100
+	}
+	if snapshot.Totals.Won > 0 {
+		snapshot.AverageGuesses = float64(totalGuesses) / float64(snapshot.Totals.Won)
+	}
+	return snapshot
+}
+
+// RecentResult is a completed game's public summary with its share text, which
+// shows the emoji grid without any letters
+type RecentResult struct {
+	GameSummary
+	Share string `json:"share"`
+}
+
 // GameWithGuesses represents a game with all its guesses
 type GameWithGuesses struct {
 	Game    Game    `json:"game"`
 	Guesses []Guess `json:"guesses"`
 }
 
+// Score rates a finished game: a win scores one point per unused guess plus
+// one, so solving in the final guess scores 1. Losses and unfinished games score 0.
+func (g *Game) Score() int {
+	if !g.IsWon {
+		return 0
+	}
+	return g.MaxGuesses - g.GuessCount + 1
+}
+
+// PartialCredit returns the most correct-position letters achieved in any
+// single guess, which rewards a near miss in a game that was not solved
+func PartialCredit(guesses []Guess) int {
+	best := 0
+	for _, guess := range guesses {
+		correct := 0
+		for _, letter := range guess.Result {
+			if letter.Status == "correct" {
+				correct++
+			}
+		}
+		best = max(best, correct)
+	}
+	return best
+}
+
+// WordLength returns the number of letters in the game's target word, which
+// guesses must match regardless of the server's default word length
+func (g *Game) WordLength() int {
+	return utf8.RuneCountInString(g.TargetWord)
+}
+
+// IsReplayable reports whether a finished game may be reset and replayed.
+// Daily games are locked to their puzzle date, so only completed non-daily
+// games qualify.
+func (g *Game) IsReplayable() bool {
+	return g.IsCompleted && g.DailyDate == nil
+}
+
 // IsGameComplete checks if the game is complete based on guess count or win status
 func (g *Game) IsGameComplete() bool {
 	return g.IsWon || g.GuessCount >= g.MaxGuesses
@@ -105,34 +639,47 @@ func (p *Player) WinRate() float64 {
 	return float64(p.GamesWon) / float64(p.GamesPlayed) * 100
 }
 
+// CalculateWinRate fills in the tier's win rate as a percentage
+func (d *DifficultyTierStats) CalculateWinRate() {
+	if d.GamesPlayed == 0 {
+		d.WinRate = 0.0
+		return
+	}
+	d.WinRate = float64(d.GamesWon) / float64(d.GamesPlayed) * 100
+}
+
 // EvaluateGuess evaluates a guess against the target word and returns the result
 func EvaluateGuess(guess, target string) GuessResult {
-	if len(guess) != len(target) {
+	return EvaluateGuessWithCase(guess, target, strings.ToUpper)
+}
+
+// EvaluateGuessWithCase evaluates a guess against the target word, using upper
+// to normalise the case of both words before comparing them letter by letter
+func EvaluateGuessWithCase(guess, target string, upper func(string) string) GuessResult {
+	guessChars := []rune(upper(guess))
+	targetChars := []rune(upper(target))
+
+	if len(guessChars) != len(targetChars) {
 		return nil
 	}
 
-	guess = strings.ToUpper(guess)
-	target = strings.ToUpper(target)
-
-	result := make(GuessResult, len(guess))
-	targetChars := make([]rune, len(target))
-	copy(targetChars, []rune(target))
+	result := make(GuessResult, len(guessChars))
 
 	// First pass: mark correct letters
-	for i, char := range guess {
+	for i, char := range guessChars {
 		result[i] = LetterResult{
 			Letter: string(char),
 			Status: "absent",
 		}
 
-		if i < len(targetChars) && char == targetChars[i] {
+		if char == targetChars[i] {
 			result[i].Status = "correct"
 			targetChars[i] = 0 // Mark as used
 		}
 	}
 
 	// Second pass: mark present letters
-	for i, char := range guess {
+	for i, char := range guessChars {
 		if result[i].Status == "correct" {
 			continue
 		}
@@ -149,9 +696,176 @@ func EvaluateGuess(guess, target string) GuessResult {
 	return result
 }
 
+// NewUpperCaser returns a function that uppercases words using the case rules
+// of the given BCP 47 locale (e.g. "tr" maps i to İ). An empty locale keeps the
+// default Unicode mapping of strings.ToUpper.
+func NewUpperCaser(locale string) (func(string) string, error) {
+	if locale == "" {
+		return strings.ToUpper, nil
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locale %q: %w", locale, err)
+	}
+
+	// A cases.Caser is stateful, so build a fresh one per call to stay goroutine safe
+	return func(s string) string {
+		return cases.Upper(tag).String(s)
+	}, nil
+}
+
+// NewLowerCaser returns a function that lowercases words using the case rules
+// of the given BCP 47 locale (e.g. "tr" maps I to ı). An empty locale keeps the
+// default Unicode mapping of strings.ToLower.
+func NewLowerCaser(locale string) (func(string) string, error) {
+	if locale == "" {
+		return strings.ToLower, nil
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locale %q: %w", locale, err)
+	}
+
+	return func(s string) string {
+		return cases.Lower(tag).String(s)
+	}, nil
+}
+
+// PositionTally holds the letter status counts for a single board position
+type PositionTally struct {
+	Position int `json:"position"`
+	Correct  int `json:"correct"`
+	Present  int `json:"present"`
+	Absent   int `json:"absent"`
+}
+
+// BuildGuessHeatmap tallies correct/present/absent counts per position (1..length)
+// across the given guess results. Letters beyond length are ignored.
+func BuildGuessHeatmap(results []GuessResult, length int) []PositionTally {
+	heatmap := make([]PositionTally, length)
+	for i := range heatmap {
+		heatmap[i].Position = i + 1
+	}
+
+	for _, result := range results {
+		for i, letter := range result {
+			if i >= length {
+				break
+			}
+			switch letter.Status {
+			case "correct":
+				heatmap[i].Correct++
+			case "present":
+				heatmap[i].Present++
+			case "absent":
+				heatmap[i].Absent++
+			}
+		}
+	}
+
+	return heatmap
+}
+
+// Timeline event types
+const (
+	TimelineCreated   = "created"
+	TimelineGuess     = "guess"
+	TimelineCompleted = "completed"
+)
+
+// TimelineEvent is one entry in a game's audit timeline. Guess fields are only
+// set on guess events and Won only on the completion event.
+type TimelineEvent struct {
+	Type        string      `json:"type"`
+	At          time.Time   `json:"at"`
+	GuessNumber int         `json:"guess_number,omitempty"`
+	GuessWord   string      `json:"guess_word,omitempty"`
+	Result      GuessResult `json:"result,omitempty"`
+	Won         *bool       `json:"won,omitempty"`
+}
+
+// BuildTimeline merges the game's creation and completion with its guesses into
+// a single chronological list. Events with equal timestamps keep their logical
+// order: creation, guesses by number, then completion.
+func BuildTimeline(game *Game, guesses []Guess) []TimelineEvent {
+	ordered := make([]Guess, len(guesses))
+	copy(ordered, guesses)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].GuessNumber < ordered[j].GuessNumber
+	})
+
+	events := make([]TimelineEvent, 0, len(ordered)+2)
+	events = append(events, TimelineEvent{Type: TimelineCreated, At: game.CreatedAt})
+	for _, guess := range ordered {
+		events = append(events, TimelineEvent{
+			Type:        TimelineGuess,
+			At:          guess.CreatedAt,
+			GuessNumber: guess.GuessNumber,
+			GuessWord:   guess.GuessWord,
+			Result:      guess.Result,
+		})
+	}
+	if game.IsCompleted && game.CompletedAt != nil {
+		won := game.IsWon
+		events = append(events, TimelineEvent{Type: TimelineCompleted, At: *game.CompletedAt, Won: &won})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+	return events
+}
+
+// Nudge is a gentle hint naming a position where the latest guess's letter is wrong
+type Nudge struct {
+	Position int    `json:"position"` // 1-based board position
+	Letter   string `json:"letter"`   // The guessed letter that does not belong there
+	Message  string `json:"message"`
+
+	HintsRemaining *int `json:"hints_remaining,omitempty"` // Nil when hints are unlimited
+}
+
 // CreateGameRequest represents a request to create a new game
 type CreateGameRequest struct {
-	MaxGuesses int `json:"max_guesses,omitempty"`
+	MaxGuesses int    `json:"max_guesses,omitempty"` // Guesses a regular or multi-board game allows, 1 to 12; 0 uses MAX_GUESSES
+	GuessWord  string `json:"guess_word,omitempty"` // Optional first guess created atomically with the game
+	Tutorial   bool   `json:"tutorial,omitempty"`   // Create a scripted tutorial game
+	Seed       *int64 `json:"seed,omitempty"`       // Create a challenge game whose target is picked by the seed
+	MaxHints   *int   `json:"max_hints,omitempty"`  // Override MAX_HINTS_PER_GAME for this game; 0 is unlimited
+	Username   string `json:"username,omitempty"`   // Player to associate the game with, created if AUTO_CREATE_PLAYERS allows
+	Boards     int    `json:"boards,omitempty"`     // Targets to solve with the same guesses, 2 to MAX_BOARDS; 0 or 1 is a regular game
+
+	// Custom challenge options; any of them makes the game a custom challenge
+	WordLength *int   `json:"word_length,omitempty"` // Letters in the target word
+	Dictionary string `json:"dictionary,omitempty"`  // Word list the target comes from
+	TargetWord string `json:"target_word,omitempty"` // Explicit target instead of a seeded pick
+}
+
+// CustomChallenge returns the request's custom challenge spec, or nil when it
+// sets none of the custom challenge options
+func (r *CreateGameRequest) CustomChallenge() *CustomChallenge {
+	if r.WordLength == nil && r.Dictionary == "" && r.TargetWord == "" {
+		return nil
+	}
+	spec := &CustomChallenge{Dictionary: r.Dictionary, Seed: r.Seed, TargetWord: r.TargetWord}
+	if r.WordLength != nil {
+		spec.WordLength = *r.WordLength
+	}
+	return spec
+}
+
+// DictionaryDefault names the loaded word list, the only dictionary so far
+const DictionaryDefault = "default"
+
+// CustomChallenge describes a fully custom challenge game. Seed and TargetWord
+// are mutually exclusive; with neither, seed 0 picks the target.
+type CustomChallenge struct {
+	WordLength int    // 0 uses the target word's length, or the configured one
+	Dictionary string // Empty uses DictionaryDefault
+	Seed       *int64
+	TargetWord string
 }
 
 // MakeGuessRequest represents a request to make a guess
@@ -161,14 +875,93 @@ type MakeGuessRequest struct {
 
 // GameResponse represents a response containing game state
 type GameResponse struct {
-	Game    Game    `json:"game"`
-	Guesses []Guess `json:"guesses,omitempty"`
-	Message string  `json:"message,omitempty"`
+	Game       Game    `json:"game"`
+	Guesses    []Guess `json:"guesses,omitempty"`
+	Message    string  `json:"message,omitempty"`
+	Definition string  `json:"definition,omitempty"` // Target word definition, only once the game has ended
+	Anagrams   []string `json:"anagrams,omitempty"`  // Valid words using the target's letters, only once the game has ended
+	// The new guess evaluated against each board, in multi-board guess responses
+	BoardResults []BoardResult `json:"board_results,omitempty"`
+	// Each board's keyboard state, in multi-board game responses
+	BoardKeyboards []KeyboardState `json:"board_keyboards,omitempty"`
+	Guidance   string  `json:"guidance,omitempty"`   // Tutorial guidance for the next guess
+
+	// Player the game was created for, and whether creating it created them
+	PlayerID      string `json:"player_id,omitempty"`
+	PlayerCreated bool   `json:"player_created,omitempty"`
+
+	ResumeCode string `json:"resume_code,omitempty"` // Continues a daily game on another device
+
+	// Correct-position letters in the best guess of a lost game, only in partial credit mode
+	PartialCredit *int `json:"partial_credit,omitempty"`
+
+	// Nudges (hints) the game has left; omitted when hints are unlimited
+	HintsRemaining *int `json:"hints_remaining,omitempty"`
+	// Dictionary words still consistent with the board, only when requested with ?remaining=true
+	RemainingValidWords *int `json:"remaining_valid_words,omitempty"`
+	// Highest progress of any guess so far, when the response carries guesses
+	BestProgress *float64 `json:"best_progress,omitempty"`
+	// Letters known not to be in the word at all, in guess responses
+	AbsentLetters []string `json:"absent_letters,omitempty"`
+
+	// Computed analysis, present only when requested via ?include=
+	Constraints *BoardConstraints `json:"constraints,omitempty"`
+	Candidates  *CandidateList    `json:"candidates,omitempty"`
+	Keyboard    *KeyboardState    `json:"keyboard,omitempty"`
+}
+
+// SetBestProgress records the best progress across the game's full guess history
+func (r *GameResponse) SetBestProgress(history []Guess) {
+	best := BestProgress(history)
+	r.BestProgress = &best
+}
+
+// CandidateList holds the target words still consistent with a game's feedback
+type CandidateList struct {
+	Count int      `json:"count"` // Total number of remaining candidates
+	Words []string `json:"words"` // Candidates, capped at the maximum page size
 }
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    int    `json:"code,omitempty"`
-	Details string `json:"details,omitempty"`
+	Error     string      `json:"error"`
+	Code      int         `json:"code,omitempty"`
+	Details   interface{} `json:"details,omitempty"`    // Structured context for the error, e.g. *ErrWrongLength
+	ErrorCode string      `json:"error_code,omitempty"` // Machine-readable error, e.g. "database_unavailable"
+}
+
+// ErrWrongLength is returned when a guess doesn't have the game's word length.
+// It doubles as the error response details, so clients on servers with several
+// word lengths get the lengths without parsing the message.
+type ErrWrongLength struct {
+	Expected int `json:"expected"`
+	Got      int `json:"got"`
+}
+
+func (e *ErrWrongLength) Error() string {
+	return fmt.Sprintf("guess must be %d letters long", e.Expected)
+}
+
+// ErrInvalidWord is returned when a guess is not in the dictionary. With
+// suggestions enabled it doubles as the error response details, listing the
+// nearest valid words so clients can ask "did you mean ...?".
+type ErrInvalidWord struct {
+	Word        string   `json:"-"`
+	Suggestions []string `json:"suggestions"`
 }
+
+func (e *ErrInvalidWord) Error() string {
+	return fmt.Sprintf("'%s' is not a valid word", e.Word)
+}
+
+// ErrFeatureDisabled is returned by service paths whose feature flag is off
+type ErrFeatureDisabled struct {
+	Feature string
+}
+
+func (e *ErrFeatureDisabled) Error() string {
+	return fmt.Sprintf("feature '%s' is not enabled", e.Feature)
+}
+
+// ErrorCodeDatabaseUnavailable marks responses sent while the database can't be reached
+const ErrorCodeDatabaseUnavailable = "database_unavailable"
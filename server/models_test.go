@@ -3,6 +3,7 @@ package main
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 )
@@ -76,6 +77,33 @@ func TestGameIsGameComplete(t *testing.T) {
 	}
 }
 
+func TestGameSummary(t *testing.T) {
+	game := &Game{
+		ID:          "game-1",
+		TargetWord:  "HELLO",
+		CreatedAt:   time.Now(),
+		IsCompleted: true,
+		IsWon:       true,
+		GuessCount:  3,
+		MaxGuesses:  6,
+	}
+
+	summary := game.Summary()
+	if summary.ID != game.ID || !summary.CreatedAt.Equal(game.CreatedAt) ||
+		summary.IsCompleted != game.IsCompleted || summary.IsWon != game.IsWon ||
+		summary.GuessCount != game.GuessCount {
+		t.Errorf("Summary %+v does not match game %+v", summary, game)
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatalf("Failed to marshal summary: %v", err)
+	}
+	if strings.Contains(string(data), "HELLO") || strings.Contains(string(data), "target") {
+		t.Errorf("Summary JSON must not contain the target word: %s", data)
+	}
+}
+
 func TestPlayerWinRate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -136,6 +164,32 @@ func TestPlayerWinRate(t *testing.T) {
 	}
 }
 
+func TestDifficultyTier(t *testing.T) {
+	tests := []struct {
+		difficulty float64
+		expected   string
+	}{
+		{0.0, DifficultyEasy},
+		{0.39, DifficultyEasy},
+		{0.4, DifficultyMedium},
+		{0.69, DifficultyMedium},
+		{0.7, DifficultyHard},
+		{1.0, DifficultyHard},
+	}
+
+	for _, tt := range tests {
+		if got := DifficultyTier(tt.difficulty); got != tt.expected {
+			t.Errorf("DifficultyTier(%v) = %s, expected %s", tt.difficulty, got, tt.expected)
+		}
+	}
+
+	empty := DifficultyTierStats{Tier: DifficultyHard}
+	empty.CalculateWinRate()
+	if empty.WinRate != 0 {
+		t.Errorf("Expected 0 win rate with no games, got %v", empty.WinRate)
+	}
+}
+
 func TestEvaluateGuess(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -250,6 +304,108 @@ func TestEvaluateGuessInvalidLength(t *testing.T) {
 	}
 }
 
+func TestEvaluateGuessWithTurkishLocale(t *testing.T) {
+	turkish, err := NewUpperCaser("tr")
+	if err != nil {
+		t.Fatalf("Failed to create Turkish caser: %v", err)
+	}
+
+	// Dotted i uppercases to İ in Turkish, so it matches a dotted target
+	result := EvaluateGuessWithCase("insan", "İNSAN", turkish)
+	if len(result) != 5 {
+		t.Fatalf("Expected 5 letter results, got %d", len(result))
+	}
+	for i, letter := range result {
+		if letter.Status != "correct" {
+			t.Errorf("Position %d (%s): expected 'correct', got '%s'", i, letter.Letter, letter.Status)
+		}
+	}
+	if result[0].Letter != "İ" {
+		t.Errorf("Expected first letter 'İ', got '%s'", result[0].Letter)
+	}
+
+	// The default mapping turns i into I, which does not match İ
+	result = EvaluateGuess("insan", "İNSAN")
+	if result[0].Status != "absent" {
+		t.Errorf("Expected default mapping to miss dotted İ, got '%s'", result[0].Status)
+	}
+
+	// Dotless ı uppercases to I, and is distinct from dotted i in Turkish
+	result = EvaluateGuessWithCase("ılık", "ILIK", turkish)
+	for i, letter := range result {
+		if letter.Status != "correct" {
+			t.Errorf("Position %d (%s): expected 'correct', got '%s'", i, letter.Letter, letter.Status)
+		}
+	}
+
+	result = EvaluateGuessWithCase("ilik", "ILIK", turkish)
+	expected := []string{"absent", "correct", "absent", "correct"}
+	for i, status := range expected {
+		if result[i].Status != status {
+			t.Errorf("Position %d (%s): expected '%s', got '%s'", i, result[i].Letter, status, result[i].Status)
+		}
+	}
+}
+
+func TestNewUpperCaser(t *testing.T) {
+	upper, err := NewUpperCaser("")
+	if err != nil {
+		t.Fatalf("Default caser should not return error: %v", err)
+	}
+	if upper("hello") != "HELLO" {
+		t.Errorf("Expected 'HELLO', got '%s'", upper("hello"))
+	}
+	if upper("i") != "I" {
+		t.Errorf("Expected default mapping of 'i' to 'I', got '%s'", upper("i"))
+	}
+
+	turkish, err := NewUpperCaser("tr")
+	if err != nil {
+		t.Fatalf("Turkish caser should not return error: %v", err)
+	}
+	if turkish("i") != "İ" {
+		t.Errorf("Expected Turkish mapping of 'i' to 'İ', got '%s'", turkish("i"))
+	}
+
+	if _, err := NewUpperCaser("not a locale!"); err == nil {
+		t.Error("Expected error for invalid locale")
+	}
+}
+
+func TestBuildGuessHeatmap(t *testing.T) {
+	results := []GuessResult{
+		EvaluateGuess("WORLD", "HELLO"),
+		EvaluateGuess("LLAMA", "HELLO"),
+		EvaluateGuess("HELLO", "HELLO"),
+	}
+
+	heatmap := BuildGuessHeatmap(results, 5)
+	if len(heatmap) != 5 {
+		t.Fatalf("Expected 5 positions, got %d", len(heatmap))
+	}
+
+	expected := []PositionTally{
+		{Position: 1, Correct: 1, Present: 1, Absent: 1},
+		{Position: 2, Correct: 1, Present: 2},
+		{Position: 3, Correct: 1, Absent: 2},
+		{Position: 4, Correct: 2, Absent: 1},
+		{Position: 5, Correct: 1, Absent: 2},
+	}
+	for i, want := range expected {
+		if heatmap[i] != want {
+			t.Errorf("Position %d: expected %+v, got %+v", i+1, want, heatmap[i])
+		}
+	}
+
+	// No results should still produce zeroed positions
+	empty := BuildGuessHeatmap(nil, 5)
+	for i, tally := range empty {
+		if tally.Position != i+1 || tally.Correct+tally.Present+tally.Absent != 0 {
+			t.Errorf("Expected zeroed tally at position %d, got %+v", i+1, tally)
+		}
+	}
+}
+
 func TestGuessResultValue(t *testing.T) {
 	result := GuessResult{
 		{Letter: "H", Status: "correct"},
@@ -339,6 +495,123 @@ func TestGuessResultScan(t *testing.T) {
 	}
 }
 
+func TestGuessResultCompactFormat(t *testing.T) {
+	defer SetGuessResultFormat(ResultFormatJSON)
+
+	original := EvaluateGuess("WORLD", "HELLO")
+
+	if err := SetGuessResultFormat(ResultFormatCompact); err != nil {
+		t.Fatalf("Failed to set compact format: %v", err)
+	}
+
+	value, err := original.Value()
+	if err != nil {
+		t.Fatalf("Value() should not return error: %v", err)
+	}
+	if string(value.([]byte)) != `"WBOYRBLGDB"` {
+		t.Errorf("Expected compact encoding \"WBOYRBLGDB\", got %s", value)
+	}
+
+	var scanned GuessResult
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Scan() should not return error: %v", err)
+	}
+	if len(scanned) != len(original) {
+		t.Fatalf("Expected %d letters, got %d", len(original), len(scanned))
+	}
+	for i := range original {
+		if scanned[i] != original[i] {
+			t.Errorf("Position %d: expected %+v, got %+v", i, original[i], scanned[i])
+		}
+	}
+
+	// Unquoted compact values (e.g. from a text column) are also accepted
+	if err := scanned.Scan("HGIG"); err != nil {
+		t.Fatalf("Scan() of unquoted compact value should not return error: %v", err)
+	}
+	if len(scanned) != 2 || scanned[1].Letter != "I" || scanned[1].Status != "correct" {
+		t.Errorf("Unexpected result from unquoted compact value: %+v", scanned)
+	}
+
+	// Malformed compact values are rejected
+	for _, bad := range []string{`"HGE"`, `"HX"`} {
+		if err := scanned.Scan(bad); err == nil {
+			t.Errorf("Expected error scanning %s", bad)
+		}
+	}
+}
+
+func TestGuessResultScanBackwardCompatibility(t *testing.T) {
+	defer SetGuessResultFormat(ResultFormatJSON)
+
+	// Values written in JSON format must remain readable after switching to compact
+	original := EvaluateGuess("CRANE", "SLATE")
+	jsonValue, err := original.Value()
+	if err != nil {
+		t.Fatalf("Value() should not return error: %v", err)
+	}
+
+	if err := SetGuessResultFormat(ResultFormatCompact); err != nil {
+		t.Fatalf("Failed to set compact format: %v", err)
+	}
+
+	var scanned GuessResult
+	if err := scanned.Scan(jsonValue); err != nil {
+		t.Fatalf("Scan() of JSON value should not return error: %v", err)
+	}
+	for i := range original {
+		if scanned[i] != original[i] {
+			t.Errorf("Position %d: expected %+v, got %+v", i, original[i], scanned[i])
+		}
+	}
+}
+
+func TestGuessResultAnnotated(t *testing.T) {
+	result := EvaluateGuess("WORLD", "HELLO")
+	annotated := result.Annotated()
+
+	if len(annotated) != len(result) {
+		t.Fatalf("Expected %d annotated tiles, got %d", len(result), len(annotated))
+	}
+	expected := []struct{ code, description string }{
+		{"B", "W is absent"},
+		{"Y", "O is present"},
+		{"B", "R is absent"},
+		{"G", "L is correct"},
+		{"B", "D is absent"},
+	}
+	for i, want := range expected {
+		if annotated[i].Code != want.code || annotated[i].Description != want.description {
+			t.Errorf("Tile %d: expected %s %q, got %s %q", i, want.code, want.description, annotated[i].Code, annotated[i].Description)
+		}
+		if annotated[i].Status != result[i].Status {
+			t.Errorf("Tile %d: annotation changed status from %s to %s", i, result[i].Status, annotated[i].Status)
+		}
+	}
+
+	// The original is untouched, so the stored encoding is unchanged
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+	if strings.Contains(string(data), "code") || strings.Contains(string(data), "description") {
+		t.Errorf("Unannotated result should not include annotation fields: %s", data)
+	}
+}
+
+func TestSetGuessResultFormat(t *testing.T) {
+	defer SetGuessResultFormat(ResultFormatJSON)
+
+	for _, format := range []string{"", ResultFormatJSON, ResultFormatCompact} {
+		if err := SetGuessResultFormat(format); err != nil {
+			t.Errorf("Expected format %q to be accepted, got: %v", format, err)
+		}
+	}
+	if err := SetGuessResultFormat("xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
 func TestGuessResultDriverValuer(t *testing.T) {
 	result := GuessResult{
 		{Letter: "H", Status: "correct"},
@@ -363,6 +636,7 @@ func TestCreateGameRequest(t *testing.T) {
 	// Test that the struct can be created and marshaled
 	request := CreateGameRequest{
 		MaxGuesses: 8,
+		GuessWord:  "CRANE",
 	}
 
 	data, err := json.Marshal(request)
@@ -379,6 +653,9 @@ func TestCreateGameRequest(t *testing.T) {
 	if unmarshaled.MaxGuesses != 8 {
 		t.Errorf("Expected MaxGuesses 8, got %d", unmarshaled.MaxGuesses)
 	}
+	if unmarshaled.GuessWord != "CRANE" {
+		t.Errorf("Expected GuessWord 'CRANE', got '%s'", unmarshaled.GuessWord)
+	}
 }
 
 func TestMakeGuessRequest(t *testing.T) {
@@ -479,3 +756,113 @@ func TestErrorResponse(t *testing.T) {
 		t.Errorf("Expected details 'Test details', got '%s'", unmarshaled.Details)
 	}
 }
+
+func TestGameIsReplayable(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		game Game
+		want bool
+	}{
+		{"Completed practice game", Game{IsCompleted: true}, true},
+		{"In-progress practice game", Game{}, false},
+		{"Completed daily game", Game{IsCompleted: true, DailyDate: &date}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.game.IsReplayable(); got != tt.want {
+			t.Errorf("%s: IsReplayable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+
+func TestBuildTimeline(t *testing.T) {
+	created := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	completed := created.Add(3 * time.Minute)
+	game := &Game{CreatedAt: created, CompletedAt: &completed, IsCompleted: true, IsWon: true}
+
+	// Guesses arrive out of order; the second shares its timestamp with completion
+	guesses := []Guess{
+		{GuessNumber: 2, GuessWord: "HELLO", CreatedAt: completed, Result: EvaluateGuess("HELLO", "HELLO")},
+		{GuessNumber: 1, GuessWord: "WORLD", CreatedAt: created.Add(time.Minute), Result: EvaluateGuess("WORLD", "HELLO")},
+	}
+
+	events := BuildTimeline(game, guesses)
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d: %+v", len(events), events)
+	}
+
+	wantTypes := []string{TimelineCreated, TimelineGuess, TimelineGuess, TimelineCompleted}
+	for i, event := range events {
+		if event.Type != wantTypes[i] {
+			t.Errorf("Event %d: expected type %s, got %s", i, wantTypes[i], event.Type)
+		}
+	}
+	if events[1].GuessWord != "WORLD" || events[2].GuessWord != "HELLO" {
+		t.Errorf("Expected guesses in order, got %s then %s", events[1].GuessWord, events[2].GuessWord)
+	}
+	if len(events[1].Result) != 5 {
+		t.Errorf("Expected guess event to carry its result, got %v", events[1].Result)
+	}
+	if events[3].Won == nil || !*events[3].Won {
+		t.Errorf("Expected completion event to report a win, got %v", events[3].Won)
+	}
+
+	// An in-progress game has no completion event
+	inProgress := &Game{CreatedAt: created}
+	events = BuildTimeline(inProgress, guesses[1:])
+	if len(events) != 2 || events[len(events)-1].Type != TimelineGuess {
+		t.Errorf("Expected creation and one guess for an in-progress game, got %+v", events)
+	}
+}
+
+func TestPartialCredit(t *testing.T) {
+	guesses := []Guess{
+		{Result: EvaluateGuess("WORLD", "HELLO")},
+		{Result: EvaluateGuess("HELPS", "HELLO")},
+		{Result: EvaluateGuess("CELLO", "HELLO")},
+	}
+	if got := PartialCredit(guesses); got != 4 {
+		t.Errorf("Expected best row of 4 correct letters, got %d", got)
+	}
+	if got := PartialCredit(nil); got != 0 {
+		t.Errorf("Expected no credit without guesses, got %d", got)
+	}
+}
+
+func TestGuessProgress(t *testing.T) {
+	// TRACE vs CRATE: R, A and E in place, three of five positions
+	threeOfFive := EvaluateGuess("TRACE", "CRATE")
+	if got := threeOfFive.Progress(); got != 60 {
+		t.Errorf("Expected 3/5 correct positions to be 60%%, got %v", got)
+	}
+	if got := EvaluateGuess("CRATE", "CRATE").Progress(); got != 100 {
+		t.Errorf("Expected a solved row to be 100%%, got %v", got)
+	}
+	if got := (GuessResult{}).Progress(); got != 0 {
+		t.Errorf("Expected empty result to be 0%%, got %v", got)
+	}
+
+	guesses := []Guess{
+		{GuessNumber: 1, Result: EvaluateGuess("QUICK", "CRATE")},
+		{GuessNumber: 2, Result: threeOfFive},
+		{GuessNumber: 3, Result: EvaluateGuess("CRONY", "CRATE")},
+	}
+	if got := BestProgress(guesses); got != 60 {
+		t.Errorf("Expected best progress to track the max across guesses (60), got %v", got)
+	}
+
+	data, err := json.Marshal(guesses[1])
+	if err != nil {
+		t.Fatalf("Failed to marshal guess: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal guess: %v", err)
+	}
+	if decoded["progress"] != 60.0 || decoded["guess_number"] != 2.0 {
+		t.Errorf("Expected guess JSON to include stored fields and progress, got %s", data)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions controls how NormalizeWord folds a word
+type NormalizeOptions struct {
+	// CaseSensitive keeps the word's case; by default it is lowercased
+	CaseSensitive bool
+	// Lower lowercases the word, e.g. with a locale's case rules from
+	// NewLowerCaser; nil uses the default Unicode mapping
+	Lower func(string) string
+}
+
+// invisibleRunes are dropped from words: zero-width characters and the byte
+// order mark, which survive copy and paste and editors' file encodings but
+// make otherwise identical words differ
+var invisibleRunes = map[rune]bool{
+	'\u00AD': true, // Soft hyphen
+	'\u200B': true, // Zero width space
+	'\u200C': true, // Zero width non-joiner
+	'\u200D': true, // Zero width joiner
+	'\u2060': true, // Word joiner
+	'\uFEFF': true, // Byte order mark / zero width no-break space
+}
+
+// NormalizeWord returns the form in which the word list stores and matches
+// word. Invisible characters are removed, surrounding whitespace trimmed and
+// the letters composed (NFC), so "e" followed by a combining acute accent
+// matches a precomposed "é"; unless opts.CaseSensitive, the word is then
+// lowercased with opts.Lower. Word files and guesses both go through it, so they compare alike.
+func NormalizeWord(word string, opts NormalizeOptions) string {
+	word = strings.Map(func(r rune) rune {
+		if invisibleRunes[r] {
+			return -1
+		}
+		return r
+	}, word)
+	word = norm.NFC.String(strings.TrimSpace(word))
+	if !opts.CaseSensitive {
+		lower := opts.Lower
+		if lower == nil {
+			lower = strings.ToLower
+		}
+		word = lower(word)
+	}
+	return word
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// turkishLower folds case with Turkish rules, where I lowercases to dotless ı
+var turkishLower, _ = NewLowerCaser("tr")
+
+func TestNormalizeWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		opts     NormalizeOptions
+		expected string
+	}{
+		{"trims whitespace", "  crane\t\n", NormalizeOptions{}, "crane"},
+		{"lowercases", "CrAnE", NormalizeOptions{}, "crane"},
+		{"keeps case when case-sensitive", " CrAnE ", NormalizeOptions{CaseSensitive: true}, "CrAnE"},
+		{"strips zero width space", "cra\u200bne", NormalizeOptions{}, "crane"},
+		{"strips joiners and soft hyphens", "c\u200cr\u200da\u2060n\u00ade", NormalizeOptions{}, "crane"},
+		{"strips byte order mark", "\ufeffcrane", NormalizeOptions{}, "crane"},
+		{"trims whitespace behind invisible characters", "\u200b crane \u200b", NormalizeOptions{}, "crane"},
+		{"composes combining accents", "cafe\u0301", NormalizeOptions{}, "caf\u00e9"},
+		{"keeps precomposed letters", "CAF\u00c9", NormalizeOptions{}, "caf\u00e9"},
+		{"empty when only invisible", "\u200b\ufeff", NormalizeOptions{}, ""},
+		{"lowercases with the given mapping", "KIZIL", NormalizeOptions{Lower: turkishLower}, "k\u0131z\u0131l"},
+		{"ignores the mapping when case-sensitive", "KIZIL", NormalizeOptions{CaseSensitive: true, Lower: turkishLower}, "KIZIL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeWord(tt.word, tt.opts); got != tt.expected {
+				t.Errorf("NormalizeWord(%q) = %q, expected %q", tt.word, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordListNormalizesLikeGuesses(t *testing.T) {
+	// A BOM, a zero width space and a decomposed accent, as editors and
+	// copy and paste leave them
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "test-normalize.txt")
+	content := "\ufeffcrane\nsla\u200bte\nCAFE\u0301\n\u200b\n"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	wordList, err := NewWordList(testFile)
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+	if wordList.Size() != 3 {
+		t.Errorf("Expected 3 words, got %d: %v", wordList.Size(), wordList.ToSlice())
+	}
+
+	// Guesses are normalized the same way, whichever form they arrive in
+	for _, guess := range []string{"CRANE", "crane\u200b", "SLATE", "caf\u00e9", "CAFE\u0301"} {
+		if !wordList.Contains(guess) {
+			t.Errorf("Expected %q to match the word list", guess)
+		}
+	}
+	for _, word := range wordList.ToSlice() {
+		if word != NormalizeWord(word, NormalizeOptions{}) {
+			t.Errorf("Expected loaded word %q to be stored normalized", word)
+		}
+	}
+}
+
+func TestNormalizeWordEndpoint(t *testing.T) {
+	mux := setupTestServer(t, FeatureNormalize)
+
+	get := func(query string) (int, WordNormalization) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/words/normalize"+query, nil))
+		var response WordNormalization
+		if recorder.Code == http.StatusOK {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return recorder.Code, response
+	}
+
+	code, response := get("?word=" + url.QueryEscape(" \ufeffCra\u200bne "))
+	if code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if response.Normalized != "crane" || response.Guess != "CRANE" || response.Length != 5 || !response.Valid {
+		t.Errorf("Expected a valid normalized CRANE, got %+v", response)
+	}
+
+	if _, response := get("?word=cafe%CC%81"); response.Normalized != "caf\u00e9" || response.Length != 4 || response.Valid {
+		t.Errorf("Expected composed invalid cafe, got %+v", response)
+	}
+
+	for _, query := range []string{"", "?word=", "?word=%E2%80%8B"} {
+		if code, _ := get(query); code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %q, got %d", query, code)
+		}
+	}
+
+	// The endpoint is feature flagged
+	mux = setupTestServer(t, "")
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/words/normalize?word=crane", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 when disabled, got %d", recorder.Code)
+	}
+}
+
+func TestMakeGuessNormalizesLikeWordList(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), &GameConfig{MaxGuesses: 6, WordLength: 5})
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// A pasted guess with a zero width space and a BOM is the plain word
+	response, err := service.MakeGuess(game.ID, "\ufeff cra\u200bne ")
+	if err != nil {
+		t.Fatalf("Expected the guess to be accepted, got %v", err)
+	}
+	if len(response.Guesses) != 1 || response.Guesses[0].GuessWord != "CRANE" {
+		t.Errorf("Expected guess CRANE, got %+v", response.Guesses)
+	}
+}
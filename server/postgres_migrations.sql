@@ -0,0 +1,52 @@
+-- Upgrades a PostgreSQL database created from an earlier db/init/01-create-tables.sql
+-- to the current schema. db/init only runs against an empty volume, so the server
+-- applies this file on every startup; each statement must be safe to repeat.
+-- Add the upgrade for every column, table or index added to db/init here too.
+
+-- Anagram games may use lengths other than 5
+DO $$
+BEGIN
+    IF (SELECT character_maximum_length FROM information_schema.columns
+        WHERE table_schema = 'public' AND table_name = 'games' AND column_name = 'target_word') < 16 THEN
+        ALTER TABLE games ALTER COLUMN target_word TYPE VARCHAR(16);
+    END IF;
+    IF (SELECT character_maximum_length FROM information_schema.columns
+        WHERE table_schema = 'public' AND table_name = 'guesses' AND column_name = 'guess_word') < 16 THEN
+        ALTER TABLE guesses ALTER COLUMN guess_word TYPE VARCHAR(16);
+    END IF;
+END $$;
+
+ALTER TABLE games ADD COLUMN IF NOT EXISTS daily_date DATE;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS client_ip VARCHAR(45);
+ALTER TABLE games ADD COLUMN IF NOT EXISTS user_agent VARCHAR(512);
+ALTER TABLE games ADD COLUMN IF NOT EXISTS is_tutorial BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS scramble VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE games ADD COLUMN IF NOT EXISTS mode VARCHAR(16) NOT NULL DEFAULT 'practice' CHECK (mode IN ('practice', 'daily', 'challenge'));
+ALTER TABLE games ADD COLUMN IF NOT EXISTS player_id UUID REFERENCES players(id) ON DELETE CASCADE;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS hints_used INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS max_hints INTEGER;
+ALTER TABLE games ADD COLUMN IF NOT EXISTS keyboard_state JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE games ADD COLUMN IF NOT EXISTS boards JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE IF NOT EXISTS answers (
+    puzzle_date DATE PRIMARY KEY,
+    target_word VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    api_key VARCHAR(128) PRIMARY KEY,
+    daily_game_quota INTEGER NOT NULL CHECK (daily_game_quota >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key VARCHAR(128) NOT NULL REFERENCES api_keys(api_key) ON DELETE CASCADE,
+    usage_date DATE NOT NULL,
+    games_created INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key, usage_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_mode_created_at ON games(mode, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_games_daily_date ON games(daily_date) WHERE daily_date IS NOT NULL AND player_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_games_player_daily_date ON games(player_id, daily_date) WHERE mode = 'daily' AND player_id IS NOT NULL;
@@ -0,0 +1,65 @@
+package main
+
+import "sort"
+
+// maxLettersPerPosition caps how many of the likeliest letters are reported
+// for each position
+const maxLettersPerPosition = 5
+
+// LetterProbability is the share of candidates with a letter at one position
+type LetterProbability struct {
+	Letter      string  `json:"letter"`
+	Count       int     `json:"count"`
+	Probability float64 `json:"probability"`
+}
+
+// PositionProbabilities lists the likeliest letters at a 1-based position
+type PositionProbabilities struct {
+	Position int                 `json:"position"`
+	Letters  []LetterProbability `json:"letters"`
+}
+
+// LetterProbabilities tallies, for each of length positions, how often each
+// letter appears there among candidates, and returns the top letters per
+// position by count (ties alphabetical). With every letter kept the
+// probabilities at each position sum to 1.
+func LetterProbabilities(candidates []string, length, top int) []PositionProbabilities {
+	tallies := make([]map[string]int, length)
+	for i := range tallies {
+		tallies[i] = make(map[string]int)
+	}
+	total := 0
+	for _, word := range candidates {
+		letters := []rune(word)
+		if len(letters) != length {
+			continue
+		}
+		total++
+		for i, r := range letters {
+			tallies[i][string(r)]++
+		}
+	}
+
+	positions := make([]PositionProbabilities, length)
+	for i, tally := range tallies {
+		letters := make([]LetterProbability, 0, len(tally))
+		for letter, count := range tally {
+			letters = append(letters, LetterProbability{
+				Letter:      letter,
+				Count:       count,
+				Probability: float64(count) / float64(total),
+			})
+		}
+		sort.Slice(letters, func(a, b int) bool {
+			if letters[a].Count != letters[b].Count {
+				return letters[a].Count > letters[b].Count
+			}
+			return letters[a].Letter < letters[b].Letter
+		})
+		if len(letters) > top {
+			letters = letters[:top]
+		}
+		positions[i] = PositionProbabilities{Position: i + 1, Letters: letters}
+	}
+	return positions
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLetterProbabilities(t *testing.T) {
+	positions := LetterProbabilities(latchFamily, 5, 26)
+	if len(positions) != 5 {
+		t.Fatalf("Expected 5 positions, got %d", len(positions))
+	}
+
+	for _, position := range positions {
+		sum := 0.0
+		for _, letter := range position.Letters {
+			sum += letter.Probability
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("Position %d: expected probabilities to sum to 1, got %v", position.Position, sum)
+		}
+	}
+
+	// Seven of the eight words end in H; CRANE is the odd one out
+	last := positions[4].Letters
+	if last[0].Letter != "H" || last[0].Count != 7 || last[0].Probability != 0.875 {
+		t.Errorf("Expected H to lead position 5 at 0.875, got %+v", last[0])
+	}
+	if last[1].Letter != "E" || last[1].Count != 1 {
+		t.Errorf("Expected E second at position 5, got %+v", last[1])
+	}
+
+	// Truncated to the top letters, ties broken alphabetically
+	first := LetterProbabilities(latchFamily, 5, 3)[0].Letters
+	if len(first) != 3 || first[0].Letter != "C" || first[1].Letter != "B" || first[2].Letter != "H" {
+		t.Errorf("Expected C (2), then B and H (1), got %+v", first)
+	}
+
+	// Words of another length are ignored
+	positions = LetterProbabilities([]string{"ZEBRA", "CAT"}, 5, maxLettersPerPosition)
+	if positions[0].Letters[0].Probability != 1 {
+		t.Errorf("Expected only ZEBRA to count, got %+v", positions[0].Letters)
+	}
+}
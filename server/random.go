@@ -0,0 +1,60 @@
+package main
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// rng is the shared random source for target selection, scrambles and answer
+// schedules. It is seeded from crypto/rand at startup, so the sequence can't be
+// predicted from the start time, and is safe for concurrent use.
+var rng = newLockedRand(cryptoSeed())
+
+// SetRandomSeed reseeds the shared random source, making word selection
+// reproducible (RANDOM_SEED). Never set it where players could exploit it.
+func SetRandomSeed(seed int64) {
+	rng.Seed(seed)
+}
+
+// lockedSource serializes access to a rand.Source, which is not safe for
+// concurrent use on its own
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// newLockedRand returns a concurrency-safe *rand.Rand with the given seed
+func newLockedRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+// cryptoSeed returns a seed from the operating system's secure random source,
+// falling back to the clock only if that is unavailable
+func cryptoSeed() int64 {
+	var seed [8]byte
+	if _, err := cryptorand.Read(seed[:]); err != nil {
+		return time.Now().UnixNano()
+	}
+	return int64(binary.LittleEndian.Uint64(seed[:]))
+}
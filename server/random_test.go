@@ -0,0 +1,122 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// newRandomTestWordList returns a word list whose targets are exactly words
+func newRandomTestWordList(t *testing.T, words string) *WordList {
+	t.Helper()
+	targetFile := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(targetFile, []byte(words), 0644); err != nil {
+		t.Fatalf("Failed to create target file: %v", err)
+	}
+
+	wordList := newImportTestWordList(t)
+	wordList.targetFilePath = targetFile
+	if err := wordList.loadTargetWords(); err != nil {
+		t.Fatalf("Failed to load target words: %v", err)
+	}
+	return wordList
+}
+
+func TestSetRandomSeedReproducible(t *testing.T) {
+	t.Cleanup(func() { SetRandomSeed(cryptoSeed()) })
+	wordList := newRandomTestWordList(t, "crane\nslate\nfloat\nworld\n")
+
+	draw := func() []string {
+		words := make([]string, 20)
+		for i := range words {
+			words[i] = wordList.RandomWord()
+		}
+		return words
+	}
+
+	SetRandomSeed(42)
+	first := draw()
+	SetRandomSeed(42)
+	second := draw()
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same sequence for the same seed, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestWordListSetSeed(t *testing.T) {
+	wordList := newRandomTestWordList(t, "crane\nslate\nfloat\nworld\n")
+
+	draw := func() []string {
+		words := make([]string, 20)
+		for i := range words {
+			words[i] = wordList.RandomWord()
+		}
+		return words
+	}
+
+	// Draws from the shared source in between don't disturb the list's sequence
+	wordList.SetSeed(42)
+	first := draw()
+	wordList.SetSeed(42)
+	rng.Int63()
+	second := draw()
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same sequence for the same seed, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestRandomWordDistribution(t *testing.T) {
+	t.Cleanup(func() { SetRandomSeed(cryptoSeed()) })
+	SetRandomSeed(7)
+	wordList := newRandomTestWordList(t, "crane\nslate\nfloat\nworld\n")
+
+	const draws = 4000
+	counts := make(map[string]int)
+	for i := 0; i < draws; i++ {
+		counts[wordList.RandomWord()]++
+	}
+
+	if len(counts) != 4 {
+		t.Fatalf("Expected all 4 target words to be selected, got %v", counts)
+	}
+	for word, count := range counts {
+		// Expect about 1000 each
+		if count < 800 || count > 1200 {
+			t.Errorf("Word %s selected %d times out of %d, expected about %d", word, count, draws, draws/4)
+		}
+	}
+}
+
+func TestRandomWordConcurrent(t *testing.T) {
+	wordList := newRandomTestWordList(t, "crane\nslate\nfloat\nworld\n")
+	targets := wordList.TargetWordsToSet()
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if word := wordList.RandomWord(); !targets[word] {
+					errs <- word
+					return
+				}
+				scrambleWord("CRANE")
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for word := range errs {
+		t.Errorf("RandomWord returned a non-target word %q", word)
+	}
+}
@@ -3,20 +3,86 @@ package main
 import (
 	"database/sql"
 	"fmt"
-
-	"github.com/lib/pq"
+	"slices"
+	"strings"
+	"time"
 )
 
+// dbExecutor is the subset of database operations used by the repositories.
+// It is satisfied by both *DB and its transactions so repositories can run inside a transaction.
+type dbExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Dialect() Dialect
+}
+
 // GameRepository handles database operations for games
 type GameRepository struct {
-	db *DB
+	db dbExecutor
 }
 
 // GuessRepository handles database operations for guesses
 type GuessRepository struct {
+	db dbExecutor
+}
+
+// AnswerRepository handles database operations for the daily answer schedule
+type AnswerRepository struct {
+	db dbExecutor
+}
+
+// APIKeyRepository handles database operations for API keys and their usage
+type APIKeyRepository struct {
+	db dbExecutor
+}
+
+// PlayerRepository handles database operations for players
+type PlayerRepository struct {
+	db dbExecutor
+}
+
+// Transactor runs repository operations inside a single database transaction
+type Transactor struct {
 	db *DB
 }
 
+// gameColumns lists the games columns read by scanGame, in scan order
+const gameColumns = `id, target_word, created_at, completed_at, is_completed, is_won, guess_count, max_guesses, daily_date, is_tutorial, scramble, mode, player_id, hints_used, max_hints, keyboard_state, boards`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanGame scans a row selected with gameColumns into game and derives IsLoss
+func scanGame(row rowScanner, game *Game) error {
+	err := row.Scan(
+		&game.ID,
+		&game.TargetWord,
+		&game.CreatedAt,
+		&game.CompletedAt,
+		&game.IsCompleted,
+		&game.IsWon,
+		&game.GuessCount,
+		&game.MaxGuesses,
+		&game.DailyDate,
+		&game.IsTutorial,
+		&game.Scramble,
+		&game.Mode,
+		&game.PlayerID,
+		&game.HintsUsed,
+		&game.MaxHints,
+		&game.KeyboardState,
+		&game.Boards,
+	)
+	if err != nil {
+		return err
+	}
+	game.IsLoss = game.IsCompleted && !game.IsWon
+	return nil
+}
+
 // NewGameRepository creates a new game repository
 func NewGameRepository(db *DB) *GameRepository {
 	return &GameRepository{db: db}
@@ -27,26 +93,54 @@ func NewGuessRepository(db *DB) *GuessRepository {
 	return &GuessRepository{db: db}
 }
 
+// NewAnswerRepository creates a new answer repository
+func NewAnswerRepository(db *DB) *AnswerRepository {
+	return &AnswerRepository{db: db}
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// NewPlayerRepository creates a new player repository
+func NewPlayerRepository(db *DB) *PlayerRepository {
+	return &PlayerRepository{db: db}
+}
+
+// NewTransactor creates a new transactor
+func NewTransactor(db *DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction calls fn with repositories bound to a new transaction.
+// The transaction is committed if fn returns nil and rolled back otherwise.
+func (t *Transactor) WithinTransaction(fn func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error) error {
+	return t.WithinPlayerTransaction(func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, playerRepo PlayerRepositoryInterface) error {
+		return fn(gameRepo, guessRepo)
+	})
+}
+
+// WithinPlayerTransaction is WithinTransaction with a player repository bound
+// to the same transaction
+func (t *Transactor) WithinPlayerTransaction(fn func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, playerRepo PlayerRepositoryInterface) error) error {
+	return t.db.WithTransaction(func(tx *sql.Tx) error {
+		executor := t.db.executor(tx)
+		return fn(&GameRepository{db: executor}, &GuessRepository{db: executor}, &PlayerRepository{db: executor})
+	})
+}
+
 // Game Repository Methods
 
-// CreateGame creates a new game in the database
-func (r *GameRepository) CreateGame(targetWord string, maxGuesses int) (*Game, error) {
+// CreateGame creates a new game with the given ID in the database
+func (r *GameRepository) CreateGame(id, targetWord string, maxGuesses int) (*Game, error) {
 	query := `
-		INSERT INTO games (target_word, max_guesses, created_at)
-		VALUES ($1, $2, NOW())
-		RETURNING id, target_word, created_at, completed_at, is_completed, is_won, guess_count, max_guesses`
+		INSERT INTO games (id, target_word, max_guesses, created_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		RETURNING ` + gameColumns
 
 	game := &Game{}
-	err := r.db.QueryRow(query, targetWord, maxGuesses).Scan(
-		&game.ID,
-		&game.TargetWord,
-		&game.CreatedAt,
-		&game.CompletedAt,
-		&game.IsCompleted,
-		&game.IsWon,
-		&game.GuessCount,
-		&game.MaxGuesses,
-	)
+	err := scanGame(r.db.QueryRow(query, id, targetWord, maxGuesses), game)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create game: %w", err)
@@ -55,157 +149,825 @@ func (r *GameRepository) CreateGame(targetWord string, maxGuesses int) (*Game, e
 	return game, nil
 }
 
+// SetClientInfo records the client IP and user agent a game was created from
+func (r *GameRepository) SetClientInfo(gameID string, client ClientInfo) error {
+	query := `UPDATE games SET client_ip = $2, user_agent = $3 WHERE id = $1`
+
+	result, err := r.db.Exec(query, gameID, client.IP, client.UserAgent)
+	if err != nil {
+		return fmt.Errorf("failed to set client info: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("game not found: %s", gameID)
+	}
+
+	return nil
+}
+
+// SetWordDifficulty stores the target word's difficulty score for a game in
+// game_stats, where the difficulty tier statistics read it
+func (r *GameRepository) SetWordDifficulty(gameID string, difficulty float64) error {
+	query := `INSERT INTO game_stats (game_id, word_difficulty) VALUES ($1, $2)`
+
+	if _, err := r.db.Exec(query, gameID, difficulty); err != nil {
+		return fmt.Errorf("failed to set word difficulty: %w", err)
+	}
+
+	return nil
+}
+
+// CreateGames inserts one game per target word, with the ID at the same index,
+// using a single multi-row INSERT
+func (r *GameRepository) CreateGames(ids, targetWords []string, maxGuesses int) error {
+	if len(ids) != len(targetWords) {
+		return fmt.Errorf("got %d ids for %d games", len(ids), len(targetWords))
+	}
+	if len(targetWords) == 0 {
+		return nil
+	}
+
+	var values strings.Builder
+	args := make([]interface{}, 0, 2*len(targetWords)+1)
+	args = append(args, maxGuesses)
+	for i, word := range targetWords {
+		if i > 0 {
+			values.WriteString(", ")
+		}
+		fmt.Fprintf(&values, "($%d, $%d, $1, CURRENT_TIMESTAMP)", 2*i+2, 2*i+3)
+		args = append(args, ids[i], word)
+	}
+
+	query := `
+		INSERT INTO games (id, target_word, max_guesses, created_at)
+		VALUES ` + values.String()
+
+	if _, err := r.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to create games: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDailyGame creates the daily game for the given date
+func (r *GameRepository) CreateDailyGame(id, targetWord string, maxGuesses int, date time.Time) (*Game, error) {
+	query := `
+		INSERT INTO games (id, target_word, max_guesses, daily_date, mode, created_at)
+		VALUES ($1, $2, $3, $4, 'daily', CURRENT_TIMESTAMP)
+		RETURNING ` + gameColumns
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, id, targetWord, maxGuesses, dateKey(date)), game)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("daily game already exists: %s", dateKey(date))
+		}
+		return nil, fmt.Errorf("failed to create daily game: %w", err)
+	}
+
+	return game, nil
+}
+
+// CreatePlayerDailyGame creates the player's own daily game for the given
+// date. A player can only have one daily game per date.
+func (r *GameRepository) CreatePlayerDailyGame(id, playerID, targetWord string, maxGuesses int, date time.Time) (*Game, error) {
+	query := `
+		INSERT INTO games (id, target_word, max_guesses, daily_date, mode, player_id, created_at)
+		VALUES ($1, $2, $3, $4, 'daily', $5, CURRENT_TIMESTAMP)
+		RETURNING ` + gameColumns
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, id, targetWord, maxGuesses, dateKey(date), playerID), game)
+
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("daily game already exists for player %s: %s", playerID, dateKey(date))
+		}
+		return nil, fmt.Errorf("failed to create daily game: %w", err)
+	}
+
+	return game, nil
+}
+
+// CreateChallengeGame creates a new challenge game with the seed-picked target word
+func (r *GameRepository) CreateChallengeGame(id, targetWord string, maxGuesses int) (*Game, error) {
+	query := `
+		INSERT INTO games (id, target_word, max_guesses, mode, created_at)
+		VALUES ($1, $2, $3, 'challenge', CURRENT_TIMESTAMP)
+		RETURNING ` + gameColumns
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, id, targetWord, maxGuesses), game)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create challenge game: %w", err)
+	}
+
+	return game, nil
+}
+
+// CreateTutorialGame creates a new tutorial game with the scripted target word
+func (r *GameRepository) CreateTutorialGame(id, targetWord string, maxGuesses int) (*Game, error) {
+	query := `
+		INSERT INTO games (id, target_word, max_guesses, is_tutorial, created_at)
+		VALUES ($1, $2, $3, TRUE, CURRENT_TIMESTAMP)
+		RETURNING ` + gameColumns
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, id, targetWord, maxGuesses), game)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tutorial game: %w", err)
+	}
+
+	return game, nil
+}
+
+// CreateMultiBoardGame creates a new game with a board for each target,
+// solved by the same guesses. The first target is also the game's target word.
+func (r *GameRepository) CreateMultiBoardGame(id string, targets []string, maxGuesses int) (*Game, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("failed to create multi-board game: no targets")
+	}
+
+	query := `
+		INSERT INTO games (id, target_word, boards, max_guesses, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		RETURNING ` + gameColumns
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, id, targets[0], NewBoards(targets), maxGuesses), game)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multi-board game: %w", err)
+	}
+
+	return game, nil
+}
+
+// CreateAnagramGame creates a new anagram game whose target must be unscrambled
+func (r *GameRepository) CreateAnagramGame(id, targetWord, scramble string, maxGuesses int) (*Game, error) {
+	query := `
+		INSERT INTO games (id, target_word, scramble, max_guesses, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		RETURNING ` + gameColumns
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, id, targetWord, scramble, maxGuesses), game)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create anagram game: %w", err)
+	}
+
+	return game, nil
+}
+
+// GetDailyGame retrieves the shared daily game for the given date
+func (r *GameRepository) GetDailyGame(date time.Time) (*Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE daily_date = $1 AND player_id IS NULL`
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, dateKey(date)), game)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("daily game not found: %s", dateKey(date))
+		}
+		return nil, fmt.Errorf("failed to get daily game: %w", err)
+	}
+
+	return game, nil
+}
+
+// GetPlayerDailyGame retrieves the player's own daily game for the given date
+func (r *GameRepository) GetPlayerDailyGame(playerID string, date time.Time) (*Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE player_id = $1 AND daily_date = $2 AND mode = 'daily'`
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, playerID, dateKey(date)), game)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("daily game not found for player %s: %s", playerID, dateKey(date))
+		}
+		return nil, fmt.Errorf("failed to get daily game: %w", err)
+	}
+
+	return game, nil
+}
+
 // GetGame retrieves a game by ID
 func (r *GameRepository) GetGame(gameID string) (*Game, error) {
+	return r.getGame(gameID, "")
+}
+
+// GetGameForUpdate gets a game by ID and locks its row until the transaction
+// it runs in ends, so concurrent updates to the game are applied in turn
+func (r *GameRepository) GetGameForUpdate(gameID string) (*Game, error) {
+	return r.getGame(gameID, r.db.Dialect().ForUpdate())
+}
+
+// getGame gets a game by ID, appending lock to the query
+func (r *GameRepository) getGame(gameID, lock string) (*Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE id = $1` + lock
+
+	game := &Game{}
+	err := scanGame(r.db.QueryRow(query, gameID), game)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("game not found: %s", gameID)
+		}
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	return game, nil
+}
+
+// UpdateGame updates a game in the database
+func (r *GameRepository) UpdateGame(game *Game) error {
+	query := `
+		UPDATE games 
+		SET completed_at = $2, is_completed = $3, is_won = $4, guess_count = $5, keyboard_state = $6, boards = $7
+		WHERE id = $1`
+
+	result, err := r.db.Exec(query,
+		game.ID,
+		game.CompletedAt,
+		game.IsCompleted,
+		game.IsWon,
+		game.GuessCount,
+		game.KeyboardState,
+		game.Boards,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update game: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("game not found: %s", game.ID)
+	}
+
+	return nil
+}
+
+// SetMaxHints overrides the hint limit for one game; 0 makes it unlimited
+func (r *GameRepository) SetMaxHints(gameID string, maxHints int) error {
+	query := `UPDATE games SET max_hints = $2 WHERE id = $1`
+
+	result, err := r.db.Exec(query, gameID, maxHints)
+	if err != nil {
+		return fmt.Errorf("failed to set max hints: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("game not found: %s", gameID)
+	}
+
+	return nil
+}
+
+// UseHint counts a hint against the game and returns the hints used so far.
+// The game's own max_hints, or defaultLimit when it has none, caps the count
+// (0 is unlimited); the check and increment are one statement so concurrent
+// requests can't overshoot the limit.
+func (r *GameRepository) UseHint(gameID string, defaultLimit int) (int, error) {
+	query := `
+		UPDATE games
+		SET hints_used = hints_used + 1
+		WHERE id = $1 AND (COALESCE(max_hints, $2) <= 0 OR hints_used < COALESCE(max_hints, $2))
+		RETURNING hints_used`
+
+	var used int
+	err := r.db.QueryRow(query, gameID, defaultLimit).Scan(&used)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("hint limit reached for game %s", gameID)
+		}
+		return 0, fmt.Errorf("failed to use hint: %w", err)
+	}
+
+	return used, nil
+}
+
+// DeleteGame deletes a game and all associated guesses
+func (r *GameRepository) DeleteGame(gameID string) error {
+	query := `DELETE FROM games WHERE id = $1`
+
+	result, err := r.db.Exec(query, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to delete game: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("game not found: %s", gameID)
+	}
+
+	return nil
+}
+
+// GetGameWithGuesses retrieves a game with all its guesses
+func (r *GameRepository) GetGameWithGuesses(gameID string) (*GameWithGuesses, error) {
+	game, err := r.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	guessRepo := &GuessRepository{db: r.db}
+	guesses, err := guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
+	}
+
+	return &GameWithGuesses{
+		Game:    *game,
+		Guesses: guesses,
+	}, nil
+}
+
+// GetRecentGames gets the most recent games
+func (r *GameRepository) GetRecentGames(limit int) ([]Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		ORDER BY created_at DESC
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []Game
+	for rows.Next() {
+		var game Game
+		err := scanGame(rows, &game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetRecentGamesByMode gets the most recent games started in the given mode
+func (r *GameRepository) GetRecentGamesByMode(mode string, limit int) ([]Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE mode = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, mode, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []Game
+	for rows.Next() {
+		var game Game
+		err := scanGame(rows, &game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetRecentCompletedGames gets the most recently completed games
+func (r *GameRepository) GetRecentCompletedGames(limit int) ([]Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE is_completed = TRUE
+		ORDER BY completed_at DESC
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent completed games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []Game
+	for rows.Next() {
+		var game Game
+		err := scanGame(rows, &game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetCompletedGamesByTargetWord gets the most recent completed games with the given target word
+func (r *GameRepository) GetCompletedGamesByTargetWord(targetWord string, limit int) ([]Game, error) {
+	return r.getGamesByTargetWord(targetWord, true, limit)
+}
+
+// GetGamesByTargetWord gets the most recent games with the given target word,
+// including games still in progress
+func (r *GameRepository) GetGamesByTargetWord(targetWord string, limit int) ([]Game, error) {
+	return r.getGamesByTargetWord(targetWord, false, limit)
+}
+
+func (r *GameRepository) getGamesByTargetWord(targetWord string, completedOnly bool, limit int) ([]Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE target_word = $1 AND (is_completed = TRUE OR NOT $3)
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, targetWord, limit, completedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get games by target word: %w", err)
+	}
+	defer rows.Close()
+
+	var games []Game
+	for rows.Next() {
+		var game Game
+		err := scanGame(rows, &game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetGamesWonInGuesses gets the most recently completed games won in exactly
+// the given number of guesses
+func (r *GameRepository) GetGamesWonInGuesses(guesses int, limit int) ([]Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE is_won = TRUE AND guess_count = $1
+		ORDER BY completed_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, guesses, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get games won in %d guesses: %w", guesses, err)
+	}
+	defer rows.Close()
+
+	var games []Game
+	for rows.Next() {
+		var game Game
+		err := scanGame(rows, &game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetAtRiskGames gets in-progress games with at most maxRemaining guesses left,
+// fewest remaining first and then newest first
+func (r *GameRepository) GetAtRiskGames(maxRemaining int, limit int) ([]Game, error) {
+	query := `
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE NOT is_completed AND (max_guesses - guess_count) <= $1
+		ORDER BY (max_guesses - guess_count) ASC, created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, maxRemaining, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get at-risk games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []Game
+	for rows.Next() {
+		var game Game
+		err := scanGame(rows, &game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetBestWonGameForPlayer gets the player's won game with the fewest guesses,
+// preferring the earliest game on ties. Players are linked to games through game_stats.
+func (r *GameRepository) GetBestWonGameForPlayer(playerID string) (*Game, error) {
 	query := `
-		SELECT id, target_word, created_at, completed_at, is_completed, is_won, guess_count, max_guesses
+		SELECT ` + gameColumns + `
 		FROM games
-		WHERE id = $1`
+		WHERE id IN (SELECT game_id FROM game_stats WHERE player_id = $1) AND is_won = TRUE
+		ORDER BY guess_count ASC, created_at ASC
+		LIMIT 1`
 
 	game := &Game{}
-	err := r.db.QueryRow(query, gameID).Scan(
-		&game.ID,
-		&game.TargetWord,
-		&game.CreatedAt,
-		&game.CompletedAt,
-		&game.IsCompleted,
-		&game.IsWon,
-		&game.GuessCount,
-		&game.MaxGuesses,
-	)
+	err := scanGame(r.db.QueryRow(query, playerID), game)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("game not found: %s", gameID)
+			return nil, fmt.Errorf("no won games found for player: %s", playerID)
 		}
-		return nil, fmt.Errorf("failed to get game: %w", err)
+		return nil, fmt.Errorf("failed to get best game: %w", err)
 	}
 
 	return game, nil
 }
 
-// UpdateGame updates a game in the database
-func (r *GameRepository) UpdateGame(game *Game) error {
+// GetCompletedGamesForPlayer gets the player's completed games, most recently
+// completed first. Players are linked to games through game_stats.
+func (r *GameRepository) GetCompletedGamesForPlayer(playerID string, limit int) ([]Game, error) {
 	query := `
-		UPDATE games 
-		SET completed_at = $2, is_completed = $3, is_won = $4, guess_count = $5
-		WHERE id = $1`
-
-	result, err := r.db.Exec(query,
-		game.ID,
-		game.CompletedAt,
-		game.IsCompleted,
-		game.IsWon,
-		game.GuessCount,
-	)
+		SELECT ` + gameColumns + `
+		FROM games
+		WHERE id IN (SELECT game_id FROM game_stats WHERE player_id = $1) AND is_completed = TRUE
+		ORDER BY completed_at DESC
+		LIMIT $2`
 
+	rows, err := r.db.Query(query, playerID, limit)
 	if err != nil {
-		return fmt.Errorf("failed to update game: %w", err)
+		return nil, fmt.Errorf("failed to get player games: %w", err)
 	}
+	defer rows.Close()
 
-	rowsAffected, err := result.RowsAffected()
+	var games []Game
+	for rows.Next() {
+		var game Game
+		err := scanGame(rows, &game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game: %w", err)
+		}
+		games = append(games, game)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return games, nil
+}
+
+// GetPlayedTargetWords gets the distinct target words of every game the player
+// has played, whether linked through game_stats or as their own daily game
+func (r *GameRepository) GetPlayedTargetWords(playerID string) ([]string, error) {
+	query := `
+		SELECT DISTINCT target_word
+		FROM games
+		WHERE player_id = $1 OR id IN (SELECT game_id FROM game_stats WHERE player_id = $1)`
+
+	rows, err := r.db.Query(query, playerID)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to get played target words: %w", err)
 	}
+	defer rows.Close()
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("game not found: %s", game.ID)
+	var words []string
+	for rows.Next() {
+		var word string
+		if err := rows.Scan(&word); err != nil {
+			return nil, fmt.Errorf("failed to scan target word: %w", err)
+		}
+		words = append(words, word)
 	}
 
-	return nil
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating target words: %w", err)
+	}
+
+	return words, nil
 }
 
-// DeleteGame deletes a game and all associated guesses
-func (r *GameRepository) DeleteGame(gameID string) error {
-	query := `DELETE FROM games WHERE id = $1`
+// GetPlayerForGame gets the player associated with a game through game_stats
+func (r *GameRepository) GetPlayerForGame(gameID string) (*Player, error) {
+	query := `
+		SELECT p.id, COALESCE(p.username, ''), COALESCE(p.email, ''), p.created_at,
+			p.games_played, p.games_won, p.current_streak, p.max_streak
+		FROM players p
+		JOIN game_stats s ON s.player_id = p.id
+		WHERE s.game_id = $1
+		LIMIT 1`
+
+	player := &Player{}
+	err := r.db.QueryRow(query, gameID).Scan(
+		&player.ID,
+		&player.Username,
+		&player.Email,
+		&player.CreatedAt,
+		&player.GamesPlayed,
+		&player.GamesWon,
+		&player.CurrentStreak,
+		&player.MaxStreak,
+	)
 
-	result, err := r.db.Exec(query, gameID)
 	if err != nil {
-		return fmt.Errorf("failed to delete game: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no player found for game: %s", gameID)
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return player, nil
+}
+
+// SetGamePlayer associates a game with a player through game_stats, adding the
+// game's stats row if it has none yet
+func (r *GameRepository) SetGamePlayer(gameID, playerID string) error {
+	result, err := r.db.Exec(`UPDATE game_stats SET player_id = $2 WHERE game_id = $1`, gameID, playerID)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return fmt.Errorf("failed to set game player: %w", err)
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated > 0 {
+		return err
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("game not found: %s", gameID)
+	if _, err := r.db.Exec(`INSERT INTO game_stats (game_id, player_id) VALUES ($1, $2)`, gameID, playerID); err != nil {
+		return fmt.Errorf("failed to set game player: %w", err)
 	}
+	return nil
+}
 
+// RecordPlayerResult counts a finished game in its player's stats: games
+// played and won, and the current and longest winning streaks. The player is
+// the one linked through game_stats or, for a per-player daily game, its
+// owner; games without one change nothing.
+func (r *GameRepository) RecordPlayerResult(gameID string, won bool) error {
+	query := `
+		UPDATE players SET
+			games_played = games_played + 1,
+			games_won = games_won + CASE WHEN $2 THEN 1 ELSE 0 END,
+			current_streak = CASE WHEN $2 THEN current_streak + 1 ELSE 0 END,
+			max_streak = CASE WHEN $2 AND current_streak + 1 > max_streak THEN current_streak + 1 ELSE max_streak END
+		WHERE id IN (
+			SELECT player_id FROM game_stats WHERE game_id = $1 AND player_id IS NOT NULL
+			UNION
+			SELECT player_id FROM games WHERE id = $1 AND player_id IS NOT NULL
+		)`
+
+	if _, err := r.db.Exec(query, gameID, won); err != nil {
+		return fmt.Errorf("failed to record player result: %w", err)
+	}
 	return nil
 }
 
-// GetGameWithGuesses retrieves a game with all its guesses
-func (r *GameRepository) GetGameWithGuesses(gameID string) (*GameWithGuesses, error) {
-	game, err := r.GetGame(gameID)
+// GetStatsByDifficulty counts completed and won games per difficulty tier.
+// Games without a word_difficulty in game_stats are not included.
+func (r *GameRepository) GetStatsByDifficulty() ([]DifficultyTierStats, error) {
+	query := `
+		SELECT tier, COUNT(*), COUNT(*) FILTER (WHERE is_won)
+		FROM (
+			SELECT g.is_won,
+				CASE
+					WHEN MAX(s.word_difficulty) < $1 THEN '` + DifficultyEasy + `'
+					WHEN MAX(s.word_difficulty) < $2 THEN '` + DifficultyMedium + `'
+					ELSE '` + DifficultyHard + `'
+				END AS tier,
+				MAX(s.word_difficulty) AS difficulty
+			FROM games g
+			JOIN game_stats s ON s.game_id = g.id
+			WHERE g.is_completed = TRUE AND s.word_difficulty IS NOT NULL
+			GROUP BY g.id, g.is_won
+		) tiered
+		GROUP BY tier
+		ORDER BY MIN(difficulty)`
+
+	rows, err := r.db.Query(query, difficultyEasyMax, difficultyMediumMax)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get stats by difficulty: %w", err)
 	}
+	defer rows.Close()
 
-	guessRepo := NewGuessRepository(r.db)
-	guesses, err := guessRepo.GetGuessesByGameID(gameID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get guesses: %w", err)
+	var stats []DifficultyTierStats
+	for rows.Next() {
+		var tier DifficultyTierStats
+		if err := rows.Scan(&tier.Tier, &tier.GamesPlayed, &tier.GamesWon); err != nil {
+			return nil, fmt.Errorf("failed to scan difficulty stats: %w", err)
+		}
+		tier.CalculateWinRate()
+		stats = append(stats, tier)
 	}
 
-	return &GameWithGuesses{
-		Game:    *game,
-		Guesses: guesses,
-	}, nil
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating difficulty stats: %w", err)
+	}
+
+	return stats, nil
 }
 
-// GetRecentGames gets the most recent games
-func (r *GameRepository) GetRecentGames(limit int) ([]Game, error) {
+// GetGameTallies counts all games grouped by completion state, outcome and
+// guess count, in a single query
+func (r *GameRepository) GetGameTallies() ([]GameTally, error) {
 	query := `
-		SELECT id, target_word, created_at, completed_at, is_completed, is_won, guess_count, max_guesses
+		SELECT is_completed, is_won, guess_count, COUNT(*)
 		FROM games
-		ORDER BY created_at DESC
-		LIMIT $1`
+		GROUP BY is_completed, is_won, guess_count
+		ORDER BY is_completed, is_won, guess_count`
 
-	rows, err := r.db.Query(query, limit)
+	rows, err := r.db.Query(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get recent games: %w", err)
+		return nil, fmt.Errorf("failed to get game tallies: %w", err)
 	}
 	defer rows.Close()
 
-	var games []Game
+	var tallies []GameTally
 	for rows.Next() {
-		var game Game
-		err := rows.Scan(
-			&game.ID,
-			&game.TargetWord,
-			&game.CreatedAt,
-			&game.CompletedAt,
-			&game.IsCompleted,
-			&game.IsWon,
-			&game.GuessCount,
-			&game.MaxGuesses,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan game: %w", err)
+		var tally GameTally
+		if err := rows.Scan(&tally.IsCompleted, &tally.IsWon, &tally.GuessCount, &tally.Games); err != nil {
+			return nil, fmt.Errorf("failed to scan game tally: %w", err)
 		}
-		games = append(games, game)
+		tallies = append(tallies, tally)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating games: %w", err)
+		return nil, fmt.Errorf("error iterating game tallies: %w", err)
 	}
 
-	return games, nil
+	return tallies, nil
+}
+
+// dateKey formats a time as a DATE value (YYYY-MM-DD) in UTC
+func dateKey(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
 }
 
 // Guess Repository Methods
 
-// CreateGuess creates a new guess in the database
-func (r *GuessRepository) CreateGuess(gameID, guessWord string, guessNumber int, result GuessResult) (*Guess, error) {
+// CreateGuess creates a new guess with the given ID in the database
+func (r *GuessRepository) CreateGuess(id, gameID, guessWord string, guessNumber int, result GuessResult) (*Guess, error) {
 	query := `
-		INSERT INTO guesses (game_id, guess_word, guess_number, result, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+		INSERT INTO guesses (id, game_id, guess_word, guess_number, result, created_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
 		RETURNING id, game_id, guess_word, guess_number, result, created_at`
 
 	guess := &Guess{}
-	err := r.db.QueryRow(query, gameID, guessWord, guessNumber, result).Scan(
+	err := r.db.QueryRow(query, id, gameID, guessWord, guessNumber, result).Scan(
 		&guess.ID,
 		&guess.GameID,
 		&guess.GuessWord,
@@ -215,10 +977,8 @@ func (r *GuessRepository) CreateGuess(gameID, guessWord string, guessNumber int,
 	)
 
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			if pqErr.Code == "23505" { // unique_violation
-				return nil, fmt.Errorf("guess number %d already exists for game %s", guessNumber, gameID)
-			}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("guess number %d already exists for game %s", guessNumber, gameID)
 		}
 		return nil, fmt.Errorf("failed to create guess: %w", err)
 	}
@@ -340,3 +1100,316 @@ func (r *GuessRepository) GetLatestGuess(gameID string) (*Guess, error) {
 
 	return guess, nil
 }
+
+// GetPositionTallies counts correct, present and absent tiles per position
+// (1..length) across every stored guess. The counting is done in the database.
+func (r *GuessRepository) GetPositionTallies(length int) ([]PositionTally, error) {
+	rows, err := r.db.Query(r.db.Dialect().TileCountsQuery(), length)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position tallies: %w", err)
+	}
+	defer rows.Close()
+
+	heatmap := make([]PositionTally, length)
+	for i := range heatmap {
+		heatmap[i].Position = i + 1
+	}
+
+	for rows.Next() {
+		var position, count int
+		var status string
+		if err := rows.Scan(&position, &status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan position tally: %w", err)
+		}
+		if position < 1 || position > length {
+			continue
+		}
+		switch status {
+		case "correct":
+			heatmap[position-1].Correct = count
+		case "present":
+			heatmap[position-1].Present = count
+		case "absent":
+			heatmap[position-1].Absent = count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating position tallies: %w", err)
+	}
+
+	return heatmap, nil
+}
+
+// GetTopOpeners retrieves the most played first guesses, most played first
+// and ties alphabetical
+func (r *GuessRepository) GetTopOpeners(limit int) ([]WordCount, error) {
+	query := `
+		SELECT guess_word, COUNT(*) AS plays
+		FROM guesses
+		WHERE guess_number = 1
+		GROUP BY guess_word
+		ORDER BY plays DESC, guess_word ASC
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top openers: %w", err)
+	}
+	defer rows.Close()
+
+	var openers []WordCount
+	for rows.Next() {
+		var opener WordCount
+		if err := rows.Scan(&opener.Word, &opener.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan opener: %w", err)
+		}
+		openers = append(openers, opener)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating openers: %w", err)
+	}
+
+	return openers, nil
+}
+
+// GetDistinctGuessTallies counts completed games by how many distinct words
+// were guessed in them, fewest first. Games without guesses are left out.
+func (r *GuessRepository) GetDistinctGuessTallies() ([]DistinctGuessTally, error) {
+	query := `
+		SELECT distinct_guesses, COUNT(*)
+		FROM (
+			SELECT gu.game_id, COUNT(DISTINCT gu.guess_word) AS distinct_guesses
+			FROM guesses gu
+			JOIN games g ON g.id = gu.game_id
+			WHERE g.is_completed = TRUE
+			GROUP BY gu.game_id
+		) per_game
+		GROUP BY distinct_guesses
+		ORDER BY distinct_guesses`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get distinct guess tallies: %w", err)
+	}
+	defer rows.Close()
+
+	var tallies []DistinctGuessTally
+	for rows.Next() {
+		var tally DistinctGuessTally
+		if err := rows.Scan(&tally.DistinctGuesses, &tally.Games); err != nil {
+			return nil, fmt.Errorf("failed to scan distinct guess tally: %w", err)
+		}
+		tallies = append(tallies, tally)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating distinct guess tallies: %w", err)
+	}
+
+	return tallies, nil
+}
+
+// Answer Repository Methods
+
+// GetAnswer retrieves the scheduled target word for a date
+func (r *AnswerRepository) GetAnswer(date time.Time) (string, error) {
+	query := `SELECT target_word FROM answers WHERE puzzle_date = $1`
+
+	var targetWord string
+	err := r.db.QueryRow(query, dateKey(date)).Scan(&targetWord)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("no answer scheduled for %s", dateKey(date))
+		}
+		return "", fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	return targetWord, nil
+}
+
+// GetAnswers retrieves the scheduled answers between from and to (inclusive), ordered by date
+func (r *AnswerRepository) GetAnswers(from, to time.Time) ([]ScheduledAnswer, error) {
+	query := `
+		SELECT puzzle_date, target_word
+		FROM answers
+		WHERE puzzle_date BETWEEN $1 AND $2
+		ORDER BY puzzle_date ASC`
+
+	rows, err := r.db.Query(query, dateKey(from), dateKey(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []ScheduledAnswer
+	for rows.Next() {
+		var answer ScheduledAnswer
+		if err := rows.Scan(&answer.PuzzleDate, &answer.TargetWord); err != nil {
+			return nil, fmt.Errorf("failed to scan answer: %w", err)
+		}
+		answers = append(answers, answer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating answers: %w", err)
+	}
+
+	return answers, nil
+}
+
+// SaveAnswers inserts or replaces the scheduled answers for their dates
+func (r *AnswerRepository) SaveAnswers(answers []ScheduledAnswer) error {
+	query := `
+		INSERT INTO answers (puzzle_date, target_word, created_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (puzzle_date) DO UPDATE SET target_word = EXCLUDED.target_word`
+
+	for _, answer := range answers {
+		if _, err := r.db.Exec(query, dateKey(answer.PuzzleDate), answer.TargetWord); err != nil {
+			return fmt.Errorf("failed to save answer for %s: %w", dateKey(answer.PuzzleDate), err)
+		}
+	}
+
+	return nil
+}
+
+// API Key Repository Methods
+
+// UseGameQuota counts one game creation against the key's quota for date. The
+// check and increment are a single statement, so concurrent requests cannot
+// overshoot the quota; a refused creation is not counted.
+func (r *APIKeyRepository) UseGameQuota(key string, date time.Time) error {
+	var quota int
+	err := r.db.QueryRow(`SELECT daily_game_quota FROM api_keys WHERE api_key = $1`, key).Scan(&quota)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("unknown api key")
+		}
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	if quota <= 0 {
+		return fmt.Errorf("daily game quota of %d exceeded", quota)
+	}
+
+	query := `
+		INSERT INTO api_key_usage (api_key, usage_date, games_created)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (api_key, usage_date) DO UPDATE
+		SET games_created = api_key_usage.games_created + 1
+		WHERE api_key_usage.games_created < $3
+		RETURNING games_created`
+
+	var created int
+	err = r.db.QueryRow(query, key, dateKey(date), quota).Scan(&created)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("daily game quota of %d exceeded", quota)
+		}
+		return fmt.Errorf("failed to record api key usage: %w", err)
+	}
+
+	return nil
+}
+
+// RefundGameQuota gives back one game creation counted by UseGameQuota for
+// date, for a creation that failed after it was counted
+func (r *APIKeyRepository) RefundGameQuota(key string, date time.Time) error {
+	query := `
+		UPDATE api_key_usage
+		SET games_created = games_created - 1
+		WHERE api_key = $1 AND usage_date = $2 AND games_created > 0`
+
+	if _, err := r.db.Exec(query, key, dateKey(date)); err != nil {
+		return fmt.Errorf("failed to refund api key usage: %w", err)
+	}
+	return nil
+}
+
+// Player Repository Methods
+
+// playerColumns lists the players columns read by scanPlayer, in scan order
+const playerColumns = `id, COALESCE(username, ''), COALESCE(email, ''), created_at, games_played, games_won, current_streak, max_streak`
+
+// scanPlayer scans a row selected with playerColumns into player
+func scanPlayer(row rowScanner, player *Player) error {
+	return row.Scan(
+		&player.ID,
+		&player.Username,
+		&player.Email,
+		&player.CreatedAt,
+		&player.GamesPlayed,
+		&player.GamesWon,
+		&player.CurrentStreak,
+		&player.MaxStreak,
+	)
+}
+
+// GetPlayerByUsername retrieves the player with the given username
+func (r *PlayerRepository) GetPlayerByUsername(username string) (*Player, error) {
+	query := `SELECT ` + playerColumns + ` FROM players WHERE username = $1`
+
+	player := &Player{}
+	err := scanPlayer(r.db.QueryRow(query, username), player)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("player not found: %s", username)
+		}
+		return nil, fmt.Errorf("failed to get player: %w", err)
+	}
+
+	return player, nil
+}
+
+// CreatePlayer creates a player with the given ID and username. Usernames are
+// unique, so creating one that is taken fails with an "already exists" error.
+func (r *PlayerRepository) CreatePlayer(id, username string) (*Player, error) {
+	query := `
+		INSERT INTO players (id, username, created_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (username) DO NOTHING
+		RETURNING ` + playerColumns
+
+	// A taken username inserts nothing rather than failing, which would abort
+	// the transaction the player is being created in
+	player := &Player{}
+	err := scanPlayer(r.db.QueryRow(query, id, username), player)
+	if err != nil {
+		if err == sql.ErrNoRows || isUniqueViolation(err) {
+			return nil, fmt.Errorf("player already exists: %s", username)
+		}
+		return nil, fmt.Errorf("failed to create player: %w", err)
+	}
+
+	return player, nil
+}
+
+// GetPlayerRank ranks the player among all players by metric, which must be
+// one of PlayerRankMetrics
+func (r *PlayerRepository) GetPlayerRank(playerID, metric string) (*PlayerRank, error) {
+	if !slices.Contains(PlayerRankMetrics, metric) {
+		return nil, fmt.Errorf("invalid rank metric: %s", metric)
+	}
+
+	// metric is one of PlayerRankMetrics, each a players column, so it is safe
+	// to splice into the query
+	query := `
+		SELECT p.` + metric + `,
+			(SELECT COUNT(*) FROM players o WHERE o.` + metric + ` > p.` + metric + `),
+			(SELECT COUNT(*) FROM players o WHERE o.` + metric + ` < p.` + metric + `),
+			(SELECT COUNT(*) FROM players)
+		FROM players p
+		WHERE p.id = $1`
+
+	var value, higher, lower, players int
+	err := r.db.QueryRow(query, playerID).Scan(&value, &higher, &lower, &players)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("player not found: %s", playerID)
+		}
+		return nil, fmt.Errorf("failed to get player rank: %w", err)
+	}
+
+	return NewPlayerRank(playerID, metric, value, higher, lower, players), nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Daily resume codes let a player without an account continue a daily game
+// on another device. A code reads "<puzzle number>-<token>"; the token holds
+// the game's ID and an HMAC over the puzzle number and ID, so codes can't be
+// forged or pointed at another game without the secret.
+
+// resumeMACLength is how many bytes of the HMAC-SHA256 a resume code keeps
+const resumeMACLength = 8
+
+// resumeEncoding encodes resume code tokens; codes are written in lowercase
+// and accepted in either case
+var resumeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewResumeCode returns the resume code for the daily game gameID of puzzle
+// number puzzle, signed with secret. Game IDs must be UUIDs.
+func NewResumeCode(secret []byte, puzzle int, gameID string) (string, error) {
+	id, err := parseUUID(gameID)
+	if err != nil {
+		return "", err
+	}
+	token := append(id[:], resumeMAC(secret, puzzle, id)...)
+	return fmt.Sprintf("%d-%s", puzzle, strings.ToLower(resumeEncoding.EncodeToString(token))), nil
+}
+
+// ParseResumeCode checks code's signature against secret and returns the
+// puzzle number and game ID it encodes
+func ParseResumeCode(secret []byte, code string) (int, string, error) {
+	number, encoded, found := strings.Cut(strings.TrimSpace(code), "-")
+	if !found {
+		return 0, "", fmt.Errorf("invalid resume code")
+	}
+	puzzle, err := strconv.Atoi(number)
+	if err != nil || puzzle < 0 {
+		return 0, "", fmt.Errorf("invalid resume code")
+	}
+	token, err := resumeEncoding.DecodeString(strings.ToUpper(encoded))
+	if err != nil || len(token) != 16+resumeMACLength {
+		return 0, "", fmt.Errorf("invalid resume code")
+	}
+
+	var id [16]byte
+	copy(id[:], token[:16])
+	if !hmac.Equal(token[16:], resumeMAC(secret, puzzle, id)) {
+		return 0, "", fmt.Errorf("invalid resume code")
+	}
+	return puzzle, formatUUID(id), nil
+}
+
+// resumeMAC signs a puzzle number and game ID
+func resumeMAC(secret []byte, puzzle int, id [16]byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	var number [8]byte
+	binary.BigEndian.PutUint64(number[:], uint64(puzzle))
+	mac.Write(number[:])
+	mac.Write(id[:])
+	return mac.Sum(nil)[:resumeMACLength]
+}
+
+// parseUUID parses a UUID in the canonical 8-4-4-4-12 form
+func parseUUID(value string) ([16]byte, error) {
+	var id [16]byte
+	digits := strings.ReplaceAll(value, "-", "")
+	if len(value) != 36 || len(digits) != 32 {
+		return id, fmt.Errorf("invalid UUID %q", value)
+	}
+	if _, err := hex.Decode(id[:], []byte(digits)); err != nil {
+		return id, fmt.Errorf("invalid UUID %q", value)
+	}
+	return id, nil
+}
+
+// formatUUID formats b in the canonical 8-4-4-4-12 form
+func formatUUID(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
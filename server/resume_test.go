@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResumeCode(t *testing.T) {
+	secret := []byte("resume-test-secret")
+	gameID := "1b4e28ba-2fa1-41d2-883f-0016d3cca427"
+
+	code, err := NewResumeCode(secret, 1000, gameID)
+	if err != nil {
+		t.Fatalf("NewResumeCode failed: %v", err)
+	}
+	if !strings.HasPrefix(code, "1000-") {
+		t.Errorf("Expected the code to start with the puzzle number, got %q", code)
+	}
+
+	// Codes round trip, in either case
+	for _, input := range []string{code, strings.ToUpper(code), " " + code + " "} {
+		puzzle, id, err := ParseResumeCode(secret, input)
+		if err != nil {
+			t.Fatalf("ParseResumeCode(%q) failed: %v", input, err)
+		}
+		if puzzle != 1000 || id != gameID {
+			t.Errorf("Expected puzzle 1000 and game %s, got %d and %s", gameID, puzzle, id)
+		}
+	}
+
+	// Changing the puzzle number, the token or the secret breaks the signature
+	number, token, _ := strings.Cut(code, "-")
+	flipped := []byte(token)
+	if flipped[0] == 'a' {
+		flipped[0] = 'b'
+	} else {
+		flipped[0] = 'a'
+	}
+	tampered := []string{
+		"1001-" + token,
+		number + "-" + string(flipped),
+		number + "-" + token[:len(token)-1],
+		number + token,
+		"x-" + token,
+		"",
+	}
+	for _, input := range tampered {
+		if _, _, err := ParseResumeCode(secret, input); err == nil || !strings.Contains(err.Error(), "invalid resume code") {
+			t.Errorf("Expected %q to be rejected, got %v", input, err)
+		}
+	}
+	if _, _, err := ParseResumeCode([]byte("another-test-secret"), code); err == nil {
+		t.Error("Expected a code signed with another secret to be rejected")
+	}
+
+	if _, err := NewResumeCode(secret, 1000, "game-1"); err == nil {
+		t.Error("Expected an error for a game ID that is not a UUID")
+	}
+}
@@ -2,8 +2,14 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // GameService handles business logic for Wordle games
@@ -12,161 +18,2435 @@ type GameService struct {
 	guessRepo GuessRepositoryInterface
 	wordList  WordListInterface
 	config    *GameConfig
+	upper     func(string) string // Locale-aware uppercasing for targets and guesses
+	lower     func(string) string // Locale-aware lowercasing when normalizing words like the word list
+
+	definitions DefinitionProvider        // Optional source of target word definitions
+	transactor  TransactorInterface       // Optional; runs multi-step writes atomically
+	answerRepo  AnswerRepositoryInterface // Optional; daily answer schedule
+	ids         IDGenerator               // Generates game and guess IDs before insert
+	apiKeyRepo  APIKeyRepositoryInterface // Optional; API key game-creation quotas
+	playerRepo  PlayerRepositoryInterface // Optional; players looked up or created by username
+	tutorial    *Tutorial                 // Script for tutorial games; nil disables them
+	features    map[string]bool           // Enabled feature flags; nil enables every feature
+	notifier    CompletionNotifier        // Optional; told about won and lost games
+	auditor     AuditLogger               // Optional; records every mutation
+	actor       AuditActor                // Who audited mutations are attributed to; see WithActor
+	now         func() time.Time          // Clock that decides today's daily puzzle; see SetClock
+
+	guessSlots chan struct{} // Bounds concurrent MakeGuess calls; nil when unlimited
+
+	// Set on the copy withTransaction hands out: audit records and
+	// notifications wait here until the transaction commits
+	afterCommit *[]func()
+}
+
+// NewGameService creates a new game service
+func NewGameService(db *DB, wordList *WordList, config *GameConfig) *GameService {
+	return &GameService{
+		gameRepo:   NewGameRepository(db),
+		guessRepo:  NewGuessRepository(db),
+		wordList:   wordList,
+		config:     config,
+		upper:      upperCaserFor(config),
+		lower:      lowerCaserFor(config),
+		transactor: NewTransactor(db),
+		answerRepo: NewAnswerRepository(db),
+		apiKeyRepo: NewAPIKeyRepository(db),
+		playerRepo: NewPlayerRepository(db),
+		ids:        UUIDGenerator{},
+		tutorial:   DefaultTutorial(),
+		guessSlots: newGuessSlots(config),
+		now:        time.Now,
+	}
+}
+
+// NewGameServiceWithInterfaces creates a new game service with injectable interfaces
+func NewGameServiceWithInterfaces(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, wordList WordListInterface, config *GameConfig) *GameService {
+	return &GameService{
+		gameRepo:   gameRepo,
+		guessRepo:  guessRepo,
+		wordList:   wordList,
+		config:     config,
+		upper:      upperCaserFor(config),
+		lower:      lowerCaserFor(config),
+		ids:        UUIDGenerator{},
+		tutorial:   DefaultTutorial(),
+		guessSlots: newGuessSlots(config),
+		now:        time.Now,
+	}
+}
+
+// newGuessSlots returns the semaphore bounding concurrent guesses, or nil
+// when MaxConcurrentGuesses is not set
+func newGuessSlots(config *GameConfig) chan struct{} {
+	if config.MaxConcurrentGuesses <= 0 {
+		return nil
+	}
+	return make(chan struct{}, config.MaxConcurrentGuesses)
+}
+
+// SetTransactor sets the transactor used for multi-step writes. Without one,
+// operations run directly against the service's repositories.
+func (s *GameService) SetTransactor(transactor TransactorInterface) {
+	s.transactor = transactor
+}
+
+// inTransaction runs fn atomically when a transactor is configured, otherwise
+// it calls fn with the service's own repositories
+func (s *GameService) inTransaction(fn func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error) error {
+	if s.transactor == nil {
+		return fn(s.gameRepo, s.guessRepo)
+	}
+	return s.transactor.WithinTransaction(fn)
+}
+
+// withTransaction runs fn with a copy of the service whose game, guess and
+// player repositories are bound to one transaction, so several service calls
+// commit or roll back together. Calls on the copy that would start their own
+// transaction join this one instead. Audit records and notifications from fn
+// are only delivered once the transaction has committed.
+func (s *GameService) withTransaction(fn func(tx *GameService) error) error {
+	if s.afterCommit != nil {
+		return fn(s)
+	}
+
+	var afterCommit []func()
+	run := func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, playerRepo PlayerRepositoryInterface) error {
+		afterCommit = nil // Nothing from an attempt that failed is delivered
+		tx := *s
+		tx.gameRepo, tx.guessRepo = gameRepo, guessRepo
+		if s.playerRepo != nil {
+			tx.playerRepo = playerRepo
+		}
+		tx.transactor = nil
+		tx.afterCommit = &afterCommit
+		return fn(&tx)
+	}
+
+	var err error
+	if s.transactor == nil {
+		err = run(s.gameRepo, s.guessRepo, s.playerRepo)
+	} else {
+		err = s.transactor.WithinPlayerTransaction(run)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, deliver := range afterCommit {
+		deliver()
+	}
+	return nil
+}
+
+// whenCommitted calls deliver now, or once the transaction commits when the
+// service is the copy handed out by withTransaction
+func (s *GameService) whenCommitted(deliver func()) {
+	if s.afterCommit != nil {
+		*s.afterCommit = append(*s.afterCommit, deliver)
+		return
+	}
+	deliver()
+}
+
+// SetIDGenerator sets the generator for new game and guess IDs, e.g. a
+// seeded one for deterministic IDs in tests
+func (s *GameService) SetIDGenerator(ids IDGenerator) {
+	s.ids = ids
+}
+
+// SetClock replaces the clock that decides today's daily puzzle, so tests can
+// pin the date or step across midnight
+func (s *GameService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// Today returns the start of the current UTC day, which names today's daily
+// puzzle. The daily puzzle rolls over at midnight UTC.
+func (s *GameService) Today() time.Time {
+	return s.now().UTC().Truncate(24 * time.Hour)
+}
+
+// SetAnswerRepository sets the repository holding the daily answer schedule
+func (s *GameService) SetAnswerRepository(answerRepo AnswerRepositoryInterface) {
+	s.answerRepo = answerRepo
+}
+
+// SetAPIKeyRepository sets the repository holding API keys and their quotas
+func (s *GameService) SetAPIKeyRepository(apiKeyRepo APIKeyRepositoryInterface) {
+	s.apiKeyRepo = apiKeyRepo
+}
+
+// UseGameQuota counts a game creation against the API key's quota for date,
+// failing once the key's daily quota is used up. The creation is counted
+// before the game is created, so concurrent requests cannot overshoot the
+// quota; call RefundGameQuota if no game ends up being created.
+func (s *GameService) UseGameQuota(key string, date time.Time) error {
+	if s.apiKeyRepo == nil {
+		return fmt.Errorf("api keys are not configured")
+	}
+	if key = strings.TrimSpace(key); key == "" {
+		return fmt.Errorf("api key is required")
+	}
+	return s.apiKeyRepo.UseGameQuota(key, date)
+}
+
+// RefundGameQuota gives back a game creation counted by UseGameQuota for date
+func (s *GameService) RefundGameQuota(key string, date time.Time) error {
+	if s.apiKeyRepo == nil {
+		return fmt.Errorf("api keys are not configured")
+	}
+	return s.apiKeyRepo.RefundGameQuota(strings.TrimSpace(key), date)
+}
+
+// SetPlayerRepository sets the repository holding players
+func (s *GameService) SetPlayerRepository(playerRepo PlayerRepositoryInterface) {
+	s.playerRepo = playerRepo
+}
+
+// maxUsernameLength matches the players.username column
+const maxUsernameLength = 50
+
+// ResolvePlayer returns the player with the given username. When none exists
+// and AutoCreatePlayers is enabled the player is created; it reports whether
+// it was. A concurrent request creating the same username first is not an
+// error: its player is returned instead.
+func (s *GameService) ResolvePlayer(username string) (*Player, bool, error) {
+	if s.playerRepo == nil {
+		return nil, false, fmt.Errorf("players are not configured")
+	}
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, false, fmt.Errorf("username is required")
+	}
+	if utf8.RuneCountInString(username) > maxUsernameLength {
+		return nil, false, fmt.Errorf("username must be at most %d characters", maxUsernameLength)
+	}
+
+	player, err := s.playerRepo.GetPlayerByUsername(username)
+	if err == nil {
+		return player, false, nil
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		return nil, false, fmt.Errorf("failed to get player: %w", err)
+	}
+	if !s.config.AutoCreatePlayers {
+		return nil, false, fmt.Errorf("player %q not found", username)
+	}
+
+	player, err = s.playerRepo.CreatePlayer(s.ids.NewID(), username)
+	if err != nil && strings.Contains(err.Error(), "already exists") {
+		// A concurrent request created the player first
+		player, err = s.playerRepo.GetPlayerByUsername(username)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get player: %w", err)
+		}
+		return player, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create player: %w", err)
+	}
+	return player, true, nil
+}
+
+// GetPlayerRank returns the player's rank and percentile among all players
+// by metric, which defaults to max_streak
+func (s *GameService) GetPlayerRank(playerID, metric string) (*PlayerRank, error) {
+	if s.playerRepo == nil {
+		return nil, fmt.Errorf("players are not configured")
+	}
+	if metric == "" {
+		metric = PlayerMetricMaxStreak
+	}
+	if !slices.Contains(PlayerRankMetrics, metric) {
+		return nil, fmt.Errorf("invalid rank metric %q: must be one of %s", metric, strings.Join(PlayerRankMetrics, ", "))
+	}
+
+	rank, err := s.playerRepo.GetPlayerRank(playerID, metric)
+	if err != nil {
+		return nil, err
+	}
+	return rank, nil
+}
+
+// AssignPlayer associates a game with a player
+func (s *GameService) AssignPlayer(gameID, playerID string) error {
+	if err := s.gameRepo.SetGamePlayer(gameID, playerID); err != nil {
+		return fmt.Errorf("failed to assign player: %w", err)
+	}
+	s.audit(AuditActionGameUpdated, gameID)
+	return nil
+}
+
+// SetDefinitionProvider sets the source used to reveal the target word's
+// definition once a game has ended. A nil provider disables definitions.
+func (s *GameService) SetDefinitionProvider(provider DefinitionProvider) {
+	s.definitions = provider
+}
+
+// SetFeatures sets the enabled feature flags, keyed by the Feature* names.
+// Service paths of a disabled feature return ErrFeatureDisabled. A nil map
+// enables every feature.
+func (s *GameService) SetFeatures(features map[string]bool) {
+	s.features = features
+}
+
+// requireFeature returns ErrFeatureDisabled unless the named feature is enabled
+func (s *GameService) requireFeature(name string) error {
+	if s.features == nil || s.features[name] {
+		return nil
+	}
+	return &ErrFeatureDisabled{Feature: name}
+}
+
+// SetTutorial sets the script used for tutorial games. A nil tutorial
+// disables tutorial games.
+func (s *GameService) SetTutorial(tutorial *Tutorial) {
+	s.tutorial = tutorial
+}
+
+// SetCompletionNotifier sets the notifier told about every game that ends.
+// A nil notifier disables notifications.
+func (s *GameService) SetCompletionNotifier(notifier CompletionNotifier) {
+	s.notifier = notifier
+}
+
+// SetAuditLogger sets the logger recording every create, guess, update and
+// delete. A nil logger disables auditing.
+func (s *GameService) SetAuditLogger(auditor AuditLogger) {
+	s.auditor = auditor
+}
+
+// WithActor returns a copy of the service that attributes audited mutations to
+// actor. The copy shares the original's repositories, word list and settings.
+func (s *GameService) WithActor(actor AuditActor) *GameService {
+	scoped := *s
+	scoped.actor = actor
+	return &scoped
+}
+
+// audit records a mutation of the game when auditing is enabled. Callers must
+// only use it once the mutation has been committed.
+func (s *GameService) audit(action, gameID string) {
+	if s.auditor == nil {
+		return
+	}
+	record := AuditRecord{
+		Action:    action,
+		GameID:    gameID,
+		Timestamp: time.Now().UTC(),
+		Actor:     s.actor,
+	}
+	auditor := s.auditor
+	s.whenCommitted(func() { auditor.Record(record) })
+}
+
+// notifyIfCompleted announces the game to the notifier once it has ended.
+// Callers must only use it after the guess has been committed.
+func (s *GameService) notifyIfCompleted(game *Game) {
+	if s.notifier != nil && game.IsCompleted {
+		notifier, completed := s.notifier, *game
+		s.whenCommitted(func() { notifier.NotifyGameCompleted(completed) })
+	}
+}
+
+// RevealedDefinition returns the definition of the game's target word if the
+// game has ended and a definition is available, or an empty string otherwise
+func (s *GameService) RevealedDefinition(game *Game) string {
+	if s.definitions == nil || !game.IsCompleted {
+		return ""
+	}
+	definition, _ := s.definitions.Definition(game.TargetWord)
+	return definition
+}
+
+// RevealedAnagrams returns the valid words that are anagrams of the game's
+// target word if anagrams are revealed and the game has ended, or nil
+func (s *GameService) RevealedAnagrams(game *Game) []string {
+	if !s.config.RevealAnagrams || !game.IsCompleted {
+		return nil
+	}
+	candidates := s.wordList.WordsOfLength(game.WordLength())
+	for i, word := range candidates {
+		candidates[i] = s.upper(word)
+	}
+	return AnagramsOf(s.upper(game.TargetWord), candidates)
+}
+
+// upperCaserFor returns the case mapping for the configured game locale,
+// falling back to the default mapping if the locale cannot be parsed. With
+// case-sensitive words, targets and guesses keep their case, matching the
+// word list's exact lookups.
+func upperCaserFor(config *GameConfig) func(string) string {
+	if config.CaseSensitiveWords {
+		return func(word string) string { return word }
+	}
+	upper, err := NewUpperCaser(config.Locale)
+	if err != nil {
+		return strings.ToUpper
+	}
+	return upper
+}
+
+// lowerCaserFor returns the lowercase mapping for the configured game locale,
+// the one the word list folds words with, falling back to the default mapping
+// if the locale cannot be parsed
+func lowerCaserFor(config *GameConfig) func(string) string {
+	lower, err := NewLowerCaser(config.Locale)
+	if err != nil {
+		return strings.ToLower
+	}
+	return lower
+}
+
+// Bounds on the guesses a new game may allow in place of the configured default
+const (
+	minRequestedGuesses = 1
+	maxRequestedGuesses = 12
+)
+
+// CreateNewGame creates a new game with a random target word from the common
+// words list, allowing maxGuesses guesses; 0 uses the configured MaxGuesses
+func (s *GameService) CreateNewGame(maxGuesses int) (*Game, error) {
+	return s.CreateNewGameForClient(maxGuesses, nil)
+}
+
+// CreateNewGameForClient creates a new game like CreateNewGame and, when
+// client is not nil, records the client IP and user agent with it in the same
+// transaction
+func (s *GameService) CreateNewGameForClient(maxGuesses int, client *ClientInfo) (*Game, error) {
+	draft, err := s.draftNewGame(maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	return s.createDraft(draft, client)
+}
+
+// newGameMaxGuesses returns the guesses a new game allows: requested, or the
+// configured MaxGuesses when requested is 0. Requests outside
+// minRequestedGuesses..maxRequestedGuesses are an error.
+func (s *GameService) newGameMaxGuesses(requested int) (int, error) {
+	if requested == 0 {
+		return s.config.MaxGuesses, nil
+	}
+	if requested < minRequestedGuesses || requested > maxRequestedGuesses {
+		return 0, fmt.Errorf("max_guesses must be between %d and %d", minRequestedGuesses, maxRequestedGuesses)
+	}
+	return requested, nil
+}
+
+// recordClientInfo stores the client info for a game when it is provided
+func (s *GameService) recordClientInfo(gameRepo GameRepositoryInterface, gameID string, client *ClientInfo) error {
+	if client == nil {
+		return nil
+	}
+	if err := gameRepo.SetClientInfo(gameID, *client); err != nil {
+		return fmt.Errorf("failed to record client info: %w", err)
+	}
+	return nil
+}
+
+// gameDraft is a new game decided before its transaction opens: the target is
+// picked and its difficulty estimated up front, so the solver simulation never
+// runs while a transaction is held. insert creates the game row.
+type gameDraft struct {
+	difficulty float64
+	insert     func(gameRepo GameRepositoryInterface) (*Game, error)
+}
+
+// draftNewGame picks a random target word for a regular game allowing
+// maxGuesses guesses, 0 for the configured MaxGuesses
+func (s *GameService) draftNewGame(maxGuesses int) (*gameDraft, error) {
+	maxGuesses, err := s.newGameMaxGuesses(maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get a random five-letter word from the target words (common words)
+	// TODO: this could be in the database but for now it's loaded from a file
+	// TODO: random word should not repeat for user
+	fiveLetterTargetWords := s.wordList.FiveLetterTargetWords()
+	if len(fiveLetterTargetWords) == 0 {
+		return nil, fmt.Errorf("no five-letter target words available")
+	}
+
+	targetWord, err := s.randomTargetWord()
+	if err != nil {
+		return nil, err
+	}
+
+	return &gameDraft{
+		difficulty: s.WordDifficulty(targetWord),
+		insert: func(gameRepo GameRepositoryInterface) (*Game, error) {
+			game, err := gameRepo.CreateGame(s.ids.NewID(), targetWord, maxGuesses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create game: %w", err)
+			}
+			return game, nil
+		},
+	}, nil
+}
+
+// insertDraft creates draft's game and stores its difficulty using the given
+// repository. Callers run it in a transaction so both are written together.
+func (s *GameService) insertDraft(gameRepo GameRepositoryInterface, draft *gameDraft) (*Game, error) {
+	game, err := draft.insert(gameRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := gameRepo.SetWordDifficulty(game.ID, draft.difficulty); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+// createDraft creates draft's game in one transaction with its difficulty
+// and, when client is not nil, the client IP and user agent
+func (s *GameService) createDraft(draft *gameDraft, client *ClientInfo) (*Game, error) {
+	var game *Game
+	err := s.inTransaction(func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error {
+		var err error
+		if game, err = s.insertDraft(gameRepo, draft); err != nil {
+			return err
+		}
+		return s.recordClientInfo(gameRepo, game.ID, client)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditActionGameCreated, game.ID)
+	return game, nil
+}
+
+// randomTargetWord picks the target word for a new game. In the isogram
+// variant it is drawn from the isograms of the configured word length.
+func (s *GameService) randomTargetWord() (string, error) {
+	if !s.isogramTargets() {
+		return s.upper(s.wordList.RandomWord()), nil
+	}
+	words := s.targetPool(s.wordList.TargetWordsOfLength(s.config.WordLength))
+	if len(words) == 0 {
+		return "", fmt.Errorf("no isogram target words of length %d", s.config.WordLength)
+	}
+	return s.upper(words[rng.Intn(len(words))]), nil
+}
+
+// isogramTargets reports whether target words are restricted to isograms
+func (s *GameService) isogramTargets() bool {
+	return s.config.IsogramMode == IsogramModeTargets || s.config.IsogramMode == IsogramModeStrict
+}
+
+// targetPool returns the target words new games may pick from: all of words,
+// or only its isograms in the isogram variant
+func (s *GameService) targetPool(words []string) []string {
+	if !s.isogramTargets() {
+		return words
+	}
+	return filterIsograms(words)
+}
+
+// WordDifficulty scores how hard targetWord is to solve from 0 (easiest) to 1,
+// from the expected guesses of a bounded solver simulation over the target
+// words of the same length
+func (s *GameService) WordDifficulty(targetWord string) float64 {
+	words := s.wordList.TargetWordsOfLength(utf8.RuneCountInString(targetWord))
+	candidates := make([]string, len(words))
+	for i, word := range words {
+		candidates[i] = s.upper(word)
+	}
+	return difficultyScore(ExpectedGuesses(targetWord, candidates, s.config.MaxGuesses), s.config.MaxGuesses)
+}
+
+// CreateChallengeGame creates a challenge game whose target word is picked by
+// seed, so everyone given the same seed plays the same word. The target words
+// are sorted first so the pick doesn't depend on word file order.
+func (s *GameService) CreateChallengeGame(seed int64) (*Game, error) {
+	draft, err := s.draftChallengeGame(seed)
+	if err != nil {
+		return nil, err
+	}
+	return s.createDraft(draft, nil)
+}
+
+// draftChallengeGame picks the target word seed gives a challenge game
+func (s *GameService) draftChallengeGame(seed int64) (*gameDraft, error) {
+	words, err := s.challengeWords(s.config.WordLength)
+	if err != nil {
+		return nil, err
+	}
+	return s.challengeDraft(challengeWord(words, seed)), nil
+}
+
+// challengeDraft drafts a challenge game for targetWord
+func (s *GameService) challengeDraft(targetWord string) *gameDraft {
+	return &gameDraft{
+		difficulty: s.WordDifficulty(targetWord),
+		insert: func(gameRepo GameRepositoryInterface) (*Game, error) {
+			game, err := gameRepo.CreateChallengeGame(s.ids.NewID(), targetWord, s.config.MaxGuesses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create challenge game: %w", err)
+			}
+			return game, nil
+		},
+	}
+}
+
+// challengeWords returns the uppercased target words of the given length that
+// challenge seeds pick from, in a fixed order
+func (s *GameService) challengeWords(length int) ([]string, error) {
+	words := s.targetPool(s.wordList.TargetWordsOfLength(length))
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no target words of length %d", length)
+	}
+	sorted := make([]string, len(words))
+	for i, word := range words {
+		sorted[i] = s.upper(word)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+// Word lengths a custom challenge may use. The longest is the width of the
+// target_word and guess_word columns, VARCHAR(16).
+const (
+	minCustomWordLength = 2
+	maxCustomWordLength = 16
+)
+
+// CreateCustomChallenge creates a challenge game from a custom spec: a word
+// length and dictionary, and either a seed or an explicit target word. The
+// options are validated together before anything is created.
+func (s *GameService) CreateCustomChallenge(spec CustomChallenge) (*Game, error) {
+	draft, err := s.draftCustomChallenge(spec)
+	if err != nil {
+		return nil, err
+	}
+	return s.createDraft(draft, nil)
+}
+
+// draftCustomChallenge validates a custom challenge spec and drafts its game
+func (s *GameService) draftCustomChallenge(spec CustomChallenge) (*gameDraft, error) {
+	targetWord, err := s.customChallengeTarget(spec)
+	if err != nil {
+		return nil, err
+	}
+	return s.challengeDraft(targetWord), nil
+}
+
+// customChallengeTarget validates a custom challenge spec and returns its
+// target word. The word length defaults to the target's when one is given,
+// and to the configured length otherwise.
+func (s *GameService) customChallengeTarget(spec CustomChallenge) (string, error) {
+	if spec.Seed != nil && spec.TargetWord != "" {
+		return "", fmt.Errorf("seed and target_word must not both be set")
+	}
+	if spec.Dictionary != "" && spec.Dictionary != DictionaryDefault {
+		return "", fmt.Errorf("unknown dictionary %q (available: %q)", spec.Dictionary, DictionaryDefault)
+	}
+
+	targetWord := s.upper(strings.TrimSpace(spec.TargetWord))
+	length := spec.WordLength
+	if length == 0 {
+		length = s.config.WordLength
+		if targetWord != "" {
+			length = utf8.RuneCountInString(targetWord)
+		}
+	}
+	maxLength := maxCustomWordLength
+	if guessLength := s.maxGuessRuneLength(); guessLength < maxLength {
+		maxLength = guessLength
+	}
+	if length < minCustomWordLength || length > maxLength {
+		return "", fmt.Errorf("word_length must be between %d and %d", minCustomWordLength, maxLength)
+	}
+
+	if targetWord == "" {
+		words, err := s.challengeWords(length)
+		if err != nil {
+			return "", err
+		}
+		seed := int64(0)
+		if spec.Seed != nil {
+			seed = *spec.Seed
+		}
+		return challengeWord(words, seed), nil
+	}
+
+	if utf8.RuneCountInString(targetWord) != length {
+		return "", fmt.Errorf("target_word must be %d letters long to match word_length", length)
+	}
+	if !onlyLetters(targetWord) || !s.wordList.Contains(targetWord) {
+		return "", fmt.Errorf("target_word must be a word in the %s dictionary", DictionaryDefault)
+	}
+	if s.isogramTargets() && !IsIsogram(targetWord) {
+		return "", fmt.Errorf("target_word must be an isogram, with no repeated letters")
+	}
+	return targetWord, nil
+}
+
+// challengeWord returns the word seed picks from the sorted challenge words
+func challengeWord(words []string, seed int64) string {
+	return words[rand.New(rand.NewSource(seed)).Intn(len(words))]
+}
+
+const (
+	// maxSeedAttemptsPerWord bounds the seeds SuggestNextSeed tries, per target
+	// word, before giving up on finding one for an unplayed word
+	maxSeedAttemptsPerWord = 100
+	// maxSuggestedSeed keeps suggested seeds exact as JavaScript numbers
+	maxSuggestedSeed = 1 << 53
+)
+
+// SuggestNextSeed returns a challenge seed whose target word the player hasn't
+// played yet, for starting a fresh game with CreateChallengeGame. Only the seed
+// is returned so the word stays hidden until the game is played.
+func (s *GameService) SuggestNextSeed(playerID string) (int64, error) {
+	words, err := s.challengeWords(s.config.WordLength)
+	if err != nil {
+		return 0, err
+	}
+
+	playedWords, err := s.gameRepo.GetPlayedTargetWords(playerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get played words: %w", err)
+	}
+	played := make(map[string]bool, len(playedWords))
+	for _, word := range playedWords {
+		played[s.upper(word)] = true
+	}
+
+	unplayed := 0
+	for _, word := range words {
+		if !played[word] {
+			unplayed++
+		}
+	}
+	if unplayed == 0 {
+		return 0, fmt.Errorf("no unplayed target words left for player %s", playerID)
+	}
+
+	// Seeds map to words pseudo-randomly, so try random seeds until one lands
+	// on an unplayed word; with u of n words unplayed that takes n/u tries on
+	// average
+	for attempt := 0; attempt < maxSeedAttemptsPerWord*len(words); attempt++ {
+		seed := rng.Int63n(maxSuggestedSeed)
+		if !played[challengeWord(words, seed)] {
+			return seed, nil
+		}
+	}
+	return 0, fmt.Errorf("no seed found for an unplayed target word")
+}
+
+// CreateTutorialGame creates a scripted tutorial game and returns it with the
+// guidance for the first guess
+func (s *GameService) CreateTutorialGame() (*GameResponse, error) {
+	draft, err := s.draftTutorialGame()
+	if err != nil {
+		return nil, err
+	}
+	game, err := s.createDraft(draft, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.tutorialResponse(game), nil
+}
+
+// draftTutorialGame drafts a game for the tutorial's scripted target word
+func (s *GameService) draftTutorialGame() (*gameDraft, error) {
+	if s.tutorial == nil {
+		return nil, fmt.Errorf("tutorial games are not available")
+	}
+
+	targetWord := s.upper(strings.TrimSpace(s.tutorial.TargetWord))
+	if utf8.RuneCountInString(targetWord) != s.config.WordLength {
+		return nil, fmt.Errorf("tutorial target word must be %d letters long", s.config.WordLength)
+	}
+
+	return &gameDraft{
+		difficulty: s.WordDifficulty(targetWord),
+		insert: func(gameRepo GameRepositoryInterface) (*Game, error) {
+			game, err := gameRepo.CreateTutorialGame(s.ids.NewID(), targetWord, s.config.MaxGuesses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create tutorial game: %w", err)
+			}
+			return game, nil
+		},
+	}, nil
+}
+
+// tutorialResponse returns a new tutorial game with the guidance for its first guess
+func (s *GameService) tutorialResponse(game *Game) *GameResponse {
+	return &GameResponse{
+		Game:     *game,
+		Message:  fmt.Sprintf("Tutorial started! Find the word in %d guesses.", game.MaxGuesses),
+		Guidance: s.tutorial.GuidanceBefore(1),
+	}
+}
+
+// CreateAnagramGame creates an anagram-practice game: a random target word of
+// the given length whose scrambled letters the player must unscramble. Only
+// the original word wins, even if the letters spell other valid words. No
+// difficulty is stored: the solver simulation scores finding a word from
+// tile feedback, while an anagram game hands the player all its letters.
+func (s *GameService) CreateAnagramGame(length int) (*Game, error) {
+	if err := s.requireFeature(FeatureAnagram); err != nil {
+		return nil, err
+	}
+	words := s.targetPool(s.wordList.TargetWordsOfLength(length))
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no target words of length %d", length)
+	}
+
+	targetWord := s.upper(words[rng.Intn(len(words))])
+	game, err := s.gameRepo.CreateAnagramGame(s.ids.NewID(), targetWord, scrambleWord(targetWord), s.config.MaxGuesses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create anagram game: %w", err)
+	}
+	s.audit(AuditActionGameCreated, game.ID)
+
+	return game, nil
+}
+
+// CreateMultiBoardGame creates a duet/quordle-style game with the given number
+// of distinct random targets, all played with the same guesses. maxGuesses 0
+// allows the configured MaxGuesses plus one per extra board.
+func (s *GameService) CreateMultiBoardGame(boards, maxGuesses int) (*Game, error) {
+	draft, err := s.draftMultiBoardGame(boards, maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	return s.createDraft(draft, nil)
+}
+
+// draftMultiBoardGame picks the targets of a multi-board game. Its difficulty
+// is its hardest board's, since the game isn't won until that one is solved.
+func (s *GameService) draftMultiBoardGame(boards, maxGuesses int) (*gameDraft, error) {
+	if s.config.MaxBoards < 2 {
+		return nil, ErrMultiBoardDisabled
+	}
+	if boards < 2 || boards > s.config.MaxBoards {
+		return nil, fmt.Errorf("%w: boards must be between 2 and %d", ErrBoardCount, s.config.MaxBoards)
+	}
+	if maxGuesses == 0 {
+		maxGuesses = s.config.MaxGuesses + boards - 1
+	} else if _, err := s.newGameMaxGuesses(maxGuesses); err != nil {
+		return nil, err
+	}
+
+	// Distinct targets, so no one guess solves two boards
+	words := s.targetPool(s.wordList.FiveLetterTargetWords())
+	var targets []string
+	seen := make(map[string]bool)
+	for _, i := range rng.Perm(len(words)) {
+		word := s.upper(words[i])
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		targets = append(targets, word)
+		if len(targets) == boards {
+			break
+		}
+	}
+	if len(targets) < boards {
+		return nil, fmt.Errorf("not enough target words for %d boards", boards)
+	}
+
+	difficulty := 0.0
+	for _, target := range targets {
+		difficulty = max(difficulty, s.WordDifficulty(target))
+	}
+	return &gameDraft{
+		difficulty: difficulty,
+		insert: func(gameRepo GameRepositoryInterface) (*Game, error) {
+			game, err := gameRepo.CreateMultiBoardGame(s.ids.NewID(), targets, maxGuesses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create multi-board game: %w", err)
+			}
+			return game, nil
+		},
+	}, nil
+}
+
+// tutorialGuidance returns the scripted guidance for the game's next guess,
+// or an empty string for regular or finished games
+func (s *GameService) tutorialGuidance(game *Game) string {
+	if !game.IsTutorial || game.IsCompleted || s.tutorial == nil {
+		return ""
+	}
+	return s.tutorial.GuidanceBefore(game.GuessCount + 1)
+}
+
+// CreateNewGameWithGuess creates a new game and submits its first guess in a
+// single transaction, so an invalid guess or failed write leaves no game behind
+func (s *GameService) CreateNewGameWithGuess(guessWord string) (*GameResponse, error) {
+	return s.CreateNewGameWithGuessForClient(guessWord, 0, nil)
+}
+
+// CreateNewGameWithGuessForClient is CreateNewGameWithGuess allowing
+// maxGuesses guesses, 0 for the configured MaxGuesses, that also records the
+// client info, when not nil, in the same transaction
+func (s *GameService) CreateNewGameWithGuessForClient(guessWord string, maxGuesses int, client *ClientInfo) (*GameResponse, error) {
+	draft, err := s.draftNewGame(maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	return s.createDraftWithGuess(draft, guessWord, client)
+}
+
+// createDraftWithGuess creates draft's game like createDraft and submits its
+// first guess in the same transaction
+func (s *GameService) createDraftWithGuess(draft *gameDraft, guessWord string, client *ClientInfo) (*GameResponse, error) {
+	var response *GameResponse
+	err := s.inTransaction(func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error {
+		game, err := s.insertDraft(gameRepo, draft)
+		if err != nil {
+			return err
+		}
+		if err := s.recordClientInfo(gameRepo, game.ID, client); err != nil {
+			return err
+		}
+
+		response, err = s.makeGuess(gameRepo, guessRepo, game.ID, guessWord, GuessOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditActionGameCreated, response.Game.ID)
+	s.audit(AuditActionGuessCreated, response.Game.ID)
+	s.notifyIfCompleted(&response.Game)
+	return response, nil
+}
+
+// defaultMaxBoards is how many targets a multi-board game may have unless
+// MaxBoards is configured otherwise (quordle-style)
+const defaultMaxBoards = 4
+
+// defaultDailyCreateRetries is how many times CreateOrGetDailyGame fetches the
+// daily game after losing a race to create it, unless configured otherwise
+const defaultDailyCreateRetries = 3
+
+// maxScheduleDays caps how many days ScheduleAnswers will fill in one call
+const maxScheduleDays = 366
+
+// DailyResumeCode returns the code that resumes a daily game on another
+// device, or "" when resume codes are not configured
+func (s *GameService) DailyResumeCode(game *Game) (string, error) {
+	if s.config.DailyResumeSecret == "" {
+		return "", nil
+	}
+	if game.DailyDate == nil {
+		return "", fmt.Errorf("game %s is not a daily game", game.ID)
+	}
+	return NewResumeCode([]byte(s.config.DailyResumeSecret), PuzzleNumber(*game.DailyDate), game.ID)
+}
+
+// ResumeDailyGame returns the daily game, with its guesses, that a resume
+// code from DailyResumeCode refers to
+func (s *GameService) ResumeDailyGame(code string) (*GameWithGuesses, error) {
+	if err := s.requireFeature(FeatureDaily); err != nil {
+		return nil, err
+	}
+	if s.config.DailyResumeSecret == "" {
+		return nil, fmt.Errorf("daily resume codes are not configured")
+	}
+	puzzle, gameID, err := ParseResumeCode([]byte(s.config.DailyResumeSecret), code)
+	if err != nil {
+		return nil, err
+	}
+
+	gameWithGuesses, err := s.gameRepo.GetGameWithGuesses(gameID)
+	if err != nil {
+		return nil, err
+	}
+	// Signed codes only name daily games, but check the puzzle still matches
+	if game := gameWithGuesses.Game; game.DailyDate == nil || PuzzleNumber(*game.DailyDate) != puzzle {
+		return nil, fmt.Errorf("invalid resume code")
+	}
+	return gameWithGuesses, nil
+}
+
+// CreateOrGetDailyGame returns the daily game for the given date, creating it
+// from the answer schedule on first request, or from the date itself with
+// DailyDerivedWords when none is scheduled. With a player ID and
+// DailyGamePerPlayer enabled, the player gets their own daily game, at most
+// one per date; otherwise everyone shares the date's single daily game.
+// Concurrent first requests all get the one game: those losing the race to
+// create it fetch the winner's, up to DailyCreateRetries times.
+func (s *GameService) CreateOrGetDailyGame(playerID string, date time.Time) (*Game, error) {
+	if err := s.requireFeature(FeatureDaily); err != nil {
+		return nil, err
+	}
+	if s.answerRepo == nil && !s.config.DailyDerivedWords {
+		return nil, fmt.Errorf("daily answer schedule is not configured")
+	}
+	if !s.config.DailyGamePerPlayer {
+		playerID = ""
+	}
+
+	retries := s.config.DailyCreateRetries
+	if retries <= 0 {
+		retries = defaultDailyCreateRetries
+	}
+
+	var draft *gameDraft
+	for attempt := 0; ; attempt++ {
+		existing, err := s.getDailyGame(playerID, date)
+		if err == nil {
+			return existing, nil
+		}
+		if !strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("failed to get daily game: %w", err)
+		}
+
+		if draft == nil {
+			if draft, err = s.draftDailyGame(playerID, date); err != nil {
+				return nil, err
+			}
+		}
+
+		game, err := s.createDraft(draft, nil)
+		if err == nil {
+			return game, nil
+		}
+		// A concurrent request that created the game first makes the insert a
+		// unique violation; fetch its game instead
+		if !strings.Contains(err.Error(), "already exists") || attempt >= retries {
+			return nil, fmt.Errorf("failed to create daily game: %w", err)
+		}
+	}
+}
+
+// draftDailyGame drafts the date's daily game, the player's own when playerID
+// is set
+func (s *GameService) draftDailyGame(playerID string, date time.Time) (*gameDraft, error) {
+	targetWord, err := s.dailyTargetWord(date)
+	if err != nil {
+		return nil, err
+	}
+	return &gameDraft{
+		difficulty: s.WordDifficulty(targetWord),
+		insert: func(gameRepo GameRepositoryInterface) (*Game, error) {
+			if playerID != "" {
+				return gameRepo.CreatePlayerDailyGame(s.ids.NewID(), playerID, targetWord, s.config.MaxGuesses, date)
+			}
+			return gameRepo.CreateDailyGame(s.ids.NewID(), targetWord, s.config.MaxGuesses, date)
+		},
+	}, nil
+}
+
+// dailyTargetWord returns the answer scheduled for date or, with
+// DailyDerivedWords, the word list's word for the date when none is
+func (s *GameService) dailyTargetWord(date time.Time) (string, error) {
+	if s.answerRepo != nil {
+		targetWord, err := s.answerRepo.GetAnswer(date)
+		if err == nil {
+			return s.upper(targetWord), nil
+		}
+		if !s.config.DailyDerivedWords || !strings.Contains(err.Error(), "no answer scheduled") {
+			return "", err
+		}
+	}
+
+	targetWord := s.wordList.DailyWord(date)
+	if targetWord == "" {
+		return "", fmt.Errorf("no answer scheduled for %s and no target words to derive one", dateKey(date))
+	}
+	return s.upper(targetWord), nil
+}
+
+// getDailyGame gets the player's own daily game, or the shared one when
+// playerID is empty
+func (s *GameService) getDailyGame(playerID string, date time.Time) (*Game, error) {
+	if playerID != "" {
+		return s.gameRepo.GetPlayerDailyGame(playerID, date)
+	}
+	return s.gameRepo.GetDailyGame(date)
+}
+
+// ScheduleAnswers regenerates the answer schedule for the given number of days
+// starting at start. Each date gets a distinct target word that is also not
+// scheduled within a year either side of the range.
+func (s *GameService) ScheduleAnswers(start time.Time, days int) ([]ScheduledAnswer, error) {
+	if err := s.requireFeature(FeatureDaily); err != nil {
+		return nil, err
+	}
+	if s.answerRepo == nil {
+		return nil, fmt.Errorf("daily answer schedule is not configured")
+	}
+	if days <= 0 || days > maxScheduleDays {
+		return nil, fmt.Errorf("days must be between 1 and %d", maxScheduleDays)
+	}
+
+	start = start.UTC().Truncate(24 * time.Hour)
+	end := start.AddDate(0, 0, days-1)
+
+	// Words scheduled near the range are not reused
+	used := make(map[string]bool)
+	for _, window := range [][2]time.Time{
+		{start.AddDate(-1, 0, 0), start.AddDate(0, 0, -1)},
+		{end.AddDate(0, 0, 1), end.AddDate(1, 0, 0)},
+	} {
+		nearby, err := s.answerRepo.GetAnswers(window[0], window[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scheduled answers: %w", err)
+		}
+		for _, answer := range nearby {
+			used[s.upper(answer.TargetWord)] = true
+		}
+	}
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, word := range s.targetPool(s.wordList.FiveLetterTargetWords()) {
+		word = s.upper(word)
+		if !used[word] && !seen[word] {
+			candidates = append(candidates, word)
+			seen[word] = true
+		}
+	}
+	if len(candidates) < days {
+		return nil, fmt.Errorf("not enough unused target words to schedule %d days (have %d)", days, len(candidates))
+	}
+
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	answers := make([]ScheduledAnswer, days)
+	for i := range answers {
+		answers[i] = ScheduledAnswer{
+			PuzzleDate: start.AddDate(0, 0, i),
+			TargetWord: candidates[i],
+		}
+	}
+
+	if err := s.answerRepo.SaveAnswers(answers); err != nil {
+		return nil, fmt.Errorf("failed to save answer schedule: %w", err)
+	}
+
+	return answers, nil
+}
+
+// maxBulkGames caps the number of games CreateGames will insert in one call
+const maxBulkGames = 1000
+
+// CreateGames inserts n games with random target words in a single batch and
+// returns their IDs, storing their difficulties in the same transaction. Each
+// distinct word is scored once, before the transaction opens. It is intended
+// for load testing and seeding, and is only available when bulk creation is
+// enabled in the game configuration.
+func (s *GameService) CreateGames(n int) ([]string, error) {
+	if !s.config.AllowBulkCreate {
+		return nil, fmt.Errorf("bulk game creation is disabled")
+	}
+	if n <= 0 || n > maxBulkGames {
+		return nil, fmt.Errorf("game count must be between 1 and %d", maxBulkGames)
+	}
+	if len(s.wordList.FiveLetterTargetWords()) == 0 {
+		return nil, fmt.Errorf("no five-letter target words available")
+	}
+
+	ids := make([]string, n)
+	targetWords := make([]string, n)
+	difficulties := make(map[string]float64)
+	for i := range targetWords {
+		targetWord, err := s.randomTargetWord()
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = s.ids.NewID()
+		targetWords[i] = targetWord
+		if _, ok := difficulties[targetWord]; !ok {
+			difficulties[targetWord] = s.WordDifficulty(targetWord)
+		}
+	}
+
+	err := s.inTransaction(func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error {
+		if err := gameRepo.CreateGames(ids, targetWords, s.config.MaxGuesses); err != nil {
+			return fmt.Errorf("failed to create games: %w", err)
+		}
+		for i, id := range ids {
+			if err := gameRepo.SetWordDifficulty(id, difficulties[targetWords[i]]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		s.audit(AuditActionGameCreated, id)
+	}
+
+	return ids, nil
+}
+
+// GetGame retrieves a game by ID
+func (s *GameService) GetGame(gameID string) (*Game, error) {
+	return s.gameRepo.GetGame(gameID)
+}
+
+// GetGameWithGuesses retrieves a game with all its guesses
+func (s *GameService) GetGameWithGuesses(gameID string) (*GameWithGuesses, error) {
+	return s.gameRepo.GetGameWithGuesses(gameID)
+}
+
+// Guess orderings accepted by GetGameWithGuessesOrdered
+const (
+	GuessOrderAsc  = "asc"
+	GuessOrderDesc = "desc"
+)
+
+// GetGameWithGuessesOrdered retrieves a game with its guesses sorted by guess
+// number in the given order. An empty order means ascending.
+func (s *GameService) GetGameWithGuessesOrdered(gameID, order string) (*GameWithGuesses, error) {
+	order = strings.ToLower(strings.TrimSpace(order))
+	if order == "" {
+		order = GuessOrderAsc
+	}
+	if order != GuessOrderAsc && order != GuessOrderDesc {
+		return nil, fmt.Errorf("invalid order %q (expected %q or %q)", order, GuessOrderAsc, GuessOrderDesc)
+	}
+
+	gameWithGuesses, err := s.gameRepo.GetGameWithGuesses(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	guesses := gameWithGuesses.Guesses
+	sort.SliceStable(guesses, func(i, j int) bool {
+		if order == GuessOrderDesc {
+			return guesses[i].GuessNumber > guesses[j].GuessNumber
+		}
+		return guesses[i].GuessNumber < guesses[j].GuessNumber
+	})
+
+	return gameWithGuesses, nil
+}
+
+// Computed sections accepted by ParseIncludes
+const (
+	IncludeConstraints = "constraints"
+	IncludeCandidates  = "candidates"
+	IncludeKeyboard    = "keyboard"
+)
+
+// ParseIncludes parses a comma-separated list of computed sections to add to a
+// game response. Unknown section names are rejected.
+func ParseIncludes(value string) (map[string]bool, error) {
+	includes := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case IncludeConstraints, IncludeCandidates, IncludeKeyboard:
+			includes[name] = true
+		default:
+			return nil, fmt.Errorf("invalid include %q (expected %s, %s or %s)", name, IncludeConstraints, IncludeCandidates, IncludeKeyboard)
+		}
+	}
+	return includes, nil
+}
+
+// AddAnalysis fills in the requested computed sections of response from its
+// game and guesses, so clients can fetch a game and its analysis in one call
+func (s *GameService) AddAnalysis(response *GameResponse, includes map[string]bool) {
+	if !includes[IncludeConstraints] && !includes[IncludeCandidates] && !includes[IncludeKeyboard] {
+		return
+	}
+
+	constraints := DeriveConstraints(response.Guesses)
+	if includes[IncludeConstraints] {
+		response.Constraints = &constraints
+	}
+	if includes[IncludeCandidates] {
+		response.Candidates = s.candidates(response.Game, constraints)
+	}
+	if includes[IncludeKeyboard] {
+		keyboard := BuildKeyboardState(response.Guesses)
+		response.Keyboard = &keyboard
+	}
+}
+
+// candidates lists the target words of the game's length that constraints allow
+func (s *GameService) candidates(game Game, constraints BoardConstraints) *CandidateList {
+	words := s.remainingCandidates(game.WordLength(), constraints)
+	list := &CandidateList{Count: len(words), Words: []string{}}
+	if limit := s.clampLimit(s.config.MaxPageSize); len(words) > limit {
+		words = words[:limit]
+	}
+	list.Words = append(list.Words, words...)
+	return list
+}
+
+// remainingCandidates returns every target word of the given length that
+// constraints allow, upper-cased
+func (s *GameService) remainingCandidates(length int, constraints BoardConstraints) []string {
+	var words []string
+	for _, word := range s.wordList.TargetWordsOfLength(length) {
+		if word = s.upper(word); constraints.Allows(word) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// GuessOptions controls how MakeGuessWithOptions builds its response
+type GuessOptions struct {
+	// Delta returns only the newly created guess instead of the full history
+	Delta bool
+	// Annotate adds a status code and spelled-out description to every tile
+	Annotate bool
+	// SkipDictionary evaluates and stores guesses that are not in the word list,
+	// as long as they are letters of the right length. Callers must only set it
+	// for authorized admin/QA requests.
+	SkipDictionary bool
+	// CountRemaining adds how many dictionary words are still consistent with
+	// the board. It scans the whole validation list, so it is opt-in.
+	CountRemaining bool
+}
+
+// MakeGuess processes a guess for a game
+func (s *GameService) MakeGuess(gameID, guessWord string) (*GameResponse, error) {
+	return s.MakeGuessWithOptions(gameID, guessWord, GuessOptions{})
+}
+
+// MakeGuessWithOptions processes a guess for a game using the given response options
+func (s *GameService) MakeGuessWithOptions(gameID, guessWord string, opts GuessOptions) (*GameResponse, error) {
+	// Shed load rather than queue when the configured number of guesses is in flight
+	if s.guessSlots != nil {
+		select {
+		case s.guessSlots <- struct{}{}:
+			defer func() { <-s.guessSlots }()
+		default:
+			return nil, fmt.Errorf("server busy: too many concurrent guesses")
+		}
+	}
+
+	guessWord, err := s.validateGuess(s.gameRepo, s.guessRepo, gameID, guessWord, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The guess insert and game update commit together, so a failed guess write
+	// never leaves the game's state ahead of its stored guesses
+	var game *Game
+	var guess *Guess
+	err = s.inTransaction(func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error {
+		var err error
+		game, guess, err = s.recordGuess(gameRepo, guessRepo, gameID, guessWord)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.guessResponse(s.guessRepo, game, guess, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(AuditActionGuessCreated, gameID)
+	s.notifyIfCompleted(&response.Game)
+	return response, nil
+}
+
+// checkStalling enforces the optional anti-stalling rules for timed play:
+// repeating an earlier guess, or rearranging its letters. Anagram games are
+// exempt from the rearrangement rule, since every guess there is one.
+func (s *GameService) checkStalling(guessRepo GuessRepositoryInterface, game *Game, guessWord string) error {
+	rejectAnagrams := s.config.RejectAnagramGuesses && game.Scramble == ""
+	if !s.config.RejectRepeatGuesses && !rejectAnagrams {
+		return nil
+	}
+
+	previous, err := guessRepo.GetGuessesByGameID(game.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get guesses: %w", err)
+	}
+
+	// A repeat is also the most trivial rearrangement, so either rule rejects it
+	for _, guess := range previous {
+		if guess.GuessWord == guessWord {
+			return fmt.Errorf("'%s' was already guessed", guessWord)
+		}
+		if rejectAnagrams && isAnagramOf(guessWord, guess.GuessWord) {
+			return fmt.Errorf("'%s' only rearranges earlier guess '%s'", guessWord, guess.GuessWord)
+		}
+	}
+	return nil
+}
+
+// maxGuessInputLength returns the configured raw guess size limit in bytes,
+// falling back to the default when unset
+func (s *GameService) maxGuessInputLength() int {
+	if s.config.MaxGuessInputLength <= 0 {
+		return defaultMaxGuessInputLength
+	}
+	return s.config.MaxGuessInputLength
+}
+
+// maxGuessRuneLength returns the configured guess size limit in letters,
+// falling back to the default when unset
+func (s *GameService) maxGuessRuneLength() int {
+	if s.config.MaxGuessRuneLength <= 0 {
+		return defaultMaxGuessRuneLength
+	}
+	return s.config.MaxGuessRuneLength
+}
+
+// checkGuessInputSize rejects input too many bytes long to be any word. It is
+// cheap enough to run on raw input before anything else touches it.
+func (s *GameService) checkGuessInputSize(input string) error {
+	if maxLength := s.maxGuessInputLength(); len(input) > maxLength {
+		return fmt.Errorf("guess must be at most %d characters", maxLength)
+	}
+	return nil
+}
+
+// ValidateGuessLength checks a normalized guess against the expected word
+// length. Lengths are counted in runes, so multi-byte letters count once.
+// Input too large to be any word is rejected as oversized, first by its size
+// in bytes and then in letters; any other length mismatch is an
+// *ErrWrongLength, the game rule.
+func (s *GameService) ValidateGuessLength(word string, expected int) error {
+	if err := s.checkGuessInputSize(word); err != nil {
+		return err
+	}
+
+	got := utf8.RuneCountInString(word)
+	if maxRunes := s.maxGuessRuneLength(); got > maxRunes {
+		return fmt.Errorf("guess must be at most %d letters", maxRunes)
+	}
+	if got != expected {
+		return &ErrWrongLength{Expected: expected, Got: got}
+	}
+	return nil
+}
+
+// normalizeGuess trims a raw guess and applies the configured handling of
+// whitespace left inside it: stripped, or rejected rather than failing the
+// length or dictionary checks confusingly
+func (s *GameService) normalizeGuess(guessWord string) (string, error) {
+	// Fold the guess like the word list does, but leave case to s.upper
+	trimmed := NormalizeWord(guessWord, NormalizeOptions{CaseSensitive: true})
+	if strings.IndexFunc(trimmed, unicode.IsSpace) < 0 {
+		return trimmed, nil
+	}
+	if s.config.InnerWhitespace == InnerWhitespaceStrip {
+		return strings.Join(strings.Fields(trimmed), ""), nil
+	}
+	return "", fmt.Errorf("guess must be a single word without spaces")
+}
+
+// WordNormalization shows how a word is normalized for matching against the
+// word list, and whether the result is a valid word
+type WordNormalization struct {
+	Word       string `json:"word"`       // As given
+	Normalized string `json:"normalized"` // As stored and looked up in the word list
+	Guess      string `json:"guess"`      // As shown in a guess
+	Length     int    `json:"length"`     // Letters in the normalized word
+	Valid      bool   `json:"valid"`      // In the word list
+}
+
+// NormalizeWord normalizes word the way the word list and guesses do, so
+// deployments can see why a word does or doesn't match
+func (s *GameService) NormalizeWord(word string) (*WordNormalization, error) {
+	if err := s.requireFeature(FeatureNormalize); err != nil {
+		return nil, err
+	}
+	if err := s.checkGuessInputSize(word); err != nil {
+		return nil, err
+	}
+	normalized := NormalizeWord(word, NormalizeOptions{CaseSensitive: s.config.CaseSensitiveWords, Lower: s.lower})
+	if normalized == "" {
+		return nil, fmt.Errorf("word must not be empty")
+	}
+
+	return &WordNormalization{
+		Word:       word,
+		Normalized: normalized,
+		Guess:      s.upper(normalized),
+		Length:     utf8.RuneCountInString(normalized),
+		Valid:      s.wordList.Contains(normalized),
+	}, nil
+}
+
+// makeGuess validates, evaluates and stores a guess using the given
+// repositories, for callers that already hold a transaction
+func (s *GameService) makeGuess(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, gameID, guessWord string, opts GuessOptions) (*GameResponse, error) {
+	guessWord, err := s.validateGuess(gameRepo, guessRepo, gameID, guessWord, opts)
+	if err != nil {
+		return nil, err
+	}
+	game, guess, err := s.recordGuess(gameRepo, guessRepo, gameID, guessWord)
+	if err != nil {
+		return nil, err
+	}
+	return s.guessResponse(guessRepo, game, guess, opts)
+}
+
+// validateGuess checks a raw guess against the game's rules and the
+// dictionary and returns it normalized. It reads the game without locking it,
+// so recordGuess checks again whatever a concurrent guess could have changed.
+func (s *GameService) validateGuess(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, gameID, guessWord string, opts GuessOptions) (string, error) {
+	// Reject pathological input before it is trimmed, case-mapped or reaches the database
+	if err := s.checkGuessInputSize(guessWord); err != nil {
+		return "", err
+	}
+
+	// Get the current game
+	game, err := gameRepo.GetGame(gameID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get game: %w", err)
+	}
+
+	// Check if game is already completed
+	if game.IsCompleted {
+		return "", fmt.Errorf("game is already completed")
+	}
+
+	// Validate guess word against the game's own length, which may differ from
+	// the configured default; anagram games also require the scramble's letters
+	trimmed, err := s.normalizeGuess(guessWord)
+	if err != nil {
+		return "", err
+	}
+	guessWord = s.upper(trimmed)
+	if err := s.ValidateGuessLength(guessWord, game.WordLength()); err != nil {
+		return "", err
+	}
+	if game.Scramble != "" && !isAnagramOf(guessWord, game.Scramble) {
+		return "", fmt.Errorf("guess must be an arrangement of the letters %s", game.Scramble)
+	}
+	if s.config.IsogramMode == IsogramModeStrict && !IsIsogram(guessWord) {
+		return "", fmt.Errorf("guess must be an isogram, with no repeated letters")
+	}
+
+	// Check if word is valid (the word list does its own case folding)
+	if opts.SkipDictionary {
+		if !onlyLetters(guessWord) {
+			return "", fmt.Errorf("'%s' is not a valid word", guessWord)
+		}
+	} else if !s.wordList.Contains(trimmed) {
+		return "", s.invalidWordError(guessWord, game.WordLength())
+	}
+
+	// Check if player has remaining guesses
+	if game.GuessCount >= game.MaxGuesses {
+		return "", fmt.Errorf("no remaining guesses")
+	}
+
+	if err := s.checkStalling(guessRepo, game, guessWord); err != nil {
+		return "", err
+	}
+	return guessWord, nil
+}
+
+// recordGuess stores a validated guess and the game state it leads to. The
+// game is read locked, so concurrent guesses on it are applied one at a time;
+// run it in a transaction for the lock to hold until the update is written.
+func (s *GameService) recordGuess(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, gameID, guessWord string) (*Game, *Guess, error) {
+	game, err := gameRepo.GetGameForUpdate(gameID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	if game.Mode == GameModeDaily {
+		if err := s.requireFeature(FeatureDaily); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// A concurrent guess may have ended the game since it was validated
+	if game.IsCompleted {
+		return nil, nil, fmt.Errorf("game is already completed")
+	}
+	if game.GuessCount >= game.MaxGuesses {
+		return nil, nil, fmt.Errorf("no remaining guesses")
+	}
+
+	// Evaluate the guess
+	result := EvaluateGuessWithCase(guessWord, game.TargetWord, s.upper)
+	guessNumber := game.GuessCount + 1
+
+	// Create the guess record
+	guess, err := guessRepo.CreateGuess(s.ids.NewID(), gameID, guessWord, guessNumber, result)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to save guess: %w", err)
+	}
+
+	// Update game state. A multi-board game is only won once every board is
+	// solved; the keyboard tracks the first board, like the stored result.
+	game.GuessCount = guessNumber
+	game.KeyboardState = game.KeyboardState.Merge(result)
+	won := guessWord == game.TargetWord
+	if len(game.Boards) > 0 {
+		game.Boards = game.Boards.Solve(guessWord, guessNumber)
+		won = game.Boards.AllSolved()
+	}
+	game.SetOutcome(won)
+
+	if game.IsCompleted {
+		now := time.Now()
+		game.CompletedAt = &now
+	}
+
+	// Save updated game
+	err = gameRepo.UpdateGame(game)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update game: %w", err)
+	}
+
+	// A finished game counts toward its player's stats and streak; the
+	// scripted tutorial doesn't
+	if game.IsCompleted && !game.IsTutorial {
+		if err := gameRepo.RecordPlayerResult(game.ID, game.IsWon); err != nil {
+			return nil, nil, err
+		}
+	}
+	return game, guess, nil
+}
+
+// guessResponse builds the response to guess, which brought game to its
+// current state
+func (s *GameService) guessResponse(guessRepo GuessRepositoryInterface, game *Game, guess *Guess, opts GuessOptions) (*GameResponse, error) {
+	// Progress, partial credit and the remaining word count use the full
+	// history; the response carries just the new guess in delta mode
+	history, err := guessRepo.GetGuessesByGameID(game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
+	}
+	guesses := history
+	if opts.Delta {
+		guesses = []Guess{*guess}
+	}
+	guesses = s.withBoardResults(game.Boards, guesses)
+
+	if opts.Annotate {
+		annotated := make([]Guess, len(guesses))
+		for i, g := range guesses {
+			annotated[i] = g
+			annotated[i].Result = g.Result.Annotated()
+			annotated[i].BoardResults = annotateBoardResults(g.BoardResults)
+		}
+		guesses = annotated
+	}
+
+	// In partial credit mode a loss is scored by its best row
+	var partialCredit *int
+	if s.config.PartialCredit && game.IsCompleted && !game.IsWon {
+		credit := PartialCredit(history)
+		partialCredit = &credit
+	}
+
+	var remaining *int
+	if opts.CountRemaining {
+		count := s.countRemainingValidWords(game.WordLength(), DeriveConstraints(history))
+		remaining = &count
+	}
+
+	// Prepare response message
+	var message string
+	if game.IsWon {
+		message = fmt.Sprintf("Congratulations! You won in %d guess(es)!", game.GuessCount)
+	} else if game.IsCompleted && len(game.Boards) > 0 {
+		message = fmt.Sprintf("Game over! The words were %s", strings.Join(game.Boards.Targets(), ", "))
+	} else if game.IsCompleted {
+		message = fmt.Sprintf("Game over! The word was '%s'", game.TargetWord)
+		if partialCredit != nil {
+			message += fmt.Sprintf(". Partial credit: %d/%d letters", *partialCredit, utf8.RuneCountInString(game.TargetWord))
+		}
+	} else {
+		remaining := game.MaxGuesses - game.GuessCount
+		message = fmt.Sprintf("Good guess! %d guess(es) remaining", remaining)
+	}
+
+	response := &GameResponse{
+		Game:          *game,
+		Guesses:       guesses,
+		Message:       message,
+		Definition:    s.RevealedDefinition(game),
+		Anagrams:      s.RevealedAnagrams(game),
+		Guidance:      s.tutorialGuidance(game),
+		PartialCredit: partialCredit,
+
+		RemainingValidWords: remaining,
+		HintsRemaining:      s.HintsRemaining(game),
+		AbsentLetters:       game.KeyboardState.AbsentLetters(),
+	}
+	if len(game.Boards) > 0 {
+		// A letter missing from the first board may be on another one
+		response.AbsentLetters = nil
+		response.BoardResults = s.boardResults(game.Boards, *guess)
+		response.BoardKeyboards = s.boardKeyboards(game.Boards, history)
+	}
+	response.SetBestProgress(history)
+	return response, nil
+}
+
+// AddBoards fills in each guess's results against every board of a
+// multi-board game response, and each board's keyboard state. Only the
+// results against the first board are stored, so both are derived from the
+// boards and the guess words; response must carry the full guess history.
+func (s *GameService) AddBoards(response *GameResponse) {
+	if len(response.Game.Boards) == 0 {
+		return
+	}
+	response.Guesses = s.withBoardResults(response.Game.Boards, response.Guesses)
+	response.BoardKeyboards = s.boardKeyboards(response.Game.Boards, response.Guesses)
+}
+
+// withBoardResults returns a copy of guesses, each with its results against
+// every board, or guesses itself for a game without boards
+func (s *GameService) withBoardResults(boards Boards, guesses []Guess) []Guess {
+	if len(boards) == 0 {
+		return guesses
+	}
+	withResults := make([]Guess, len(guesses))
+	for i, guess := range guesses {
+		withResults[i] = guess
+		withResults[i].BoardResults = s.boardResults(boards, guess)
+	}
+	return withResults
+}
+
+// boardResults evaluates guess against each of a multi-board game's boards,
+// as they stood once it was made
+func (s *GameService) boardResults(boards Boards, guess Guess) []BoardResult {
+	results := make([]BoardResult, len(boards))
+	for i, board := range boards {
+		results[i] = BoardResult{
+			Board:  i,
+			Result: EvaluateGuessWithCase(guess.GuessWord, board.Target, s.upper),
+			Solved: board.SolvedBy(guess.GuessNumber),
+		}
+	}
+	return results
+}
+
+// boardKeyboards builds the keyboard state of each of a multi-board game's
+// boards from its guesses
+func (s *GameService) boardKeyboards(boards Boards, guesses []Guess) []KeyboardState {
+	keyboards := make([]KeyboardState, len(boards))
+	for i, board := range boards {
+		keyboards[i] = KeyboardState{}
+		for _, guess := range guesses {
+			keyboards[i] = keyboards[i].Merge(EvaluateGuessWithCase(guess.GuessWord, board.Target, s.upper))
+		}
+	}
+	return keyboards
+}
+
+// annotateBoardResults returns a copy of results with annotated tiles
+func annotateBoardResults(results []BoardResult) []BoardResult {
+	if results == nil {
+		return nil
+	}
+	annotated := make([]BoardResult, len(results))
+	for i, result := range results {
+		annotated[i] = result
+		annotated[i].Result = result.Result.Annotated()
+	}
+	return annotated
+}
+
+// invalidWordError reports a guess that is not in the dictionary, with the
+// nearest dictionary words of the same length when suggestions are enabled
+func (s *GameService) invalidWordError(guessWord string, length int) error {
+	err := &ErrInvalidWord{Word: guessWord}
+	if !s.config.SuggestWords {
+		return err
+	}
+
+	words := s.wordList.WordsOfLength(length)
+	candidates := make([]string, len(words))
+	for i, word := range words {
+		candidates[i] = s.upper(word)
+	}
+	err.Suggestions = NearestWords(guessWord, candidates, maxSuggestionDistance, maxSuggestions)
+	return err
+}
+
+// countRemainingValidWords counts the dictionary words of the given length that
+// the constraints still allow, i.e. the remaining search space for guesses
+func (s *GameService) countRemainingValidWords(length int, constraints BoardConstraints) int {
+	count := 0
+	for _, word := range s.wordList.WordsOfLength(length) {
+		if constraints.Allows(s.upper(word)) {
+			count++
+		}
+	}
+	return count
+}
+
+// ReconcileGame recomputes a game's denormalized fields (guess count, won and
+// completed flags, completion time, keyboard state and solved boards) from its
+// stored guesses and target words, saving the game if anything had drifted. It
+// reports whether the game was changed.
+func (s *GameService) ReconcileGame(gameID string) (*Game, bool, error) {
+	var game *Game
+	changed := false
+	err := s.inTransaction(func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error {
+		var err error
+		game, err = gameRepo.GetGame(gameID)
+		if err != nil {
+			return fmt.Errorf("failed to get game: %w", err)
+		}
+
+		guesses, err := guessRepo.GetGuessesByGameID(gameID)
+		if err != nil {
+			return fmt.Errorf("failed to get guesses: %w", err)
+		}
+
+		reconciled := *game
+		reconciled.GuessCount = len(guesses)
+		isWon := false
+		for _, guess := range guesses {
+			if guess.GuessWord == game.TargetWord {
+				isWon = true
+			}
+		}
+		// A multi-board game is only won once every board is solved, as in recordGuess
+		if len(game.Boards) > 0 {
+			reconciled.Boards = NewBoards(game.Boards.Targets())
+			for _, guess := range guesses {
+				reconciled.Boards = reconciled.Boards.Solve(guess.GuessWord, guess.GuessNumber)
+			}
+			isWon = reconciled.Boards.AllSolved()
+		}
+		reconciled.SetOutcome(isWon)
+		reconciled.KeyboardState = BuildKeyboardState(guesses)
+
+		switch {
+		case !reconciled.IsCompleted:
+			reconciled.CompletedAt = nil
+		case reconciled.CompletedAt == nil && len(guesses) > 0:
+			// The last guess is the best record of when the game ended
+			completedAt := guesses[len(guesses)-1].CreatedAt
+			reconciled.CompletedAt = &completedAt
+		}
+
+		changed = reconciled.GuessCount != game.GuessCount ||
+			reconciled.IsWon != game.IsWon ||
+			reconciled.IsCompleted != game.IsCompleted ||
+			(reconciled.CompletedAt == nil) != (game.CompletedAt == nil) ||
+			!reconciled.KeyboardState.Equal(game.KeyboardState) ||
+			!reconciled.Boards.Equal(game.Boards)
+		if !changed {
+			return nil
+		}
+
+		if err := gameRepo.UpdateGame(&reconciled); err != nil {
+			return fmt.Errorf("failed to update game: %w", err)
+		}
+		game = &reconciled
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if changed {
+		s.audit(AuditActionGameUpdated, gameID)
+	}
+	return game, changed, nil
+}
+
+// maxPageSize returns the configured maximum list size
+func (s *GameService) maxPageSize() int {
+	if s.config.MaxPageSize <= 0 {
+		return defaultMaxPageSize
+	}
+	return s.config.MaxPageSize
+}
+
+// clampLimit bounds a requested list size to the configured page size.
+// Unspecified (non-positive) limits use the default page size and larger
+// limits are capped at the maximum, so no list is ever unbounded.
+func (s *GameService) clampLimit(requested int) int {
+	maxSize := s.maxPageSize()
+	defaultSize := s.config.DefaultPageSize
+	if defaultSize <= 0 || defaultSize > maxSize {
+		defaultSize = min(defaultPageSize, maxSize)
+	}
+
+	if requested <= 0 {
+		return defaultSize
+	}
+	if requested > maxSize {
+		return maxSize
+	}
+	return requested
+}
+
+// GetConstraints returns what the game's guesses so far reveal about the target word
+func (s *GameService) GetConstraints(gameID string) (*BoardConstraints, error) {
+	if _, err := s.gameRepo.GetGame(gameID); err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
+	}
+
+	constraints := DeriveConstraints(guesses)
+	return &constraints, nil
 }
 
-// NewGameService creates a new game service
-func NewGameService(db *DB, wordList *WordList, config *GameConfig) *GameService {
-	return &GameService{
-		gameRepo:  NewGameRepository(db),
-		guessRepo: NewGuessRepository(db),
-		wordList:  wordList,
-		config:    config,
+// GetBoard returns the per-position board summary of the game's guesses so far
+func (s *GameService) GetBoard(gameID string) ([]BoardPosition, error) {
+	game, err := s.gameRepo.GetGame(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
 	}
+
+	return BuildBoard(guesses, game.WordLength()), nil
 }
 
-// NewGameServiceWithInterfaces creates a new game service with injectable interfaces
-func NewGameServiceWithInterfaces(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, wordList WordListInterface, config *GameConfig) *GameService {
-	return &GameService{
-		gameRepo:  gameRepo,
-		guessRepo: guessRepo,
-		wordList:  wordList,
-		config:    config,
+// Evaluate scores guess against target without touching any game, exposing the
+// evaluation algorithm as a stateless utility. Both words must be non-empty,
+// contain only letters and have the same length.
+func (s *GameService) Evaluate(guess, target string) (GuessResult, error) {
+	guess = s.upper(strings.TrimSpace(guess))
+	target = s.upper(strings.TrimSpace(target))
+	if guess == "" || target == "" {
+		return nil, fmt.Errorf("guess and target are required")
+	}
+	for _, word := range []string{guess, target} {
+		if !onlyLetters(word) {
+			return nil, fmt.Errorf("%q must contain only letters", word)
+		}
 	}
+	if utf8.RuneCountInString(guess) != utf8.RuneCountInString(target) {
+		return nil, fmt.Errorf("guess and target must be the same length")
+	}
+
+	return EvaluateGuessWithCase(guess, target, s.upper), nil
 }
 
-// CreateNewGame creates a new game with a random target word from the common words list
-func (s *GameService) CreateNewGame() (*Game, error) {
-	// Get a random five-letter word from the target words (common words)
-	// TODO: this could be in the database but for now it's loaded from a file
-	// TODO: random word should not repeat for user
-	fiveLetterTargetWords := s.wordList.FiveLetterTargetWords()
-	if len(fiveLetterTargetWords) == 0 {
-		return nil, fmt.Errorf("no five-letter target words available")
+// WordNeighbors returns the dictionary words that differ from word in exactly
+// one position, for word ladder helpers. Matching is case-insensitive and the
+// word itself is never included.
+func (s *GameService) WordNeighbors(word string) ([]string, error) {
+	word = s.upper(strings.TrimSpace(word))
+	if word == "" {
+		return nil, fmt.Errorf("word is required")
+	}
+	if !onlyLetters(word) {
+		return nil, fmt.Errorf("%q must contain only letters", word)
 	}
 
-	targetWord := strings.ToUpper(s.wordList.RandomWord())
-	maxGuesses := s.config.MaxGuesses
+	target := []rune(word)
+	neighbors := []string{}
+	for _, candidate := range s.wordList.WordsOfLength(len(target)) {
+		candidate = s.upper(candidate)
+		if differsInOnePosition(target, []rune(candidate)) {
+			neighbors = append(neighbors, candidate)
+		}
+	}
+	sort.Strings(neighbors)
+	return neighbors, nil
+}
 
-	game, err := s.gameRepo.CreateGame(targetWord, maxGuesses)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create game: %w", err)
+// differsInOnePosition reports whether a and b have the same length and
+// differ in exactly one position
+func differsInOnePosition(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	differences := 0
+	for i := range a {
+		if a[i] != b[i] {
+			differences++
+			if differences > 1 {
+				return false
+			}
+		}
 	}
+	return differences == 1
+}
 
-	return game, nil
+// onlyLetters reports whether every rune of word is a letter
+func onlyLetters(word string) bool {
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
 }
 
-// GetGame retrieves a game by ID
-func (s *GameService) GetGame(gameID string) (*Game, error) {
-	return s.gameRepo.GetGame(gameID)
+// ImportTargetWords streams newline-delimited words from r into the target
+// list, accepting only words of the configured length that are in the
+// validation list. With persist the added words are also written to the
+// target word file.
+func (s *GameService) ImportTargetWords(r io.Reader, persist bool) (*ImportStats, error) {
+	return s.wordList.ImportTargetWords(r, s.config.WordLength, persist)
 }
 
-// GetGameWithGuesses retrieves a game with all its guesses
-func (s *GameService) GetGameWithGuesses(gameID string) (*GameWithGuesses, error) {
-	return s.gameRepo.GetGameWithGuesses(gameID)
+// GetShareText renders the share text for a finished game. With includeStats,
+// the associated player's streak and the game's score are added; anonymous
+// games get the plain share text.
+func (s *GameService) GetShareText(gameID string, includeStats bool) (string, error) {
+	game, err := s.gameRepo.GetGame(gameID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get game: %w", err)
+	}
+	if !game.IsCompleted {
+		return "", fmt.Errorf("game is not completed yet")
+	}
+
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get guesses: %w", err)
+	}
+
+	options := ShareOptions{IncludeStats: includeStats}
+	if includeStats {
+		player, err := s.gameRepo.GetPlayerForGame(gameID)
+		if err != nil && !strings.Contains(err.Error(), "no player") {
+			return "", fmt.Errorf("failed to get player: %w", err)
+		}
+		options.Player = player
+	}
+
+	return ShareText(*game, guesses, options), nil
 }
 
-// MakeGuess processes a guess for a game
-func (s *GameService) MakeGuess(gameID, guessWord string) (*GameResponse, error) {
-	// Get the current game
+// GetResultsCSV returns a completed game's guess results as CSV, one row per
+// guess in order with each position's status
+func (s *GameService) GetResultsCSV(gameID string) ([]byte, error) {
 	game, err := s.gameRepo.GetGame(gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
+	if !game.IsCompleted {
+		return nil, fmt.Errorf("game is not completed yet")
+	}
 
-	// Check if game is already completed
-	if game.IsCompleted {
-		return nil, fmt.Errorf("game is already completed")
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
 	}
+	sort.SliceStable(guesses, func(i, j int) bool {
+		return guesses[i].GuessNumber < guesses[j].GuessNumber
+	})
 
-	// Validate guess word
-	guessWord = strings.ToUpper(strings.TrimSpace(guessWord))
-	if len(guessWord) != s.config.WordLength {
-		return nil, fmt.Errorf("guess must be %d letters long", s.config.WordLength)
+	return GuessResultsCSV(guesses, utf8.RuneCountInString(game.TargetWord))
+}
+
+// GetTimeline returns the game's creation, guesses and completion as one
+// chronologically ordered list of events
+func (s *GameService) GetTimeline(gameID string) ([]TimelineEvent, error) {
+	game, err := s.gameRepo.GetGame(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
 
-	// Check if word is valid
-	if !s.wordList.Contains(guessWord) {
-		return nil, fmt.Errorf("'%s' is not a valid word", guessWord)
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
 	}
 
-	// Check if player has remaining guesses
-	if game.GuessCount >= game.MaxGuesses {
-		return nil, fmt.Errorf("no remaining guesses")
+	return BuildTimeline(game, guesses), nil
+}
+
+// IsStillPossible reports whether candidateWord is consistent with all feedback
+// from the game's guesses so far, i.e. whether it could still be the answer
+func (s *GameService) IsStillPossible(gameID, candidateWord string) (bool, error) {
+	if err := s.requireFeature(FeatureSolver); err != nil {
+		return false, err
+	}
+	candidate := s.upper(strings.TrimSpace(candidateWord))
+	if candidate == "" {
+		return false, fmt.Errorf("word is required")
 	}
 
-	// Evaluate the guess
-	result := EvaluateGuess(guessWord, game.TargetWord)
-	guessNumber := game.GuessCount + 1
+	game, err := s.gameRepo.GetGame(gameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get game: %w", err)
+	}
+	if utf8.RuneCountInString(candidate) != game.WordLength() {
+		return false, fmt.Errorf("word must be %d letters long", game.WordLength())
+	}
 
-	// Create the guess record
-	_, err = s.guessRepo.CreateGuess(gameID, guessWord, guessNumber, result)
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to save guess: %w", err)
+		return false, fmt.Errorf("failed to get guesses: %w", err)
 	}
 
-	// Update game state
-	game.GuessCount = guessNumber
-	isWin := guessWord == game.TargetWord
-	game.IsWon = isWin
-	game.IsCompleted = isWin || game.GuessCount >= game.MaxGuesses
+	return DeriveConstraints(guesses).Allows(candidate), nil
+}
 
+// SolutionPath returns a near-optimal sequence of guesses from the game's
+// current board to its answer, for coaching stuck players. The path reveals
+// the answer, so callers must gate access to it.
+func (s *GameService) SolutionPath(gameID string) (*SolutionPath, error) {
+	if err := s.requireFeature(FeatureSolver); err != nil {
+		return nil, err
+	}
+	game, err := s.gameRepo.GetGame(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
 	if game.IsCompleted {
-		now := time.Now()
-		game.CompletedAt = &now
+		return nil, fmt.Errorf("game is already completed")
 	}
 
-	// Save updated game
-	err = s.gameRepo.UpdateGame(game)
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
+	}
+
+	candidates := s.remainingCandidates(game.WordLength(), DeriveConstraints(guesses))
+
+	// Custom and tutorial targets need not be in the target list; SolvePath
+	// adds the target to the candidates when missing
+	depth := min(maxSolutionDepth, game.MaxGuesses-game.GuessCount)
+	path := SolvePath(s.upper(game.TargetWord), candidates, depth)
+	return &path, nil
+}
+
+// RankGuesses ranks the allowed guesses for a game by how many of the
+// remaining candidate answers they are expected to leave, returning the best
+// topN (the default page size when not positive). The ranking only uses the
+// board, not the target word.
+func (s *GameService) RankGuesses(gameID string, topN int) (*GuessRanking, error) {
+	if err := s.requireFeature(FeatureSolver); err != nil {
+		return nil, err
+	}
+	game, err := s.gameRepo.GetGame(gameID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update game: %w", err)
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+	if game.IsCompleted {
+		return nil, fmt.Errorf("game is already completed")
 	}
 
-	// Get all guesses for response
 	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get guesses: %w", err)
 	}
 
-	// Prepare response message
-	var message string
-	if game.IsWon {
-		message = fmt.Sprintf("Congratulations! You won in %d guess(es)!", game.GuessCount)
-	} else if game.IsCompleted {
-		message = fmt.Sprintf("Game over! The word was '%s'", game.TargetWord)
-	} else {
-		remaining := game.MaxGuesses - game.GuessCount
-		message = fmt.Sprintf("Good guess! %d guess(es) remaining", remaining)
+	candidates := s.remainingCandidates(game.WordLength(), DeriveConstraints(guesses))
+	allowed := s.wordList.WordsOfLength(game.WordLength())
+	for i, word := range allowed {
+		allowed[i] = s.upper(word)
 	}
-
-	return &GameResponse{
-		Game:    *game,
-		Guesses: guesses,
-		Message: message,
+	return &GuessRanking{
+		Guesses:    RankByExpectedRemaining(allowed, candidates, s.clampLimit(topN)),
+		Candidates: len(candidates),
 	}, nil
 }
 
+// LetterProbabilities returns, for each position, the likeliest letters among
+// the target words still consistent with the game's board, and how many such
+// candidates there are
+func (s *GameService) LetterProbabilities(gameID string) ([]PositionProbabilities, int, error) {
+	if err := s.requireFeature(FeatureSolver); err != nil {
+		return nil, 0, err
+	}
+	game, err := s.gameRepo.GetGame(gameID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	guesses, err := s.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get guesses: %w", err)
+	}
+
+	candidates := s.remainingCandidates(game.WordLength(), DeriveConstraints(guesses))
+	return LetterProbabilities(candidates, game.WordLength(), maxLettersPerPosition), len(candidates), nil
+}
+
+// GetNudge returns a hint naming the first position where the latest guess has
+// the wrong letter, without revealing which letter belongs there
+func (s *GameService) GetNudge(gameID string) (*Nudge, error) {
+	game, err := s.gameRepo.GetGame(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game: %w", err)
+	}
+
+	if game.IsCompleted {
+		return nil, fmt.Errorf("game is already completed")
+	}
+
+	if game.GuessCount == 0 {
+		return nil, fmt.Errorf("no guesses made yet")
+	}
+
+	latest, err := s.guessRepo.GetLatestGuess(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest guess: %w", err)
+	}
+
+	for i, letter := range latest.Result {
+		if letter.Status != "correct" {
+			// Only nudges actually given count against the game's hint limit
+			used, err := s.gameRepo.UseHint(gameID, s.config.MaxHintsPerGame)
+			if err != nil {
+				return nil, err
+			}
+			game.HintsUsed = used
+			s.audit(AuditActionGameUpdated, gameID)
+
+			return &Nudge{
+				Position:       i + 1,
+				Letter:         letter.Letter,
+				Message:        fmt.Sprintf("Position %d is not %s", i+1, letter.Letter),
+				HintsRemaining: s.HintsRemaining(game),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no incorrect positions in the latest guess")
+}
+
+// HintsRemaining returns how many more nudges the game allows, or nil when
+// its hints are unlimited
+func (s *GameService) HintsRemaining(game *Game) *int {
+	limit := s.config.MaxHintsPerGame
+	if game.MaxHints != nil {
+		limit = *game.MaxHints
+	}
+	if limit <= 0 {
+		return nil
+	}
+	remaining := max(limit-game.HintsUsed, 0)
+	return &remaining
+}
+
+// SetMaxHints overrides MaxHintsPerGame for one game; 0 makes its hints
+// unlimited
+func (s *GameService) SetMaxHints(game *Game, maxHints int) error {
+	if maxHints < 0 {
+		return fmt.Errorf("max_hints must be at least 0")
+	}
+	if err := s.gameRepo.SetMaxHints(game.ID, maxHints); err != nil {
+		return fmt.Errorf("failed to set max hints: %w", err)
+	}
+	game.MaxHints = &maxHints
+	s.audit(AuditActionGameUpdated, game.ID)
+	return nil
+}
+
 // GetRecentGames gets recent games
 func (s *GameService) GetRecentGames(limit int) ([]Game, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 10 // Default limit
+	return s.gameRepo.GetRecentGames(s.clampLimit(limit))
+}
+
+// GetRecentGamesByMode gets recent games started in the given mode; an empty
+// mode lists games of every mode
+func (s *GameService) GetRecentGamesByMode(mode string, limit int) ([]Game, error) {
+	if mode == "" {
+		return s.GetRecentGames(limit)
+	}
+	if err := ValidateGameMode(mode); err != nil {
+		return nil, err
+	}
+	return s.gameRepo.GetRecentGamesByMode(mode, s.clampLimit(limit))
+}
+
+// GetRecentGamesByOutcome gets up to limit recent games, in one query, grouped
+// into won, lost and in-progress buckets, with how many games were grouped
+func (s *GameService) GetRecentGamesByOutcome(limit int) (map[string]*OutcomeBucket, int, error) {
+	games, err := s.GetRecentGames(limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return GroupByOutcome(games), len(games), nil
+}
+
+// GetRecentGameSummaries gets recent games as public summaries without target words
+func (s *GameService) GetRecentGameSummaries(limit int) ([]GameSummary, error) {
+	games, err := s.GetRecentGames(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]GameSummary, len(games))
+	for i := range games {
+		summaries[i] = games[i].Summary()
+	}
+	return summaries, nil
+}
+
+// GetRecentResults gets recently completed games with their share text for a
+// public gallery. Only completed games are included and the share text has no
+// letters, so no answer is revealed.
+func (s *GameService) GetRecentResults(limit int) ([]RecentResult, error) {
+	games, err := s.gameRepo.GetRecentCompletedGames(s.clampLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]RecentResult, len(games))
+	for i := range games {
+		guesses, err := s.guessRepo.GetGuessesByGameID(games[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get guesses: %w", err)
+		}
+		results[i] = RecentResult{
+			GameSummary: games[i].Summary(),
+			Share:       ShareText(games[i], guesses, ShareOptions{}),
+		}
+	}
+	return results, nil
+}
+
+// GetGamesWonInGuesses gets the most recent games won in exactly n guesses,
+// e.g. n = 1 or 2 for highlight reels of fast solves
+func (s *GameService) GetGamesWonInGuesses(n int, limit int) ([]Game, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("guesses must be at least 1")
+	}
+	return s.gameRepo.GetGamesWonInGuesses(n, s.clampLimit(limit))
+}
+
+// GetAtRiskGames lists in-progress games with at most threshold guesses left,
+// for monitoring players on their last attempts. Target words are left out.
+func (s *GameService) GetAtRiskGames(threshold int, limit int) ([]AtRiskGame, error) {
+	if threshold < 1 {
+		return nil, fmt.Errorf("threshold must be at least 1")
+	}
+
+	games, err := s.gameRepo.GetAtRiskGames(threshold, s.clampLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get at-risk games: %w", err)
+	}
+
+	atRisk := make([]AtRiskGame, len(games))
+	for i := range games {
+		atRisk[i] = games[i].AtRisk()
+	}
+	return atRisk, nil
+}
+
+// GetGamesByTargetWord gets completed games that had the given target word.
+// Only completed games are returned so in-progress answers are not revealed.
+func (s *GameService) GetGamesByTargetWord(word string, limit int) ([]Game, error) {
+	if err := s.requireFeature(FeatureByWord); err != nil {
+		return nil, err
+	}
+	word = s.upper(strings.TrimSpace(word))
+	if word == "" {
+		return nil, fmt.Errorf("word is required")
+	}
+	return s.gameRepo.GetCompletedGamesByTargetWord(word, s.clampLimit(limit))
+}
+
+// GetWordGameOutcomes lists up to limit of the most recent games, in progress
+// or not, that had the given target word, with how each went. It reveals
+// answers, so it is for admin use only.
+func (s *GameService) GetWordGameOutcomes(word string, limit int) ([]WordGameOutcome, error) {
+	word = s.upper(strings.TrimSpace(word))
+	if word == "" {
+		return nil, fmt.Errorf("word is required")
+	}
+
+	games, err := s.gameRepo.GetGamesByTargetWord(word, s.clampLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]WordGameOutcome, len(games))
+	for i, game := range games {
+		outcomes[i] = WordGameOutcome{
+			ID:          game.ID,
+			CreatedAt:   game.CreatedAt,
+			CompletedAt: game.CompletedAt,
+			Outcome:     game.Outcome(),
+			GuessCount:  game.GuessCount,
+			MaxGuesses:  game.MaxGuesses,
+		}
+	}
+	return outcomes, nil
+}
+
+// GetReplayableGames gets up to limit of the player's most recently completed
+// games that may be reset and replayed (see Game.IsReplayable)
+func (s *GameService) GetReplayableGames(playerID string, limit int) ([]Game, error) {
+	games, err := s.gameRepo.GetCompletedGamesForPlayer(playerID, s.clampLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	replayable := []Game{}
+	for _, game := range games {
+		if game.IsReplayable() {
+			replayable = append(replayable, game)
+		}
+	}
+	return replayable, nil
+}
+
+// GetPlayerBestGame returns the player's won game with the fewest guesses, with its guesses
+func (s *GameService) GetPlayerBestGame(playerID string) (*GameWithGuesses, error) {
+	game, err := s.gameRepo.GetBestWonGameForPlayer(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	guesses, err := s.guessRepo.GetGuessesByGameID(game.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guesses: %w", err)
 	}
-	return s.gameRepo.GetRecentGames(limit)
+
+	return &GameWithGuesses{
+		Game:    *game,
+		Guesses: guesses,
+	}, nil
 }
 
 // DeleteGame deletes a game
 func (s *GameService) DeleteGame(gameID string) error {
-	return s.gameRepo.DeleteGame(gameID)
+	if err := s.gameRepo.DeleteGame(gameID); err != nil {
+		return err
+	}
+	s.audit(AuditActionGameDeleted, gameID)
+	return nil
 }
 
-// ValidateWord checks if a word is valid for Wordle
+// ValidateWord checks if a word is valid for a new game of the configured
+// default length. Use ValidateWordForGame for guesses in an existing game.
 func (s *GameService) ValidateWord(word string) bool {
+	return s.validateWordOfLength(word, s.config.WordLength)
+}
+
+// ValidateWordForGame checks if a word is a valid guess for the given game,
+// whose length may differ from the configured default
+func (s *GameService) ValidateWordForGame(game *Game, word string) bool {
+	return s.validateWordOfLength(word, game.WordLength())
+}
+
+func (s *GameService) validateWordOfLength(word string, length int) bool {
 	word = strings.TrimSpace(word)
-	if len(word) != s.config.WordLength {
+	if utf8.RuneCountInString(word) != length {
 		return false
 	}
 	return s.wordList.Contains(word)
 }
 
+// WordListSizes returns the number of validation and target words loaded
+func (s *GameService) WordListSizes() (valid, target int) {
+	return s.wordList.Size(), s.wordList.TargetWordsSize()
+}
+
 // GetGameStats returns basic statistics about games
 func (s *GameService) GetGameStats() (map[string]interface{}, error) {
 	// This could be expanded with more sophisticated statistics
@@ -180,3 +2460,55 @@ func (s *GameService) GetGameStats() (map[string]interface{}, error) {
 
 	return stats, nil
 }
+
+// GetStatsByDifficulty returns games played and win rate for each difficulty tier
+func (s *GameService) GetStatsByDifficulty() ([]DifficultyTierStats, error) {
+	stats, err := s.gameRepo.GetStatsByDifficulty()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get difficulty stats: %w", err)
+	}
+	return stats, nil
+}
+
+// snapshotTopOpeners is how many of the most played openers a snapshot lists
+const snapshotTopOpeners = 10
+
+// GetStatsSnapshot returns totals, win rate, guess distribution, average
+// guesses and top openers in one document. It takes two grouped queries
+// regardless of how many games have been played.
+func (s *GameService) GetStatsSnapshot() (*StatsSnapshot, error) {
+	tallies, err := s.gameRepo.GetGameTallies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game tallies: %w", err)
+	}
+	openers, err := s.guessRepo.GetTopOpeners(snapshotTopOpeners)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top openers: %w", err)
+	}
+
+	return BuildStatsSnapshot(tallies, openers, s.config.MaxGuesses), nil
+}
+
+// GetDistinctGuessDistribution returns how many completed games tried each
+// number of distinct words. Repeating a word doesn't add to a game's count.
+func (s *GameService) GetDistinctGuessDistribution() (*DistinctGuessDistribution, error) {
+	tallies, err := s.guessRepo.GetDistinctGuessTallies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get distinct guess tallies: %w", err)
+	}
+
+	return BuildDistinctGuessDistribution(tallies, s.config.MaxGuesses), nil
+}
+
+// GetGuessHeatmap returns per-position correct/present/absent counts across all stored guesses
+func (s *GameService) GetGuessHeatmap() ([]PositionTally, error) {
+	if err := s.requireFeature(FeatureHeatmap); err != nil {
+		return nil, err
+	}
+	heatmap, err := s.guessRepo.GetPositionTallies(s.config.WordLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guess heatmap: %w", err)
+	}
+
+	return heatmap, nil
+}
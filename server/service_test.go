@@ -1,35 +1,59 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 )
 
 // Mock implementations for testing
 
 type MockGameRepository struct {
 	games         map[string]*Game
+	playerGames   map[string]string  // game ID -> player ID
+	players       map[string]*Player // player ID -> player
+	difficulties  map[string]float64 // game ID -> word difficulty
+	clientInfo    map[string]ClientInfo
 	nextID        int
 	shouldFailGet bool
 	shouldFailSave bool
+	shouldFailDifficulty bool // Fail only SetWordDifficulty, after the game is created
+	shouldFailUpdate bool     // Fail only UpdateGame, after a guess is written
+	shouldFailAssign bool     // Fail only SetGamePlayer, after the game is created
+	lockedReads      int      // Calls to GetGameForUpdate
 }
 
 func NewMockGameRepository() *MockGameRepository {
 	return &MockGameRepository{
-		games:  make(map[string]*Game),
-		nextID: 1,
+		games:       make(map[string]*Game),
+		playerGames:  make(map[string]string),
+		players:      make(map[string]*Player),
+		difficulties: make(map[string]float64),
+		clientInfo:   make(map[string]ClientInfo),
+		nextID:       1,
 	}
 }
 
-func (m *MockGameRepository) CreateGame(targetWord string, maxGuesses int) (*Game, error) {
+func (m *MockGameRepository) CreateGame(id, targetWord string, maxGuesses int) (*Game, error) {
 	if m.shouldFailSave {
 		return nil, errors.New("mock save error")
 	}
 
-	id := string(rune(m.nextID + 64)) // Convert to letter (A, B, C, etc.)
-	m.nextID++
+	// Tests creating games directly can leave the ID to the mock
+	if id == "" {
+		id = string(rune(m.nextID + 64)) // Convert to letter (A, B, C, etc.)
+		m.nextID++
+	}
 
 	game := &Game{
 		ID:          id,
@@ -39,12 +63,26 @@ func (m *MockGameRepository) CreateGame(targetWord string, maxGuesses int) (*Gam
 		IsWon:       false,
 		GuessCount:  0,
 		MaxGuesses:  maxGuesses,
+		Mode:        GameModePractice,
 	}
 
 	m.games[id] = game
 	return game, nil
 }
 
+func (m *MockGameRepository) CreateGames(ids, targetWords []string, maxGuesses int) error {
+	if m.shouldFailSave {
+		return errors.New("mock save error")
+	}
+
+	for i, word := range targetWords {
+		if _, err := m.CreateGame(ids[i], word, maxGuesses); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MockGameRepository) GetGame(gameID string) (*Game, error) {
 	if m.shouldFailGet {
 		return nil, errors.New("mock get error")
@@ -60,8 +98,13 @@ func (m *MockGameRepository) GetGame(gameID string) (*Game, error) {
 	return &gameCopy, nil
 }
 
+func (m *MockGameRepository) GetGameForUpdate(gameID string) (*Game, error) {
+	m.lockedReads++
+	return m.GetGame(gameID)
+}
+
 func (m *MockGameRepository) UpdateGame(game *Game) error {
-	if m.shouldFailSave {
+	if m.shouldFailSave || m.shouldFailUpdate {
 		return errors.New("mock update error")
 	}
 
@@ -114,504 +157,4668 @@ func (m *MockGameRepository) GetRecentGames(limit int) ([]Game, error) {
 	return games, nil
 }
 
-type MockGuessRepository struct {
-	guesses         map[string][]Guess
-	shouldFailSave  bool
-	shouldFailGet   bool
-	nextGuessID     int
-}
+func (m *MockGameRepository) GetRecentGamesByMode(mode string, limit int) ([]Game, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
+	}
 
-func NewMockGuessRepository() *MockGuessRepository {
-	return &MockGuessRepository{
-		guesses:     make(map[string][]Guess),
-		nextGuessID: 1,
+	var games []Game
+	for _, game := range m.games {
+		if game.Mode == mode {
+			games = append(games, *game)
+			if len(games) >= limit {
+				break
+			}
+		}
 	}
+	return games, nil
 }
 
-func (m *MockGuessRepository) CreateGuess(gameID, guessWord string, guessNumber int, result GuessResult) (*Guess, error) {
-	if m.shouldFailSave {
-		return nil, errors.New("mock save guess error")
+func (m *MockGameRepository) GetRecentCompletedGames(limit int) ([]Game, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
 	}
 
-	// Check for duplicate guess numbers
-	if guesses, exists := m.guesses[gameID]; exists {
-		for _, guess := range guesses {
-			if guess.GuessNumber == guessNumber {
-				return nil, errors.New("guess number already exists")
+	var games []Game
+	for _, game := range m.games {
+		if game.IsCompleted {
+			games = append(games, *game)
+			if len(games) >= limit {
+				break
 			}
 		}
 	}
+	return games, nil
+}
 
-	guess := &Guess{
-		ID:          string(rune(m.nextGuessID + 64)),
-		GameID:      gameID,
-		GuessWord:   guessWord,
-		GuessNumber: guessNumber,
-		Result:      result,
-		CreatedAt:   time.Now(),
+func (m *MockGameRepository) GetCompletedGamesByTargetWord(targetWord string, limit int) ([]Game, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
 	}
-	m.nextGuessID++
 
-	if m.guesses[gameID] == nil {
-		m.guesses[gameID] = []Guess{}
+	var games []Game
+	for _, game := range m.games {
+		if game.TargetWord == targetWord && game.IsCompleted {
+			games = append(games, *game)
+			if len(games) >= limit {
+				break
+			}
+		}
 	}
-	m.guesses[gameID] = append(m.guesses[gameID], *guess)
-
-	return guess, nil
+	return games, nil
 }
 
-func (m *MockGuessRepository) GetGuessesByGameID(gameID string) ([]Guess, error) {
+func (m *MockGameRepository) GetGamesWonInGuesses(guesses int, limit int) ([]Game, error) {
 	if m.shouldFailGet {
-		return nil, errors.New("mock get guesses error")
-	}
-
-	guesses, exists := m.guesses[gameID]
-	if !exists {
-		return []Guess{}, nil
+		return nil, errors.New("mock get error")
 	}
 
-	// Sort by guess number
-	sortedGuesses := make([]Guess, len(guesses))
-	copy(sortedGuesses, guesses)
-	
-	// Simple bubble sort for testing
-	for i := 0; i < len(sortedGuesses)-1; i++ {
-		for j := 0; j < len(sortedGuesses)-i-1; j++ {
-			if sortedGuesses[j].GuessNumber > sortedGuesses[j+1].GuessNumber {
-				sortedGuesses[j], sortedGuesses[j+1] = sortedGuesses[j+1], sortedGuesses[j]
+	var games []Game
+	for _, game := range m.games {
+		if game.IsWon && game.GuessCount == guesses {
+			games = append(games, *game)
+			if len(games) >= limit {
+				break
 			}
 		}
 	}
-
-	return sortedGuesses, nil
+	return games, nil
 }
 
-func (m *MockGuessRepository) GetGuess(guessID string) (*Guess, error) {
+func (m *MockGameRepository) GetAtRiskGames(maxRemaining int, limit int) ([]Game, error) {
 	if m.shouldFailGet {
-		return nil, errors.New("mock get guess error")
+		return nil, errors.New("mock get error")
 	}
 
-	// Simple implementation for testing
-	for _, guesses := range m.guesses {
-		for _, guess := range guesses {
-			if guess.ID == guessID {
-				guessCopy := guess
-				return &guessCopy, nil
-			}
+	var games []Game
+	for _, game := range m.games {
+		if !game.IsCompleted && game.MaxGuesses-game.GuessCount <= maxRemaining {
+			games = append(games, *game)
 		}
 	}
-	return nil, errors.New("guess not found")
+	sort.Slice(games, func(i, j int) bool {
+		remaining := func(g Game) int { return g.MaxGuesses - g.GuessCount }
+		if remaining(games[i]) != remaining(games[j]) {
+			return remaining(games[i]) < remaining(games[j])
+		}
+		return games[i].CreatedAt.After(games[j].CreatedAt)
+	})
+	if len(games) > limit {
+		games = games[:limit]
+	}
+	return games, nil
 }
 
-func (m *MockGuessRepository) DeleteGuess(guessID string) error {
-	if m.shouldFailSave {
-		return errors.New("mock delete guess error")
+func (m *MockGameRepository) GetGamesByTargetWord(targetWord string, limit int) ([]Game, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
 	}
 
-	// Find and remove the guess
-	for gameID, guesses := range m.guesses {
-		for i, guess := range guesses {
-			if guess.ID == guessID {
-				// Remove from slice
-				m.guesses[gameID] = append(guesses[:i], guesses[i+1:]...)
-				return nil
-			}
+	var games []Game
+	for _, game := range m.games {
+		if game.TargetWord == targetWord {
+			games = append(games, *game)
 		}
 	}
-	return errors.New("guess not found")
+	sort.Slice(games, func(i, j int) bool { return games[i].CreatedAt.After(games[j].CreatedAt) })
+	if len(games) > limit {
+		games = games[:limit]
+	}
+	return games, nil
 }
 
-func (m *MockGuessRepository) GetLatestGuess(gameID string) (*Guess, error) {
+func (m *MockGameRepository) GetBestWonGameForPlayer(playerID string) (*Game, error) {
 	if m.shouldFailGet {
-		return nil, errors.New("mock get latest guess error")
-	}
-
-	guesses, exists := m.guesses[gameID]
-	if !exists || len(guesses) == 0 {
-		return nil, errors.New("no guesses found")
+		return nil, errors.New("mock get error")
 	}
 
-	// Find the guess with the highest guess number
-	var latest *Guess
-	for _, guess := range guesses {
-		if latest == nil || guess.GuessNumber > latest.GuessNumber {
-			guessCopy := guess
-			latest = &guessCopy
+	var best *Game
+	for id, game := range m.games {
+		if m.playerGames[id] != playerID || !game.IsWon {
+			continue
+		}
+		if best == nil || game.GuessCount < best.GuessCount ||
+			(game.GuessCount == best.GuessCount && game.CreatedAt.Before(best.CreatedAt)) {
+			best = game
 		}
 	}
+	if best == nil {
+		return nil, errors.New("no won games found for player")
+	}
 
-	return latest, nil
+	gameCopy := *best
+	return &gameCopy, nil
 }
 
-type MockWordList struct {
-	words         []string
-	shouldFailGet bool
-}
+func (m *MockGameRepository) GetCompletedGamesForPlayer(playerID string, limit int) ([]Game, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
+	}
 
-func NewMockWordList() *MockWordList {
-	return &MockWordList{
-		words: []string{"HELLO", "WORLD", "CRANE", "SLATE", "AUDIO", "QUICK", "BROWN"},
+	var games []Game
+	for id, game := range m.games {
+		if m.playerGames[id] == playerID && game.IsCompleted {
+			games = append(games, *game)
+			if len(games) >= limit {
+				break
+			}
+		}
 	}
+	return games, nil
 }
 
-func (m *MockWordList) Contains(word string) bool {
+func (m *MockGameRepository) GetPlayedTargetWords(playerID string) ([]string, error) {
 	if m.shouldFailGet {
-		return false
+		return nil, errors.New("mock get error")
 	}
-	
-	word = strings.ToUpper(word)
-	for _, w := range m.words {
-		if w == word {
-			return true
+
+	seen := make(map[string]bool)
+	var words []string
+	for id, game := range m.games {
+		owned := game.PlayerID != nil && *game.PlayerID == playerID
+		if (owned || m.playerGames[id] == playerID) && !seen[game.TargetWord] {
+			seen[game.TargetWord] = true
+			words = append(words, game.TargetWord)
 		}
 	}
-	return false
+	return words, nil
 }
 
-func (m *MockWordList) RandomWord() string {
-	if len(m.words) == 0 {
-		return ""
+func (m *MockGameRepository) GetPlayerForGame(gameID string) (*Player, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
 	}
-	return m.words[0] // Always return first word for predictable testing
-}
 
-func (m *MockWordList) FiveLetterWords() []string {
-	return m.words
+	player, ok := m.players[m.playerGames[gameID]]
+	if !ok {
+		return nil, errors.New("no player found for game")
+	}
+
+	playerCopy := *player
+	return &playerCopy, nil
 }
 
-func (m *MockWordList) Size() int {
-	return len(m.words)
+func (m *MockGameRepository) SetGamePlayer(gameID, playerID string) error {
+	if m.shouldFailSave || m.shouldFailAssign {
+		return errors.New("mock update error")
+	}
+	if _, exists := m.games[gameID]; !exists {
+		return errors.New("game not found")
+	}
+	m.playerGames[gameID] = playerID
+	return nil
 }
 
-func (m *MockWordList) RandomValidWord() string {
-	if len(m.words) == 0 {
-		return ""
+func (m *MockGameRepository) RecordPlayerResult(gameID string, won bool) error {
+	if m.shouldFailSave {
+		return errors.New("mock update error")
 	}
-	return m.words[1] // Return second word for testing
+	game, exists := m.games[gameID]
+	if !exists {
+		return errors.New("game not found")
+	}
+
+	playerID := m.playerGames[gameID]
+	if game.PlayerID != nil {
+		playerID = *game.PlayerID
+	}
+	player, ok := m.players[playerID]
+	if !ok {
+		return nil
+	}
+	player.GamesPlayed++
+	if won {
+		player.GamesWon++
+		player.CurrentStreak++
+		player.MaxStreak = max(player.MaxStreak, player.CurrentStreak)
+	} else {
+		player.CurrentStreak = 0
+	}
+	return nil
 }
 
-func (m *MockWordList) FiveLetterTargetWords() []string {
-	return m.words // For testing, use same words as target words
+func (m *MockGameRepository) SetClientInfo(gameID string, client ClientInfo) error {
+	if m.shouldFailSave {
+		return errors.New("mock update error")
+	}
+	if _, exists := m.games[gameID]; !exists {
+		return errors.New("game not found")
+	}
+
+	m.clientInfo[gameID] = client
+	return nil
 }
 
-func (m *MockWordList) TargetWordsSize() int {
-	return len(m.words)
+func (m *MockGameRepository) SetWordDifficulty(gameID string, difficulty float64) error {
+	if m.shouldFailSave || m.shouldFailDifficulty {
+		return errors.New("mock update error")
+	}
+	if _, exists := m.games[gameID]; !exists {
+		return errors.New("game not found")
+	}
+
+	m.difficulties[gameID] = difficulty
+	return nil
 }
 
-// Test functions
+func (m *MockGameRepository) GetStatsByDifficulty() ([]DifficultyTierStats, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
+	}
 
-func TestGameServiceCreateNewGame(t *testing.T) {
-	gameRepo := NewMockGameRepository()
-	guessRepo := NewMockGuessRepository()
-	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	byTier := make(map[string]*DifficultyTierStats)
+	for id, difficulty := range m.difficulties {
+		game, ok := m.games[id]
+		if !ok || !game.IsCompleted {
+			continue
+		}
+		tier := DifficultyTier(difficulty)
+		if byTier[tier] == nil {
+			byTier[tier] = &DifficultyTierStats{Tier: tier}
+		}
+		byTier[tier].GamesPlayed++
+		if game.IsWon {
+			byTier[tier].GamesWon++
+		}
+	}
 
-	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	var stats []DifficultyTierStats
+	for _, tier := range []string{DifficultyEasy, DifficultyMedium, DifficultyHard} {
+		if byTier[tier] != nil {
+			byTier[tier].CalculateWinRate()
+			stats = append(stats, *byTier[tier])
+		}
+	}
+	return stats, nil
+}
 
-	game, err := service.CreateNewGame()
-	if err != nil {
-		t.Fatalf("CreateNewGame should not return error: %v", err)
+func (m *MockGameRepository) GetGameTallies() ([]GameTally, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
 	}
 
-	if game.ID == "" {
-		t.Error("Game should have an ID")
+	type key struct {
+		completed, won bool
+		guesses        int
 	}
-	if game.TargetWord != "HELLO" { // First word from mock
-		t.Errorf("Expected target word 'HELLO', got '%s'", game.TargetWord)
+	counts := make(map[key]int)
+	for _, game := range m.games {
+		counts[key{game.IsCompleted, game.IsWon, game.GuessCount}]++
 	}
-	if game.MaxGuesses != 6 {
-		t.Errorf("Expected max guesses 6, got %d", game.MaxGuesses)
+
+	var tallies []GameTally
+	for k, games := range counts {
+		tallies = append(tallies, GameTally{IsCompleted: k.completed, IsWon: k.won, GuessCount: k.guesses, Games: games})
 	}
-	if game.GuessCount != 0 {
-		t.Errorf("New game should have 0 guesses, got %d", game.GuessCount)
+	return tallies, nil
+}
+
+func (m *MockGameRepository) CreateDailyGame(id, targetWord string, maxGuesses int, date time.Time) (*Game, error) {
+	if _, err := m.GetDailyGame(date); err == nil {
+		return nil, errors.New("daily game already exists")
 	}
-	if game.IsCompleted {
-		t.Error("New game should not be completed")
+
+	game, err := m.CreateGame(id, targetWord, maxGuesses)
+	if err != nil {
+		return nil, err
 	}
-	if game.IsWon {
-		t.Error("New game should not be won")
+	day := date.UTC().Truncate(24 * time.Hour)
+	game.DailyDate = &day
+	game.Mode = GameModeDaily
+	return game, nil
+}
+
+func (m *MockGameRepository) SetMaxHints(gameID string, maxHints int) error {
+	game, exists := m.games[gameID]
+	if !exists {
+		return errors.New("game not found")
+	}
+	game.MaxHints = &maxHints
+	return nil
+}
+
+func (m *MockGameRepository) UseHint(gameID string, defaultLimit int) (int, error) {
+	game, exists := m.games[gameID]
+	if !exists {
+		return 0, errors.New("game not found")
+	}
+	limit := defaultLimit
+	if game.MaxHints != nil {
+		limit = *game.MaxHints
+	}
+	if limit > 0 && game.HintsUsed >= limit {
+		return 0, fmt.Errorf("hint limit reached for game %s", gameID)
+	}
+	game.HintsUsed++
+	return game.HintsUsed, nil
+}
+
+func (m *MockGameRepository) CreatePlayerDailyGame(id, playerID, targetWord string, maxGuesses int, date time.Time) (*Game, error) {
+	if _, err := m.GetPlayerDailyGame(playerID, date); err == nil {
+		return nil, fmt.Errorf("daily game already exists for player %s: %s", playerID, dateKey(date))
+	}
+
+	game, err := m.CreateGame(id, targetWord, maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	day := date.UTC().Truncate(24 * time.Hour)
+	game.DailyDate, game.Mode, game.PlayerID = &day, GameModeDaily, &playerID
+	return game, nil
+}
+
+func (m *MockGameRepository) GetPlayerDailyGame(playerID string, date time.Time) (*Game, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
+	}
+
+	for _, game := range m.games {
+		if game.DailyDate != nil && game.PlayerID != nil && *game.PlayerID == playerID && dateKey(*game.DailyDate) == dateKey(date) {
+			gameCopy := *game
+			return &gameCopy, nil
+		}
+	}
+	return nil, fmt.Errorf("daily game not found for player %s: %s", playerID, dateKey(date))
+}
+
+func (m *MockGameRepository) CreateChallengeGame(id, targetWord string, maxGuesses int) (*Game, error) {
+	game, err := m.CreateGame(id, targetWord, maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	game.Mode = GameModeChallenge
+	return game, nil
+}
+
+func (m *MockGameRepository) CreateTutorialGame(id, targetWord string, maxGuesses int) (*Game, error) {
+	game, err := m.CreateGame(id, targetWord, maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	game.IsTutorial = true
+	m.games[game.ID].IsTutorial = true
+	return game, nil
+}
+
+func (m *MockGameRepository) CreateAnagramGame(id, targetWord, scramble string, maxGuesses int) (*Game, error) {
+	game, err := m.CreateGame(id, targetWord, maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	game.Scramble = scramble
+	m.games[game.ID].Scramble = scramble
+	return game, nil
+}
+
+func (m *MockGameRepository) CreateMultiBoardGame(id string, targets []string, maxGuesses int) (*Game, error) {
+	game, err := m.CreateGame(id, targets[0], maxGuesses)
+	if err != nil {
+		return nil, err
+	}
+	game.Boards = NewBoards(targets)
+	return game, nil
+}
+
+func (m *MockGameRepository) GetDailyGame(date time.Time) (*Game, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get error")
+	}
+
+	for _, game := range m.games {
+		if game.DailyDate != nil && game.PlayerID == nil && dateKey(*game.DailyDate) == dateKey(date) {
+			gameCopy := *game
+			return &gameCopy, nil
+		}
+	}
+	return nil, errors.New("daily game not found")
+}
+
+type MockAnswerRepository struct {
+	answers map[string]string // YYYY-MM-DD -> target word
+}
+
+func NewMockAnswerRepository() *MockAnswerRepository {
+	return &MockAnswerRepository{answers: make(map[string]string)}
+}
+
+func (m *MockAnswerRepository) GetAnswer(date time.Time) (string, error) {
+	word, ok := m.answers[dateKey(date)]
+	if !ok {
+		return "", errors.New("no answer scheduled for " + dateKey(date))
+	}
+	return word, nil
+}
+
+func (m *MockAnswerRepository) GetAnswers(from, to time.Time) ([]ScheduledAnswer, error) {
+	var answers []ScheduledAnswer
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if word, ok := m.answers[dateKey(day)]; ok {
+			answers = append(answers, ScheduledAnswer{PuzzleDate: day, TargetWord: word})
+		}
+	}
+	return answers, nil
+}
+
+func (m *MockAnswerRepository) SaveAnswers(answers []ScheduledAnswer) error {
+	for _, answer := range answers {
+		m.answers[dateKey(answer.PuzzleDate)] = answer.TargetWord
+	}
+	return nil
+}
+
+type MockAPIKeyRepository struct {
+	quotas map[string]int // API key -> daily game quota
+	usage  map[string]int // API key and YYYY-MM-DD -> games created
+}
+
+func NewMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{quotas: make(map[string]int), usage: make(map[string]int)}
+}
+
+func (m *MockAPIKeyRepository) UseGameQuota(key string, date time.Time) error {
+	quota, ok := m.quotas[key]
+	if !ok {
+		return errors.New("unknown api key")
+	}
+	day := key + " " + dateKey(date)
+	if m.usage[day] >= quota {
+		return fmt.Errorf("daily game quota of %d exceeded", quota)
+	}
+	m.usage[day]++
+	return nil
+}
+
+func (m *MockAPIKeyRepository) RefundGameQuota(key string, date time.Time) error {
+	if day := key + " " + dateKey(date); m.usage[day] > 0 {
+		m.usage[day]--
+	}
+	return nil
+}
+
+type MockPlayerRepository struct {
+	players map[string]*Player // username -> player
+	created int
+
+	// raceOnce makes the first CreatePlayer find its username just taken, as
+	// if a concurrent request had created the player between get and create
+	raceOnce bool
+}
+
+func NewMockPlayerRepository() *MockPlayerRepository {
+	return &MockPlayerRepository{players: make(map[string]*Player)}
+}
+
+func (m *MockPlayerRepository) GetPlayerByUsername(username string) (*Player, error) {
+	player, ok := m.players[username]
+	if !ok {
+		return nil, fmt.Errorf("player not found: %s", username)
+	}
+	playerCopy := *player
+	return &playerCopy, nil
+}
+
+func (m *MockPlayerRepository) CreatePlayer(id, username string) (*Player, error) {
+	if m.raceOnce {
+		m.raceOnce = false
+		m.players[username] = &Player{ID: "racer", Username: username}
+		return nil, fmt.Errorf("player already exists: %s", username)
+	}
+	if _, exists := m.players[username]; exists {
+		return nil, fmt.Errorf("player already exists: %s", username)
+	}
+	m.created++
+	player := &Player{ID: id, Username: username, CreatedAt: time.Now()}
+	m.players[username] = player
+	playerCopy := *player
+	return &playerCopy, nil
+}
+
+func (m *MockPlayerRepository) GetPlayerRank(playerID, metric string) (*PlayerRank, error) {
+	value := func(player *Player) int {
+		switch metric {
+		case PlayerMetricCurrentStreak:
+			return player.CurrentStreak
+		case PlayerMetricGamesWon:
+			return player.GamesWon
+		case PlayerMetricGamesPlayed:
+			return player.GamesPlayed
+		default:
+			return player.MaxStreak
+		}
+	}
+
+	var target *Player
+	for _, player := range m.players {
+		if player.ID == playerID {
+			target = player
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("player not found: %s", playerID)
+	}
+
+	higher, lower := 0, 0
+	for _, player := range m.players {
+		switch {
+		case value(player) > value(target):
+			higher++
+		case value(player) < value(target):
+			lower++
+		}
+	}
+	return NewPlayerRank(playerID, metric, value(target), higher, lower, len(m.players)), nil
+}
+
+type MockGuessRepository struct {
+	guesses         map[string][]Guess
+	shouldFailSave  bool
+	shouldFailGet   bool
+	nextGuessID     int
+}
+
+func NewMockGuessRepository() *MockGuessRepository {
+	return &MockGuessRepository{
+		guesses:     make(map[string][]Guess),
+		nextGuessID: 1,
+	}
+}
+
+func (m *MockGuessRepository) CreateGuess(id, gameID, guessWord string, guessNumber int, result GuessResult) (*Guess, error) {
+	if m.shouldFailSave {
+		return nil, errors.New("mock save guess error")
+	}
+
+	// Check for duplicate guess numbers
+	if guesses, exists := m.guesses[gameID]; exists {
+		for _, guess := range guesses {
+			if guess.GuessNumber == guessNumber {
+				return nil, errors.New("guess number already exists")
+			}
+		}
+	}
+
+	if id == "" {
+		id = string(rune(m.nextGuessID + 64))
+		m.nextGuessID++
+	}
+
+	guess := &Guess{
+		ID:          id,
+		GameID:      gameID,
+		GuessWord:   guessWord,
+		GuessNumber: guessNumber,
+		Result:      result,
+		CreatedAt:   time.Now(),
+	}
+
+	if m.guesses[gameID] == nil {
+		m.guesses[gameID] = []Guess{}
+	}
+	m.guesses[gameID] = append(m.guesses[gameID], *guess)
+
+	return guess, nil
+}
+
+func (m *MockGuessRepository) GetGuessesByGameID(gameID string) ([]Guess, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get guesses error")
+	}
+
+	guesses, exists := m.guesses[gameID]
+	if !exists {
+		return []Guess{}, nil
+	}
+
+	// Sort by guess number
+	sortedGuesses := make([]Guess, len(guesses))
+	copy(sortedGuesses, guesses)
+	
+	// Simple bubble sort for testing
+	for i := 0; i < len(sortedGuesses)-1; i++ {
+		for j := 0; j < len(sortedGuesses)-i-1; j++ {
+			if sortedGuesses[j].GuessNumber > sortedGuesses[j+1].GuessNumber {
+				sortedGuesses[j], sortedGuesses[j+1] = sortedGuesses[j+1], sortedGuesses[j]
+			}
+		}
+	}
+
+	return sortedGuesses, nil
+}
+
+func (m *MockGuessRepository) GetGuess(guessID string) (*Guess, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get guess error")
+	}
+
+	// Simple implementation for testing
+	for _, guesses := range m.guesses {
+		for _, guess := range guesses {
+			if guess.ID == guessID {
+				guessCopy := guess
+				return &guessCopy, nil
+			}
+		}
+	}
+	return nil, errors.New("guess not found")
+}
+
+func (m *MockGuessRepository) DeleteGuess(guessID string) error {
+	if m.shouldFailSave {
+		return errors.New("mock delete guess error")
+	}
+
+	// Find and remove the guess
+	for gameID, guesses := range m.guesses {
+		for i, guess := range guesses {
+			if guess.ID == guessID {
+				// Remove from slice
+				m.guesses[gameID] = append(guesses[:i], guesses[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return errors.New("guess not found")
+}
+
+func (m *MockGuessRepository) GetLatestGuess(gameID string) (*Guess, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get latest guess error")
+	}
+
+	guesses, exists := m.guesses[gameID]
+	if !exists || len(guesses) == 0 {
+		return nil, errors.New("no guesses found")
+	}
+
+	// Find the guess with the highest guess number
+	var latest *Guess
+	for _, guess := range guesses {
+		if latest == nil || guess.GuessNumber > latest.GuessNumber {
+			guessCopy := guess
+			latest = &guessCopy
+		}
+	}
+
+	return latest, nil
+}
+
+func (m *MockGuessRepository) GetPositionTallies(length int) ([]PositionTally, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get position tallies error")
+	}
+
+	var results []GuessResult
+	for _, guesses := range m.guesses {
+		for _, guess := range guesses {
+			results = append(results, guess.Result)
+		}
+	}
+	return BuildGuessHeatmap(results, length), nil
+}
+
+// GetDistinctGuessTallies counts distinct words per game. The mock has no game
+// state, so every game with guesses counts as completed.
+func (m *MockGuessRepository) GetDistinctGuessTallies() ([]DistinctGuessTally, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get distinct guess tallies error")
+	}
+
+	counts := make(map[int]int)
+	for _, guesses := range m.guesses {
+		words := make(map[string]bool)
+		for _, guess := range guesses {
+			words[guess.GuessWord] = true
+		}
+		if len(words) > 0 {
+			counts[len(words)]++
+		}
+	}
+
+	var tallies []DistinctGuessTally
+	for distinct, games := range counts {
+		tallies = append(tallies, DistinctGuessTally{DistinctGuesses: distinct, Games: games})
+	}
+	sort.Slice(tallies, func(i, j int) bool { return tallies[i].DistinctGuesses < tallies[j].DistinctGuesses })
+	return tallies, nil
+}
+
+func (m *MockGuessRepository) GetTopOpeners(limit int) ([]WordCount, error) {
+	if m.shouldFailGet {
+		return nil, errors.New("mock get top openers error")
+	}
+
+	plays := make(map[string]int)
+	for _, guesses := range m.guesses {
+		for _, guess := range guesses {
+			if guess.GuessNumber == 1 {
+				plays[guess.GuessWord]++
+			}
+		}
+	}
+
+	var openers []WordCount
+	for word, count := range plays {
+		openers = append(openers, WordCount{Word: word, Count: count})
+	}
+	sort.Slice(openers, func(i, j int) bool {
+		if openers[i].Count != openers[j].Count {
+			return openers[i].Count > openers[j].Count
+		}
+		return openers[i].Word < openers[j].Word
+	})
+	if len(openers) > limit {
+		openers = openers[:limit]
+	}
+	return openers, nil
+}
+
+// MockAuditLogger keeps audit records in memory
+type MockAuditLogger struct {
+	records []AuditRecord
+}
+
+func (m *MockAuditLogger) Record(record AuditRecord) {
+	m.records = append(m.records, record)
+}
+
+// actions returns the recorded actions in order
+func (m *MockAuditLogger) actions() []string {
+	actions := make([]string, len(m.records))
+	for i, record := range m.records {
+		actions[i] = record.Action
+	}
+	return actions
+}
+
+type MockWordList struct {
+	words         []string
+	shouldFailGet bool
+}
+
+func NewMockWordList() *MockWordList {
+	return &MockWordList{
+		words: []string{"HELLO", "WORLD", "CRANE", "SLATE", "AUDIO", "QUICK", "BROWN"},
+	}
+}
+
+func (m *MockWordList) Contains(word string) bool {
+	if m.shouldFailGet {
+		return false
+	}
+	
+	word = strings.ToUpper(word)
+	for _, w := range m.words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockWordList) RandomWord() string {
+	if len(m.words) == 0 {
+		return ""
+	}
+	return m.words[0] // Always return first word for predictable testing
+}
+
+// DailyWord cycles through the words by day of the year
+func (m *MockWordList) DailyWord(date time.Time) string {
+	if len(m.words) == 0 {
+		return ""
+	}
+	return m.words[date.UTC().YearDay()%len(m.words)]
+}
+
+func (m *MockWordList) FiveLetterWords() []string {
+	return m.words
+}
+
+func (m *MockWordList) Size() int {
+	return len(m.words)
+}
+
+func (m *MockWordList) RandomValidWord() string {
+	if len(m.words) == 0 {
+		return ""
+	}
+	return m.words[1] // Return second word for testing
+}
+
+func (m *MockWordList) FiveLetterTargetWords() []string {
+	return m.words // For testing, use same words as target words
+}
+
+func (m *MockWordList) WordsOfLength(length int) []string {
+	return m.TargetWordsOfLength(length)
+}
+
+func (m *MockWordList) TargetWordsOfLength(length int) []string {
+	var result []string
+	for _, word := range m.words {
+		if utf8.RuneCountInString(word) == length {
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
+func (m *MockWordList) ImportTargetWords(r io.Reader, wordLength int, persist bool) (*ImportStats, error) {
+	return &ImportStats{}, nil
+}
+
+func (m *MockWordList) TargetWordsSize() int {
+	return len(m.words)
+}
+
+// MockTransactor emulates a transaction over the mock repositories by
+// snapshotting their state and restoring it when the function fails
+type MockTransactor struct {
+	gameRepo   *MockGameRepository
+	guessRepo  *MockGuessRepository
+	playerRepo *MockPlayerRepository // Optional; its players are rolled back too
+	commits    int
+	rollbacks  int
+	open       bool // A transaction is running
+}
+
+func (m *MockTransactor) WithinTransaction(fn func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface) error) error {
+	return m.WithinPlayerTransaction(func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, playerRepo PlayerRepositoryInterface) error {
+		return fn(gameRepo, guessRepo)
+	})
+}
+
+func (m *MockTransactor) WithinPlayerTransaction(fn func(gameRepo GameRepositoryInterface, guessRepo GuessRepositoryInterface, playerRepo PlayerRepositoryInterface) error) error {
+	games := make(map[string]*Game, len(m.gameRepo.games))
+	for id, game := range m.gameRepo.games {
+		games[id] = game
+	}
+	playerGames := make(map[string]string, len(m.gameRepo.playerGames))
+	for id, playerID := range m.gameRepo.playerGames {
+		playerGames[id] = playerID
+	}
+	guesses := make(map[string][]Guess, len(m.guessRepo.guesses))
+	for id, gameGuesses := range m.guessRepo.guesses {
+		guesses[id] = append([]Guess(nil), gameGuesses...)
+	}
+	var playerRepo PlayerRepositoryInterface
+	players := make(map[string]*Player)
+	if m.playerRepo != nil {
+		playerRepo = m.playerRepo
+		for username, player := range m.playerRepo.players {
+			players[username] = player
+		}
+	}
+
+	m.open = true
+	err := fn(m.gameRepo, m.guessRepo, playerRepo)
+	m.open = false
+	if err != nil {
+		m.gameRepo.games = games
+		m.gameRepo.playerGames = playerGames
+		m.guessRepo.guesses = guesses
+		if m.playerRepo != nil {
+			m.playerRepo.players = players
+		}
+		m.rollbacks++
+		return err
+	}
+
+	m.commits++
+	return nil
+}
+
+type StubDefinitionProvider struct {
+	definitions map[string]string
+}
+
+func (p *StubDefinitionProvider) Definition(word string) (string, bool) {
+	definition, ok := p.definitions[strings.ToUpper(word)]
+	return definition, ok
+}
+
+// Test functions
+
+func TestGameServiceCreateNewGame(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("CreateNewGame should not return error: %v", err)
+	}
+
+	if game.ID == "" {
+		t.Error("Game should have an ID")
+	}
+	if game.TargetWord != "HELLO" { // First word from mock
+		t.Errorf("Expected target word 'HELLO', got '%s'", game.TargetWord)
+	}
+	if game.MaxGuesses != 6 {
+		t.Errorf("Expected max guesses 6, got %d", game.MaxGuesses)
+	}
+	if game.GuessCount != 0 {
+		t.Errorf("New game should have 0 guesses, got %d", game.GuessCount)
+	}
+	if game.IsCompleted {
+		t.Error("New game should not be completed")
+	}
+	if game.IsWon {
+		t.Error("New game should not be won")
 	}
 }
 
 func TestGameServiceCreateNewGameNoWords(t *testing.T) {
 	gameRepo := NewMockGameRepository()
 	guessRepo := NewMockGuessRepository()
-	wordList := &MockWordList{words: []string{}} // Empty word list
+	wordList := &MockWordList{words: []string{}} // Empty word list
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	_, err := service.CreateNewGame(0)
+	if err == nil {
+		t.Error("Expected error when no words available")
+	}
+	if !strings.Contains(err.Error(), "no five-letter words available") {
+		t.Errorf("Expected specific error message, got: %v", err)
+	}
+}
+
+func TestGameServiceMakeGuessValid(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Create a game first
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Make a valid guess
+	response, err := service.MakeGuess(game.ID, "WORLD")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+
+	if response.Game.GuessCount != 1 {
+		t.Errorf("Expected guess count 1, got %d", response.Game.GuessCount)
+	}
+	if response.Game.IsWon {
+		t.Error("Game should not be won with incorrect guess")
+	}
+	if response.Game.IsCompleted {
+		t.Error("Game should not be completed after one guess")
+	}
+	if !strings.Contains(response.Message, "5 guess(es) remaining") {
+		t.Errorf("Expected remaining guesses message, got: %s", response.Message)
+	}
+}
+
+func TestGameServiceMakeGuessWinning(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Create a game
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Make winning guess (same as target word)
+	response, err := service.MakeGuess(game.ID, "HELLO")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+
+	if response.Game.GuessCount != 1 {
+		t.Errorf("Expected guess count 1, got %d", response.Game.GuessCount)
+	}
+	if !response.Game.IsWon {
+		t.Error("Game should be won with correct guess")
+	}
+	if !response.Game.IsCompleted {
+		t.Error("Game should be completed when won")
+	}
+	if !strings.Contains(response.Message, "Congratulations") {
+		t.Errorf("Expected congratulations message, got: %s", response.Message)
+	}
+}
+
+func TestGameServiceMakeGuessInvalidWord(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Create a game
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Try invalid word
+	_, err = service.MakeGuess(game.ID, "ZZZZZ")
+	if err == nil {
+		t.Error("Expected error for invalid word")
+	}
+	if !strings.Contains(err.Error(), "not a valid word") {
+		t.Errorf("Expected specific error message, got: %v", err)
+	}
+}
+
+func TestGameServiceMakeGuessWrongLength(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Create a game
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Try wrong length word
+	_, err = service.MakeGuess(game.ID, "HI")
+	if err == nil {
+		t.Error("Expected error for wrong length word")
+	}
+	if !strings.Contains(err.Error(), "must be 5 letters long") {
+		t.Errorf("Expected specific error message, got: %v", err)
+	}
+
+	var lengthErr *ErrWrongLength
+	if !errors.As(err, &lengthErr) {
+		t.Fatalf("Expected *ErrWrongLength, got %T", err)
+	}
+	if lengthErr.Expected != 5 || lengthErr.Got != 2 {
+		t.Errorf("Expected lengths 5/2, got %d/%d", lengthErr.Expected, lengthErr.Got)
+	}
+}
+
+func TestGameServiceMakeGuessGameNotFound(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Try to make guess on non-existent game
+	_, err := service.MakeGuess("nonexistent", "HELLO")
+	if err == nil {
+		t.Error("Expected error for non-existent game")
+	}
+	if !strings.Contains(err.Error(), "failed to get game") {
+		t.Errorf("Expected specific error message, got: %v", err)
+	}
+}
+
+func TestGameServiceMakeGuessGameCompleted(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Create and complete a game
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Manually mark game as completed
+	game.IsCompleted = true
+	gameRepo.UpdateGame(game)
+
+	// Try to make guess on completed game
+	_, err = service.MakeGuess(game.ID, "WORLD")
+	if err == nil {
+		t.Error("Expected error for completed game")
+	}
+	if !strings.Contains(err.Error(), "already completed") {
+		t.Errorf("Expected specific error message, got: %v", err)
+	}
+}
+
+func TestGameServiceValidateWord(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Test valid word
+	if !service.ValidateWord("HELLO") {
+		t.Error("Expected 'HELLO' to be valid")
+	}
+
+	// Test invalid word
+	if service.ValidateWord("ZZZZZ") {
+		t.Error("Expected 'ZZZZZ' to be invalid")
+	}
+
+	// Test wrong length
+	if service.ValidateWord("HI") {
+		t.Error("Expected 'HI' to be invalid due to length")
+	}
+
+	// Test with whitespace
+	if !service.ValidateWord(" HELLO ") {
+		t.Error("Expected 'HELLO' with whitespace to be valid")
+	}
+}
+
+func TestGameServiceGetGameStats(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	stats, err := service.GetGameStats()
+	if err != nil {
+		t.Fatalf("GetGameStats should not return error: %v", err)
+	}
+
+	expectedStats := map[string]interface{}{
+		"total_words":        7, // From mock word list
+		"five_letter_words":  7,
+		"max_guesses":       6,
+		"word_length":       5,
+	}
+
+	for key, expected := range expectedStats {
+		if stats[key] != expected {
+			t.Errorf("Expected %s to be %v, got %v", key, expected, stats[key])
+		}
+	}
+}
+
+func TestGameServiceGetRecentGames(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Create some games
+	_, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create first game: %v", err)
+	}
+	_, err = service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create second game: %v", err)
+	}
+
+	// Test with valid limit
+	games, err := service.GetRecentGames(10)
+	if err != nil {
+		t.Fatalf("GetRecentGames should not return error: %v", err)
+	}
+
+	if len(games) != 2 {
+		t.Errorf("Expected 2 games, got %d", len(games))
+	}
+
+	// Test with limit bounds
+	games, err = service.GetRecentGames(0)
+	if err != nil {
+		t.Fatalf("GetRecentGames should not return error: %v", err)
+	}
+	// Should default to 10
+	if len(games) > 10 {
+		t.Errorf("Expected at most 10 games with limit 0, got %d", len(games))
+	}
+
+	games, err = service.GetRecentGames(200)
+	if err != nil {
+		t.Fatalf("GetRecentGames should not return error: %v", err)
+	}
+	// Should default to 10
+	if len(games) > 10 {
+		t.Errorf("Expected at most 10 games with limit 200, got %d", len(games))
+	}
+}
+
+func TestGameServiceGetGuessHeatmap(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Seed guesses against target HELLO
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if _, err := service.MakeGuess(game.ID, "HELLO"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	heatmap, err := service.GetGuessHeatmap()
+	if err != nil {
+		t.Fatalf("GetGuessHeatmap should not return error: %v", err)
+	}
+
+	if len(heatmap) != 5 {
+		t.Fatalf("Expected 5 positions, got %d", len(heatmap))
+	}
+
+	// WORLD vs HELLO: absent, present, absent, correct, absent
+	// HELLO vs HELLO: all correct
+	expected := []PositionTally{
+		{Position: 1, Correct: 1, Absent: 1},
+		{Position: 2, Correct: 1, Present: 1},
+		{Position: 3, Correct: 1, Absent: 1},
+		{Position: 4, Correct: 2},
+		{Position: 5, Correct: 1, Absent: 1},
+	}
+	for i, want := range expected {
+		if heatmap[i] != want {
+			t.Errorf("Position %d: expected %+v, got %+v", i+1, want, heatmap[i])
+		}
+	}
+
+	// Repository failures should be surfaced
+	guessRepo.shouldFailGet = true
+	if _, err := service.GetGuessHeatmap(); err == nil {
+		t.Error("Expected error when guess results cannot be loaded")
+	}
+}
+
+func TestGameServiceCreateGames(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, AllowBulkCreate: true}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	ids, err := service.CreateGames(25)
+	if err != nil {
+		t.Fatalf("CreateGames should not return error: %v", err)
+	}
+
+	if len(ids) != 25 {
+		t.Fatalf("Expected 25 game IDs, got %d", len(ids))
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			t.Errorf("Duplicate game ID: %s", id)
+		}
+		seen[id] = true
+
+		game, err := gameRepo.GetGame(id)
+		if err != nil {
+			t.Fatalf("Created game %s not found: %v", id, err)
+		}
+		if !wordList.Contains(game.TargetWord) {
+			t.Errorf("Game %s has invalid target word '%s'", id, game.TargetWord)
+		}
+		if game.MaxGuesses != 6 {
+			t.Errorf("Expected max guesses 6, got %d", game.MaxGuesses)
+		}
+	}
+}
+
+func TestGameServiceCreateGamesGuarded(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	_, err := service.CreateGames(5)
+	if err == nil || !strings.Contains(err.Error(), "disabled") {
+		t.Errorf("Expected disabled error, got: %v", err)
+	}
+	if len(gameRepo.games) != 0 {
+		t.Errorf("Expected no games to be created, got %d", len(gameRepo.games))
+	}
+
+	config.AllowBulkCreate = true
+	for _, n := range []int{0, -1, maxBulkGames + 1} {
+		if _, err := service.CreateGames(n); err == nil {
+			t.Errorf("Expected error for count %d", n)
+		}
+	}
+}
+
+func TestGameServiceMakeGuessDelta(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make first guess: %v", err)
+	}
+
+	// Without delta the full history is returned
+	response, err := service.MakeGuess(game.ID, "CRANE")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if len(response.Guesses) != 2 {
+		t.Errorf("Expected full history of 2 guesses, got %d", len(response.Guesses))
+	}
+
+	// With delta only the new guess is returned
+	response, err = service.MakeGuessWithOptions(game.ID, "SLATE", GuessOptions{Delta: true})
+	if err != nil {
+		t.Fatalf("MakeGuessWithOptions should not return error: %v", err)
+	}
+	if len(response.Guesses) != 1 {
+		t.Fatalf("Expected exactly 1 guess in delta response, got %d", len(response.Guesses))
+	}
+	if response.Guesses[0].GuessWord != "SLATE" || response.Guesses[0].GuessNumber != 3 {
+		t.Errorf("Expected new guess SLATE #3, got %s #%d", response.Guesses[0].GuessWord, response.Guesses[0].GuessNumber)
+	}
+	if response.Game.GuessCount != 3 {
+		t.Errorf("Expected updated guess count 3, got %d", response.Game.GuessCount)
+	}
+}
+
+func TestGameServiceMakeGuessAnnotated(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	response, err := service.MakeGuessWithOptions(game.ID, "WORLD", GuessOptions{Annotate: true})
+	if err != nil {
+		t.Fatalf("MakeGuessWithOptions should not return error: %v", err)
+	}
+
+	expectedCodes := map[string]string{"correct": "G", "present": "Y", "absent": "B"}
+	for _, tile := range response.Guesses[0].Result {
+		if tile.Code != expectedCodes[tile.Status] {
+			t.Errorf("Tile %s with status %s has code %q", tile.Letter, tile.Status, tile.Code)
+		}
+		if tile.Description != tile.Letter+" is "+tile.Status {
+			t.Errorf("Tile %s with status %s has description %q", tile.Letter, tile.Status, tile.Description)
+		}
+	}
+
+	// Annotations are response-only and are not stored with the guess
+	stored, err := guessRepo.GetGuessesByGameID(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get guesses: %v", err)
+	}
+	for _, tile := range stored[0].Result {
+		if tile.Code != "" || tile.Description != "" {
+			t.Errorf("Stored tile %s should not be annotated", tile.Letter)
+		}
+	}
+
+	// Without the option tiles are not annotated
+	response, err = service.MakeGuess(game.ID, "CRANE")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	for _, tile := range response.Guesses[1].Result {
+		if tile.Code != "" || tile.Description != "" {
+			t.Errorf("Tile %s should not be annotated by default", tile.Letter)
+		}
+	}
+}
+
+func TestGameServiceRevealDefinition(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 2, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	service.SetDefinitionProvider(&StubDefinitionProvider{
+		definitions: map[string]string{"HELLO": "A greeting"},
+	})
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// No definition while the game is in progress
+	response, err := service.MakeGuess(game.ID, "WORLD")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Definition != "" {
+		t.Errorf("Expected no definition while in progress, got '%s'", response.Definition)
+	}
+
+	// Definition is revealed once the game is lost
+	response, err = service.MakeGuess(game.ID, "CRANE")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if !response.Game.IsCompleted || response.Game.IsWon {
+		t.Fatal("Expected game to be lost")
+	}
+	if response.Definition != "A greeting" {
+		t.Errorf("Expected definition 'A greeting', got '%s'", response.Definition)
+	}
+}
+
+func TestGameServiceRevealDefinitionMissing(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// No provider configured
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	response, err := service.MakeGuess(game.ID, "HELLO")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Definition != "" {
+		t.Errorf("Expected no definition without provider, got '%s'", response.Definition)
+	}
+
+	// Provider without an entry for the target word
+	service.SetDefinitionProvider(&StubDefinitionProvider{definitions: map[string]string{}})
+	game, err = service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	response, err = service.MakeGuess(game.ID, "HELLO")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Definition != "" {
+		t.Errorf("Expected no definition for missing word, got '%s'", response.Definition)
+	}
+}
+
+func TestGameServiceCreateNewGameWithGuess(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	transactor := &MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo}
+	service.SetTransactor(transactor)
+
+	response, err := service.CreateNewGameWithGuess("world")
+	if err != nil {
+		t.Fatalf("CreateNewGameWithGuess should not return error: %v", err)
+	}
+
+	if response.Game.ID == "" {
+		t.Error("Game should have an ID")
+	}
+	if response.Game.GuessCount != 1 {
+		t.Errorf("Expected guess count 1, got %d", response.Game.GuessCount)
+	}
+	if len(response.Guesses) != 1 || response.Guesses[0].GuessWord != "WORLD" {
+		t.Errorf("Expected a single WORLD guess, got %+v", response.Guesses)
+	}
+	if transactor.commits != 1 {
+		t.Errorf("Expected 1 commit, got %d", transactor.commits)
+	}
+}
+
+func TestGameServiceCreateNewGameWithInvalidGuessRollsBack(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	transactor := &MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo}
+	service.SetTransactor(transactor)
+
+	tests := []struct {
+		name      string
+		guessWord string
+		errText   string
+	}{
+		{"Invalid word", "ZZZZZ", "not a valid word"},
+		{"Wrong length", "HI", "must be 5 letters long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateNewGameWithGuess(tt.guessWord)
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errText, err)
+			}
+			if len(gameRepo.games) != 0 {
+				t.Errorf("Expected no game after rollback, got %d", len(gameRepo.games))
+			}
+		})
+	}
+
+	if transactor.commits != 0 {
+		t.Errorf("Expected no commits, got %d", transactor.commits)
+	}
+}
+
+func TestGameServiceGetGamesByTargetWord(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Seed games with the same and different targets
+	for _, target := range []string{"CRANE", "CRANE", "SLATE", "CRANE"} {
+		game, err := gameRepo.CreateGame("", target, 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		game.IsCompleted = true
+		game.IsWon = target == "SLATE"
+		gameRepo.UpdateGame(game)
+	}
+
+	// An in-progress game with the same target must not be revealed
+	if _, err := gameRepo.CreateGame("", "CRANE", 6); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	games, err := service.GetGamesByTargetWord("crane", 0)
+	if err != nil {
+		t.Fatalf("GetGamesByTargetWord should not return error: %v", err)
+	}
+	if len(games) != 3 {
+		t.Errorf("Expected 3 completed CRANE games, got %d", len(games))
+	}
+	for _, game := range games {
+		if game.TargetWord != "CRANE" || !game.IsCompleted {
+			t.Errorf("Unexpected game in results: %+v", game)
+		}
+	}
+
+	games, err = service.GetGamesByTargetWord("SLATE", 0)
+	if err != nil {
+		t.Fatalf("GetGamesByTargetWord should not return error: %v", err)
+	}
+	if len(games) != 1 || !games[0].IsWon {
+		t.Errorf("Expected 1 won SLATE game, got %+v", games)
+	}
+
+	if _, err := service.GetGamesByTargetWord("  ", 0); err == nil {
+		t.Error("Expected error for empty word")
+	}
+}
+
+func TestGameServiceGetPlayerBestGame(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	start := time.Now()
+	seed := []struct {
+		player     string
+		won        bool
+		guessCount int
+		offset     time.Duration
+	}{
+		{"player-1", true, 4, 0},
+		{"player-1", true, 3, 2 * time.Minute},
+		{"player-1", true, 3, 1 * time.Minute}, // Earliest of the fewest-guess wins
+		{"player-1", false, 2, 0},              // Losses never count
+		{"player-2", true, 1, 0},               // Other players never count
+	}
+
+	var expectedID string
+	for i, entry := range seed {
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		game.IsCompleted = true
+		game.IsWon = entry.won
+		game.GuessCount = entry.guessCount
+		game.CreatedAt = start.Add(entry.offset)
+		gameRepo.UpdateGame(game)
+		gameRepo.playerGames[game.ID] = entry.player
+		guessRepo.CreateGuess("", game.ID, "HELLO", 1, EvaluateGuess("HELLO", "HELLO"))
+
+		if i == 2 {
+			expectedID = game.ID
+		}
+	}
+
+	best, err := service.GetPlayerBestGame("player-1")
+	if err != nil {
+		t.Fatalf("GetPlayerBestGame should not return error: %v", err)
+	}
+	if best.Game.ID != expectedID {
+		t.Errorf("Expected best game %s, got %s (guess count %d)", expectedID, best.Game.ID, best.Game.GuessCount)
+	}
+	if len(best.Guesses) != 1 {
+		t.Errorf("Expected best game to include its guesses, got %d", len(best.Guesses))
+	}
+
+	_, err = service.GetPlayerBestGame("player-3")
+	if err == nil || !strings.Contains(err.Error(), "no won games") {
+		t.Errorf("Expected no won games error, got: %v", err)
+	}
+}
+
+func TestGameServiceClampLimit(t *testing.T) {
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, MaxPageSize: 50, DefaultPageSize: 20}
+	service := NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), NewMockWordList(), config)
+
+	tests := []struct {
+		requested int
+		expected  int
+	}{
+		{-1, 20},
+		{0, 20},
+		{1, 1},
+		{49, 49},
+		{50, 50},
+		{51, 50},
+		{1000, 50},
+	}
+
+	for _, tt := range tests {
+		if got := service.clampLimit(tt.requested); got != tt.expected {
+			t.Errorf("clampLimit(%d) = %d, expected %d", tt.requested, got, tt.expected)
+		}
+	}
+
+	// Unset configuration falls back to built-in defaults
+	service = NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), NewMockWordList(), &GameConfig{})
+	if got := service.clampLimit(0); got != defaultPageSize {
+		t.Errorf("Expected default page size %d, got %d", defaultPageSize, got)
+	}
+	if got := service.clampLimit(defaultMaxPageSize + 1); got != defaultMaxPageSize {
+		t.Errorf("Expected max page size %d, got %d", defaultMaxPageSize, got)
+	}
+}
+
+func TestGameServiceGetRecentGamesClamped(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, MaxPageSize: 3, DefaultPageSize: 2}
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), config)
+
+	for i := 0; i < 5; i++ {
+		if _, err := service.CreateNewGame(0); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	tests := []struct {
+		requested int
+		expected  int
+	}{
+		{0, 2},  // Default page size
+		{3, 3},  // At the boundary
+		{4, 3},  // Just past the boundary
+		{99, 3}, // Far past the boundary
+	}
+
+	for _, tt := range tests {
+		games, err := service.GetRecentGames(tt.requested)
+		if err != nil {
+			t.Fatalf("GetRecentGames should not return error: %v", err)
+		}
+		if len(games) != tt.expected {
+			t.Errorf("GetRecentGames(%d) returned %d games, expected %d", tt.requested, len(games), tt.expected)
+		}
+	}
+}
+
+func TestGameServiceGetNudge(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Refused before any guess is made
+	_, err = service.GetNudge(game.ID)
+	if err == nil || !strings.Contains(err.Error(), "no guesses") {
+		t.Errorf("Expected no guesses error, got: %v", err)
+	}
+
+	// WORLD vs HELLO: W absent at position 1
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	nudge, err := service.GetNudge(game.ID)
+	if err != nil {
+		t.Fatalf("GetNudge should not return error: %v", err)
+	}
+	if nudge.Position != 1 || nudge.Letter != "W" {
+		t.Errorf("Expected nudge for position 1 letter W, got %+v", nudge)
+	}
+	if nudge.Message != "Position 1 is not W" {
+		t.Errorf("Unexpected nudge message: %s", nudge.Message)
+	}
+
+	// Nudges are refused once the game is over
+	if _, err := service.MakeGuess(game.ID, "HELLO"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	_, err = service.GetNudge(game.ID)
+	if err == nil || !strings.Contains(err.Error(), "already completed") {
+		t.Errorf("Expected completed game error, got: %v", err)
+	}
+}
+
+func TestGameServiceGetConstraints(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := &MockWordList{words: []string{"HELLO", "WORLD", "LLAMA", "LOLLY"}}
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"WORLD", "LLAMA", "LOLLY"} {
+		if _, err := service.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+
+	constraints, err := service.GetConstraints(game.ID)
+	if err != nil {
+		t.Fatalf("GetConstraints should not return error: %v", err)
+	}
+
+	if constraints.Fixed[3] != "L" || constraints.Fixed[4] != "L" || len(constraints.Fixed) != 2 {
+		t.Errorf("Expected L fixed at positions 3 and 4, got %v", constraints.Fixed)
+	}
+	if constraints.Required["L"] != 2 || constraints.Required["O"] != 1 {
+		t.Errorf("Expected L>=2 and O>=1, got %v", constraints.Required)
+	}
+	if constraints.MaxCounts["L"] != 2 {
+		t.Errorf("Expected L capped at 2, got %v", constraints.MaxCounts)
+	}
+	if len(constraints.Excluded) != 6 {
+		t.Errorf("Expected 6 excluded letters, got %v", constraints.Excluded)
+	}
+
+	if _, err := service.GetConstraints("nonexistent"); err == nil {
+		t.Error("Expected error for non-existent game")
+	}
+}
+
+func TestGameServiceCreateOrGetDailyGame(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Without a schedule there is no daily game
+	if _, err := service.CreateOrGetDailyGame("", time.Now()); err == nil {
+		t.Error("Expected error without an answer schedule")
+	}
+
+	answerRepo := NewMockAnswerRepository()
+	service.SetAnswerRepository(answerRepo)
+
+	day := time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC)
+	answerRepo.answers["2024-03-01"] = "slate"
+
+	game, err := service.CreateOrGetDailyGame("", day)
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if game.TargetWord != "SLATE" {
+		t.Errorf("Expected scheduled target 'SLATE', got '%s'", game.TargetWord)
+	}
+	if game.DailyDate == nil || dateKey(*game.DailyDate) != "2024-03-01" {
+		t.Errorf("Expected daily date 2024-03-01, got %v", game.DailyDate)
+	}
+
+	// The same date returns the existing game
+	again, err := service.CreateOrGetDailyGame("", day.Add(2 * time.Hour))
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if again.ID != game.ID {
+		t.Errorf("Expected existing daily game %s, got %s", game.ID, again.ID)
+	}
+
+	// A date without a scheduled answer is an error
+	_, err = service.CreateOrGetDailyGame("", day.AddDate(0, 0, 1))
+	if err == nil || !strings.Contains(err.Error(), "no answer scheduled") {
+		t.Errorf("Expected no answer scheduled error, got: %v", err)
+	}
+}
+
+func TestGameServiceGetPlayerRank(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	if _, err := service.GetPlayerRank("p3", ""); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("Expected not configured error, got %v", err)
+	}
+
+	playerRepo := NewMockPlayerRepository()
+	service.SetPlayerRepository(playerRepo)
+
+	// Five players with distinct max streaks; p3 is in the middle, and has the
+	// most wins with p5
+	seeded := []Player{
+		{ID: "p1", Username: "ann", MaxStreak: 1, GamesWon: 2},
+		{ID: "p2", Username: "bob", MaxStreak: 8, GamesWon: 3},
+		{ID: "p3", Username: "cat", MaxStreak: 5, GamesWon: 9},
+		{ID: "p4", Username: "dan", MaxStreak: 3, GamesWon: 1},
+		{ID: "p5", Username: "eve", MaxStreak: 12, GamesWon: 9},
+	}
+	for i := range seeded {
+		playerRepo.players[seeded[i].Username] = &seeded[i]
+	}
+
+	rank, err := service.GetPlayerRank("p3", "")
+	if err != nil {
+		t.Fatalf("GetPlayerRank should not return error: %v", err)
+	}
+	expected := PlayerRank{PlayerID: "p3", Metric: "max_streak", Value: 5, Rank: 3, Players: 5, Percentile: 50}
+	if *rank != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *rank)
+	}
+
+	// Tied players share the best rank, and count as half in the percentile
+	rank, err = service.GetPlayerRank("p3", "games_won")
+	if err != nil {
+		t.Fatalf("GetPlayerRank should not return error: %v", err)
+	}
+	if rank.Rank != 1 || rank.Percentile != 80 {
+		t.Errorf("Expected rank 1 at the 80th percentile, got %+v", rank)
+	}
+
+	if _, err := service.GetPlayerRank("p3", "email"); err == nil || !strings.Contains(err.Error(), "invalid rank metric") {
+		t.Errorf("Expected invalid metric error, got %v", err)
+	}
+	if _, err := service.GetPlayerRank("nobody", "max_streak"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestGameServiceDailyDerivedWords(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, DailyDerivedWords: true}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// One second before midnight UTC is still March 1st
+	now := time.Date(2024, 3, 1, 23, 59, 59, 0, time.UTC)
+	service.SetClock(func() time.Time { return now })
+	today := service.Today()
+	if dateKey(today) != "2024-03-01" || !today.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Expected today to start 2024-03-01, got %v", today)
+	}
+
+	// Without a schedule the word comes from the date, and replaying reuses the game
+	game, err := service.CreateOrGetDailyGame("", service.Today())
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if expected := wordList.DailyWord(today); game.TargetWord != expected {
+		t.Errorf("Expected derived target %s, got %s", expected, game.TargetWord)
+	}
+	again, err := service.CreateOrGetDailyGame("", service.Today())
+	if err != nil || again.ID != game.ID {
+		t.Errorf("Expected the same day to reuse game %s, got %+v, %v", game.ID, again, err)
+	}
+
+	// Two seconds later it is March 2nd, with a new game
+	now = now.Add(2 * time.Second)
+	if dateKey(service.Today()) != "2024-03-02" {
+		t.Fatalf("Expected the day to roll over at midnight UTC, got %v", service.Today())
+	}
+	next, err := service.CreateOrGetDailyGame("", service.Today())
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if next.ID == game.ID {
+		t.Error("Expected a new daily game after midnight UTC")
+	}
+	if expected := wordList.DailyWord(service.Today()); next.TargetWord != expected {
+		t.Errorf("Expected derived target %s, got %s", expected, next.TargetWord)
+	}
+
+	// A scheduled answer still takes precedence
+	answerRepo := NewMockAnswerRepository()
+	answerRepo.answers["2024-03-03"] = "audio"
+	service.SetAnswerRepository(answerRepo)
+	scheduled, err := service.CreateOrGetDailyGame("", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if scheduled.TargetWord != "AUDIO" {
+		t.Errorf("Expected scheduled target AUDIO, got %s", scheduled.TargetWord)
+	}
+}
+
+func TestGameServiceScheduleAnswers(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList() // 7 target words
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	answerRepo := NewMockAnswerRepository()
+	service.SetAnswerRepository(answerRepo)
+
+	// A word scheduled just before the range must not be reused
+	start := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	answerRepo.answers["2024-05-09"] = "HELLO"
+
+	answers, err := service.ScheduleAnswers(start, 5)
+	if err != nil {
+		t.Fatalf("ScheduleAnswers should not return error: %v", err)
+	}
+	if len(answers) != 5 {
+		t.Fatalf("Expected 5 scheduled answers, got %d", len(answers))
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		key := dateKey(start.AddDate(0, 0, i))
+		word, ok := answerRepo.answers[key]
+		if !ok {
+			t.Errorf("Expected an answer scheduled for %s", key)
+			continue
+		}
+		if seen[word] {
+			t.Errorf("Duplicate word %s in schedule", word)
+		}
+		if word == "HELLO" {
+			t.Errorf("Word scheduled the previous day was reused on %s", key)
+		}
+		if !wordList.Contains(word) {
+			t.Errorf("Scheduled word %s is not a target word", word)
+		}
+		seen[word] = true
+	}
+
+	// The schedule drives daily game selection
+	game, err := service.CreateOrGetDailyGame("", start.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if game.TargetWord != answers[2].TargetWord {
+		t.Errorf("Expected daily target %s, got %s", answers[2].TargetWord, game.TargetWord)
+	}
+
+	// Requests that cannot be filled without duplicates are rejected
+	if _, err := service.ScheduleAnswers(start, 7); err == nil {
+		t.Error("Expected error when not enough unused words remain")
+	}
+	for _, days := range []int{0, maxScheduleDays + 1} {
+		if _, err := service.ScheduleAnswers(start, days); err == nil {
+			t.Errorf("Expected error for %d days", days)
+		}
+	}
+}
+
+func TestGameServiceGetStatsByDifficulty(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	seed := func(difficulty float64, completed, won bool) {
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		gameRepo.games[game.ID].IsCompleted = completed
+		gameRepo.games[game.ID].IsWon = won
+		gameRepo.difficulties[game.ID] = difficulty
+	}
+
+	seed(0.1, true, true)
+	seed(0.2, true, true)
+	seed(0.5, true, true)
+	seed(0.6, true, false)
+	seed(0.65, true, false)
+	seed(0.9, true, false)
+	seed(0.9, false, false) // In progress; not counted
+
+	stats, err := service.GetStatsByDifficulty()
+	if err != nil {
+		t.Fatalf("GetStatsByDifficulty should not return error: %v", err)
+	}
+
+	expected := []DifficultyTierStats{
+		{Tier: DifficultyEasy, GamesPlayed: 2, GamesWon: 2, WinRate: 100},
+		{Tier: DifficultyMedium, GamesPlayed: 3, GamesWon: 1, WinRate: float64(1) / 3 * 100},
+		{Tier: DifficultyHard, GamesPlayed: 1, GamesWon: 0, WinRate: 0},
+	}
+	if len(stats) != len(expected) {
+		t.Fatalf("Expected %d tiers, got %d: %+v", len(expected), len(stats), stats)
+	}
+	for i, want := range expected {
+		if stats[i] != want {
+			t.Errorf("Tier %d: expected %+v, got %+v", i, want, stats[i])
+		}
+	}
+
+	gameRepo.shouldFailGet = true
+	if _, err := service.GetStatsByDifficulty(); err == nil {
+		t.Error("Expected error when repository fails")
+	}
+}
+
+// guessHistoryGameRepository returns the guesses stored in a MockGuessRepository
+// from GetGameWithGuesses
+type guessHistoryGameRepository struct {
+	*MockGameRepository
+	guessRepo *MockGuessRepository
+}
+
+func (r *guessHistoryGameRepository) GetGameWithGuesses(gameID string) (*GameWithGuesses, error) {
+	game, err := r.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	guesses, err := r.guessRepo.GetGuessesByGameID(gameID)
+	if err != nil {
+		return nil, err
+	}
+	return &GameWithGuesses{Game: *game, Guesses: guesses}, nil
+}
+
+func TestGameServiceGetGameWithGuessesOrdered(t *testing.T) {
+	guessRepo := NewMockGuessRepository()
+	gameRepo := &guessHistoryGameRepository{MockGameRepository: NewMockGameRepository(), guessRepo: guessRepo}
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"WORLD", "CRANE", "SLATE"} {
+		if _, err := service.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+
+	tests := []struct {
+		order string
+		first int
+		last  int
+	}{
+		{"", 1, 3},
+		{"asc", 1, 3},
+		{"desc", 3, 1},
+		{"DESC", 3, 1},
+	}
+
+	for _, tt := range tests {
+		result, err := service.GetGameWithGuessesOrdered(game.ID, tt.order)
+		if err != nil {
+			t.Fatalf("order %q: unexpected error: %v", tt.order, err)
+		}
+		guesses := result.Guesses
+		if len(guesses) != 3 {
+			t.Fatalf("order %q: expected 3 guesses, got %d", tt.order, len(guesses))
+		}
+		if guesses[0].GuessNumber != tt.first || guesses[2].GuessNumber != tt.last {
+			t.Errorf("order %q: expected guesses %d..%d, got %d..%d",
+				tt.order, tt.first, tt.last, guesses[0].GuessNumber, guesses[2].GuessNumber)
+		}
+	}
+
+	_, err = service.GetGameWithGuessesOrdered(game.ID, "sideways")
+	if err == nil || !strings.Contains(err.Error(), "invalid order") {
+		t.Errorf("Expected invalid order error, got: %v", err)
+	}
+}
+
+func TestGameServiceIsStillPossible(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	// CRANE is eliminated by the absent R
+	possible, err := service.IsStillPossible(game.ID, "crane")
+	if err != nil {
+		t.Fatalf("IsStillPossible should not return error: %v", err)
+	}
+	if possible {
+		t.Error("Expected CRANE to be eliminated")
+	}
+
+	// CELLO is unaffected by the feedback so far
+	possible, err = service.IsStillPossible(game.ID, "cello")
+	if err != nil {
+		t.Fatalf("IsStillPossible should not return error: %v", err)
+	}
+	if !possible {
+		t.Error("Expected CELLO to still be possible")
+	}
+
+	if _, err := service.IsStillPossible(game.ID, "toolong"); err == nil {
+		t.Error("Expected error for wrong length word")
+	}
+	if _, err := service.IsStillPossible(game.ID, " "); err == nil {
+		t.Error("Expected error for missing word")
+	}
+	if _, err := service.IsStillPossible("missing", "cello"); err == nil {
+		t.Error("Expected error for missing game")
+	}
+}
+
+func TestGameServiceCreateNewGameForClient(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	transactor := &MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo}
+	service.SetTransactor(transactor)
+
+	client := &ClientInfo{IP: "198.51.100.1", UserAgent: "test-agent/1.0"}
+	game, err := service.CreateNewGameForClient(0, client)
+	if err != nil {
+		t.Fatalf("CreateNewGameForClient should not return error: %v", err)
+	}
+	if gameRepo.clientInfo[game.ID] != *client {
+		t.Errorf("Expected client info %+v, got %+v", *client, gameRepo.clientInfo[game.ID])
+	}
+
+	response, err := service.CreateNewGameWithGuessForClient("WORLD", 0, client)
+	if err != nil {
+		t.Fatalf("CreateNewGameWithGuessForClient should not return error: %v", err)
+	}
+	if gameRepo.clientInfo[response.Game.ID] != *client {
+		t.Errorf("Expected client info recorded with first guess game")
+	}
+
+	// Without client info nothing is recorded
+	game, err = service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("CreateNewGame should not return error: %v", err)
+	}
+	if _, ok := gameRepo.clientInfo[game.ID]; ok {
+		t.Error("Expected no client info for plain game creation")
+	}
+}
+
+func TestGameServiceTutorialGuidance(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	service.SetTutorial(&Tutorial{
+		TargetWord: "hello",
+		Steps: []TutorialStep{
+			{Guess: "AUDIO", Guidance: "Try a word with common vowels."},
+			{Guess: "WORLD", Guidance: "Yellow letters are in the word."},
+		},
+	})
+
+	response, err := service.CreateTutorialGame()
+	if err != nil {
+		t.Fatalf("CreateTutorialGame should not return error: %v", err)
+	}
+	if !response.Game.IsTutorial || response.Game.TargetWord != "HELLO" {
+		t.Errorf("Expected tutorial game for HELLO, got %+v", response.Game)
+	}
+	if response.Guidance != "Try a word with common vowels." {
+		t.Errorf("Expected step 1 guidance at creation, got %q", response.Guidance)
+	}
+
+	gameID := response.Game.ID
+	response, err = service.MakeGuess(gameID, "AUDIO")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Guidance != "Yellow letters are in the word." {
+		t.Errorf("Expected step 2 guidance after first guess, got %q", response.Guidance)
+	}
+
+	// The script has run out after two steps
+	response, err = service.MakeGuess(gameID, "WORLD")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Guidance != "" {
+		t.Errorf("Expected no guidance after the script ends, got %q", response.Guidance)
+	}
+
+	// Regular games never get guidance
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	response, err = service.MakeGuess(game.ID, "AUDIO")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Guidance != "" {
+		t.Errorf("Expected no guidance for regular game, got %q", response.Guidance)
+	}
+
+	service.SetTutorial(nil)
+	if _, err := service.CreateTutorialGame(); err == nil {
+		t.Error("Expected error when tutorials are disabled")
+	}
+
+	service.SetTutorial(&Tutorial{TargetWord: "TOOLONG"})
+	if _, err := service.CreateTutorialGame(); err == nil {
+		t.Error("Expected error for tutorial target of the wrong length")
+	}
+}
+
+func TestGameServiceKeyboardStateMatchesRecompute(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	for _, word := range []string{"CRANE", "AUDIO", "WORLD", "SLATE"} {
+		response, err := service.MakeGuess(game.ID, word)
+		if err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+
+		stored, err := gameRepo.GetGame(game.ID)
+		if err != nil {
+			t.Fatalf("Failed to get game: %v", err)
+		}
+		guesses, err := guessRepo.GetGuessesByGameID(game.ID)
+		if err != nil {
+			t.Fatalf("Failed to get guesses: %v", err)
+		}
+
+		expected := BuildKeyboardState(guesses)
+		if !reflect.DeepEqual(stored.KeyboardState, expected) {
+			t.Errorf("After %s: stored keyboard %v does not match recomputed %v", word, stored.KeyboardState, expected)
+		}
+		if !reflect.DeepEqual(response.Game.KeyboardState, expected) {
+			t.Errorf("After %s: response keyboard %v does not match recomputed %v", word, response.Game.KeyboardState, expected)
+		}
+	}
+}
+
+func TestGameServiceReconcileGame(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	service.SetTransactor(&MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo})
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"WORLD", "HELLO"} {
+		if _, err := service.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+
+	// A consistent game is left alone
+	_, changed, err := service.ReconcileGame(game.ID)
+	if err != nil {
+		t.Fatalf("ReconcileGame should not return error: %v", err)
+	}
+	if changed {
+		t.Error("Expected a consistent game to be unchanged")
+	}
+
+	// Simulate a partial failure that lost the game update
+	stored := gameRepo.games[game.ID]
+	stored.GuessCount = 1
+	stored.IsWon = false
+	stored.IsCompleted = false
+	stored.CompletedAt = nil
+	stored.KeyboardState = nil
+
+	reconciled, changed, err := service.ReconcileGame(game.ID)
+	if err != nil {
+		t.Fatalf("ReconcileGame should not return error: %v", err)
+	}
+	if !changed {
+		t.Error("Expected the inconsistent game to be changed")
+	}
+
+	guesses, _ := guessRepo.GetGuessesByGameID(game.ID)
+	for _, g := range []*Game{reconciled, gameRepo.games[game.ID]} {
+		if g.GuessCount != 2 || !g.IsWon || !g.IsCompleted || g.CompletedAt == nil {
+			t.Errorf("Expected game won in 2 guesses and completed, got %+v", g)
+		}
+		if !g.KeyboardState.Equal(BuildKeyboardState(guesses)) {
+			t.Errorf("Expected keyboard state to match guesses, got %v", g.KeyboardState)
+		}
+	}
+
+	// Flags claiming a finished game without the guesses to back it are cleared
+	other, err := gameRepo.CreateGame("", "CRANE", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	now := time.Now()
+	gameRepo.games[other.ID].IsCompleted = true
+	gameRepo.games[other.ID].IsWon = true
+	gameRepo.games[other.ID].GuessCount = 3
+	gameRepo.games[other.ID].CompletedAt = &now
+
+	reconciled, changed, err = service.ReconcileGame(other.ID)
+	if err != nil {
+		t.Fatalf("ReconcileGame should not return error: %v", err)
+	}
+	if !changed || reconciled.GuessCount != 0 || reconciled.IsWon || reconciled.IsCompleted || reconciled.CompletedAt != nil {
+		t.Errorf("Expected game reset to no guesses, got %+v", reconciled)
+	}
+
+	if _, _, err := service.ReconcileGame("missing"); err == nil {
+		t.Error("Expected error for missing game")
+	}
+}
+
+func TestGameServiceReconcileMultiBoardGame(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, MaxBoards: 4}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, NewMockWordList(), config)
+	service.SetTransactor(&MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo})
+
+	game, err := gameRepo.CreateMultiBoardGame("", []string{"CRANE", "SLATE"}, 7)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"AUDIO", "CRANE"} {
+		if _, err := service.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+
+	// Solving the first board leaves the game in progress
+	reconciled, changed, err := service.ReconcileGame(game.ID)
+	if err != nil {
+		t.Fatalf("ReconcileGame should not return error: %v", err)
+	}
+	if changed || reconciled.IsCompleted || reconciled.IsWon {
+		t.Errorf("Expected the game with one board solved unchanged and in progress, got %+v", reconciled)
+	}
+
+	// Lost board progress is rebuilt from the guesses
+	gameRepo.games[game.ID].Boards = NewBoards([]string{"CRANE", "SLATE"})
+	reconciled, changed, err = service.ReconcileGame(game.ID)
+	if err != nil {
+		t.Fatalf("ReconcileGame should not return error: %v", err)
+	}
+	expected := Boards{{Target: "CRANE", SolvedAt: 2}, {Target: "SLATE"}}
+	for _, g := range []*Game{reconciled, gameRepo.games[game.ID]} {
+		if !g.Boards.Equal(expected) || g.IsCompleted || g.IsWon {
+			t.Errorf("Expected boards %+v with the game in progress, got %+v", expected, g)
+		}
+	}
+	if !changed {
+		t.Error("Expected the rebuilt boards to count as a change")
+	}
+}
+
+func TestGameServiceCreateAnagramGame(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	for i := 0; i < 20; i++ {
+		game, err := service.CreateAnagramGame(5)
+		if err != nil {
+			t.Fatalf("CreateAnagramGame should not return error: %v", err)
+		}
+		if !wordList.Contains(game.TargetWord) {
+			t.Fatalf("Expected target from the word list, got %s", game.TargetWord)
+		}
+		if !isAnagramOf(game.Scramble, game.TargetWord) {
+			t.Errorf("Expected %s to be a permutation of %s", game.Scramble, game.TargetWord)
+		}
+		if game.Scramble == game.TargetWord {
+			t.Errorf("Expected %s to be scrambled", game.TargetWord)
+		}
+	}
+
+	if _, err := service.CreateAnagramGame(7); err == nil || !strings.Contains(err.Error(), "no target words") {
+		t.Errorf("Expected no target words error for length 7, got %v", err)
+	}
+}
+
+func TestGameServiceAnagramGuesses(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateAnagramGame(5)
+	if err != nil {
+		t.Fatalf("CreateAnagramGame should not return error: %v", err)
+	}
+
+	// Valid words that do not use the scrambled letters are rejected
+	other := "QUICK"
+	if game.TargetWord == other {
+		other = "BROWN"
+	}
+	if _, err := service.MakeGuess(game.ID, other); err == nil || !strings.Contains(err.Error(), "must be") {
+		t.Errorf("Expected letters error for %s, got %v", other, err)
+	}
+
+	response, err := service.MakeGuess(game.ID, strings.ToLower(game.TargetWord))
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if !response.Game.IsWon || !response.Game.IsCompleted {
+		t.Errorf("Expected guessing the original word to win, got %+v", response.Game)
+	}
+	if response.Game.GuessCount != 1 {
+		t.Errorf("Expected rejected guess not to count, got %d guesses", response.Game.GuessCount)
+	}
+}
+
+func TestGameServiceGetShareText(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := service.GetShareText(game.ID, false); err == nil || !strings.Contains(err.Error(), "not completed") {
+		t.Errorf("Expected in-progress game to be rejected, got %v", err)
+	}
+
+	for _, word := range []string{"WORLD", "HELLO"} {
+		if _, err := service.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+
+	// Anonymous game: stats are requested but there is no player to report
+	text, err := service.GetShareText(game.ID, true)
+	if err != nil {
+		t.Fatalf("GetShareText should not return error: %v", err)
+	}
+	if text != "Wordle Practice 2/6\n\n⬛🟨⬛🟩⬛\n🟩🟩🟩🟩🟩" {
+		t.Errorf("Unexpected anonymous share text:\n%s", text)
+	}
+
+	// The streak is the player's run of won games, kept as they finish them
+	gameRepo.players["p1"] = &Player{ID: "p1"}
+	var played *Game
+	for i := 0; i < 2; i++ {
+		if played, err = service.CreateNewGame(0); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		if err := service.AssignPlayer(played.ID, "p1"); err != nil {
+			t.Fatalf("Failed to assign player: %v", err)
+		}
+		if _, err := service.MakeGuess(played.ID, played.TargetWord); err != nil {
+			t.Fatalf("Failed to make guess: %v", err)
+		}
+	}
+
+	text, err = service.GetShareText(played.ID, true)
+	if err != nil {
+		t.Fatalf("GetShareText should not return error: %v", err)
+	}
+	if !strings.Contains(text, "\n🔥 streak: 2 · score: 6\n") {
+		t.Errorf("Expected streak line in share text:\n%s", text)
+	}
+
+	text, err = service.GetShareText(played.ID, false)
+	if err != nil {
+		t.Fatalf("GetShareText should not return error: %v", err)
+	}
+	if strings.Contains(text, "streak") {
+		t.Errorf("Streak line should only appear when requested:\n%s", text)
+	}
+}
+
+func TestGameServiceCaseSensitiveWords(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "proper-nouns.txt")
+	if err := os.WriteFile(testFile, []byte("Paris\nparis\nPerth\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	wordList, err := NewWordListWithOptions(testFile, WordListOptions{CaseSensitive: true})
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+
+	gameRepo := NewMockGameRepository()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, CaseSensitiveWords: true}
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), wordList, config)
+
+	game, err := gameRepo.CreateGame("", "Paris", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Guesses keep their case and are validated exactly
+	if _, err := service.MakeGuess(game.ID, "PARIS"); err == nil {
+		t.Error("Expected PARIS to be rejected by a case-sensitive word list")
+	}
+
+	response, err := service.MakeGuess(game.ID, "paris")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Game.IsWon || response.Guesses[len(response.Guesses)-1].Result[0].Status == "correct" {
+		t.Error("Expected paris not to match Paris")
+	}
+
+	response, err = service.MakeGuess(game.ID, "Paris")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if !response.Game.IsWon {
+		t.Error("Expected the exact spelling to win")
+	}
+}
+
+func TestGameServiceGetReplayableGames(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	daily, _ := gameRepo.CreateDailyGame("", "CRANE", 6, date)
+	practice, _ := gameRepo.CreateGame("", "HELLO", 6)
+	inProgress, _ := gameRepo.CreateGame("", "WORLD", 6)
+	otherPlayer, _ := gameRepo.CreateGame("", "SLATE", 6)
+
+	for _, game := range []*Game{daily, practice, otherPlayer} {
+		gameRepo.games[game.ID].IsCompleted = true
+	}
+	for _, game := range []*Game{daily, practice, inProgress} {
+		gameRepo.playerGames[game.ID] = "p1"
+	}
+	gameRepo.playerGames[otherPlayer.ID] = "p2"
+
+	games, err := service.GetReplayableGames("p1", 0)
+	if err != nil {
+		t.Fatalf("GetReplayableGames should not return error: %v", err)
+	}
+	if len(games) != 1 || games[0].ID != practice.ID {
+		t.Errorf("Expected only the completed practice game, got %+v", games)
+	}
+
+	games, err = service.GetReplayableGames("nobody", 0)
+	if err != nil {
+		t.Fatalf("GetReplayableGames should not return error: %v", err)
+	}
+	if games == nil || len(games) != 0 {
+		t.Errorf("Expected an empty list for a player without games, got %v", games)
+	}
+}
+
+
+func TestMakeGuessRejectsOversizedInput(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Rejected before the game is even looked up
+	gameRepo.shouldFailGet = true
+	_, err = service.MakeGuess(game.ID, strings.Repeat("A", 10000))
+	if err == nil || !strings.Contains(err.Error(), "at most 64 characters") {
+		t.Fatalf("Expected oversized guess to be rejected by the default limit, got %v", err)
+	}
+	gameRepo.shouldFailGet = false
+
+	config.MaxGuessInputLength = 8
+	if _, err := service.MakeGuess(game.ID, "  HELLO   "); err == nil || !strings.Contains(err.Error(), "at most 8 characters") {
+		t.Errorf("Expected configured limit to apply to the raw input, got %v", err)
+	}
+
+	response, err := service.MakeGuess(game.ID, " HELLO ")
+	if err != nil {
+		t.Fatalf("Expected normal guess to pass, got %v", err)
+	}
+	if response.Game.GuessCount != 1 {
+		t.Errorf("Expected guess to be stored, got guess count %d", response.Game.GuessCount)
+	}
+}
+
+func TestMakeGuessInnerWhitespace(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Rejected by default with an error that names the spaces, not the length
+	_, err = service.MakeGuess(game.ID, "he  llo")
+	if err == nil || !strings.Contains(err.Error(), "without spaces") {
+		t.Errorf("Expected inner whitespace to be rejected, got %v", err)
+	}
+	if game.GuessCount != 0 {
+		t.Errorf("Expected rejected guess not to be counted, got %d", game.GuessCount)
+	}
+
+	config.InnerWhitespace = InnerWhitespaceStrip
+	response, err := service.MakeGuess(game.ID, " wo r\tld ")
+	if err != nil {
+		t.Fatalf("Expected stripped guess to pass, got %v", err)
+	}
+	if response.Guesses[0].GuessWord != "WORLD" {
+		t.Errorf("Expected stripped guess WORLD, got %s", response.Guesses[0].GuessWord)
+	}
+
+	response, err = service.MakeGuess(game.ID, "he  llo")
+	if err != nil {
+		t.Fatalf("Expected stripped guess to pass, got %v", err)
+	}
+	if !response.Game.IsWon {
+		t.Error("Expected stripped HELLO to win the game")
+	}
+}
+
+func TestGameServiceIsogramMode(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, IsogramMode: IsogramModeTargets}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// The mock's random word is HELLO, which repeats an L
+	for i := 0; i < 20; i++ {
+		game, err := service.CreateNewGame(0)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		if !IsIsogram(game.TargetWord) {
+			t.Fatalf("Expected an isogram target, got %s", game.TargetWord)
+		}
+	}
+
+	// Non-isogram guesses are still allowed unless strict
+	game, err := gameRepo.CreateGame("", "CRANE", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := service.MakeGuess(game.ID, "HELLO"); err != nil {
+		t.Errorf("Expected HELLO to be accepted outside strict mode, got %v", err)
+	}
+
+	config.IsogramMode = IsogramModeStrict
+	_, err = service.MakeGuess(game.ID, "HELLO")
+	if err == nil || !strings.Contains(err.Error(), "isogram") {
+		t.Errorf("Expected HELLO to be rejected in strict mode, got %v", err)
+	}
+	if count := gameRepo.games[game.ID].GuessCount; count != 1 {
+		t.Errorf("Expected rejected guess not to be counted, got %d", count)
+	}
+	if _, err := service.MakeGuess(game.ID, "SLATE"); err != nil {
+		t.Errorf("Expected SLATE to be accepted in strict mode, got %v", err)
+	}
+
+	// Without any isogram targets there is nothing to pick
+	wordList.words = []string{"HELLO"}
+	if _, err := service.CreateNewGame(0); err == nil {
+		t.Error("Expected an error when no target word is an isogram")
+	}
+}
+
+func TestMakeGuessOutcomeFlags(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 2, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	flags := func(game Game) [3]bool {
+		return [3]bool{game.IsCompleted, game.IsWon, game.IsLoss}
+	}
+
+	lost, err := gameRepo.CreateGame("", "HELLO", 2)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	response, err := service.MakeGuess(lost.ID, "WORLD")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if got := flags(response.Game); got != [3]bool{false, false, false} {
+		t.Errorf("Expected an in-progress game (completed, won, loss), got %v", got)
+	}
+	response, err = service.MakeGuess(lost.ID, "CRANE")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if got := flags(response.Game); got != [3]bool{true, false, true} {
+		t.Errorf("Expected a lost game (completed, won, loss), got %v", got)
+	}
+
+	won, err := gameRepo.CreateGame("", "HELLO", 2)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	response, err = service.MakeGuess(won.ID, "HELLO")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if got := flags(response.Game); got != [3]bool{true, true, false} {
+		t.Errorf("Expected a won game (completed, won, loss), got %v", got)
+	}
+
+	// The flag is always serialized, even when false
+	encoded, err := json.Marshal(response.Game)
+	if err != nil {
+		t.Fatalf("Failed to encode game: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"is_loss":false`) {
+		t.Errorf("Expected is_loss in %s", encoded)
+	}
+}
+
+func TestGameServiceCreateCustomChallenge(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// An explicit target sets the word length when none is given
+	game, err := service.CreateCustomChallenge(CustomChallenge{Dictionary: DictionaryDefault, TargetWord: "crane"})
+	if err != nil {
+		t.Fatalf("CreateCustomChallenge should not return error: %v", err)
+	}
+	if game.TargetWord != "CRANE" || game.Mode != GameModeChallenge {
+		t.Errorf("Expected a CRANE challenge game, got %+v", game)
+	}
+
+	// A seeded custom challenge of the default shape matches a plain challenge
+	seed := int64(7)
+	seeded, err := service.CreateCustomChallenge(CustomChallenge{WordLength: 5, Seed: &seed})
+	if err != nil {
+		t.Fatalf("CreateCustomChallenge should not return error: %v", err)
+	}
+	plain, err := service.CreateChallengeGame(seed)
+	if err != nil {
+		t.Fatalf("CreateChallengeGame should not return error: %v", err)
+	}
+	if seeded.TargetWord != plain.TargetWord {
+		t.Errorf("Expected seed %d to pick %s, got %s", seed, plain.TargetWord, seeded.TargetWord)
+	}
+
+	tests := []struct {
+		name string
+		spec CustomChallenge
+		want string
+	}{
+		{"length mismatch", CustomChallenge{WordLength: 6, TargetWord: "CRANE"}, "must be 6 letters"},
+		{"seed and target", CustomChallenge{Seed: &seed, TargetWord: "CRANE"}, "must not both be set"},
+		{"unknown dictionary", CustomChallenge{Dictionary: "klingon"}, "unknown dictionary"},
+		{"not a word", CustomChallenge{TargetWord: "CRABE"}, "dictionary"},
+		{"length out of range", CustomChallenge{WordLength: 1}, "word_length must be between"},
+		{"longer than the word columns", CustomChallenge{WordLength: 17}, "word_length must be between 2 and 16"},
+		{"no words of length", CustomChallenge{WordLength: 4, Seed: &seed}, "no target words of length 4"},
+	}
+	for _, tt := range tests {
+		before := len(gameRepo.games)
+		_, err := service.CreateCustomChallenge(tt.spec)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+		}
+		if len(gameRepo.games) != before {
+			t.Errorf("%s: expected no game to be created", tt.name)
+		}
+	}
+}
+
+func TestGameServiceGetAtRiskGames(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// In-progress games with 1, 2, 3 and 6 guesses left, and a finished game
+	// that had one guess to spare
+	ids := make(map[int]string)
+	for _, remaining := range []int{1, 2, 3, 6} {
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		game.GuessCount = 6 - remaining
+		ids[remaining] = game.ID
+	}
+	finished, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	finished.GuessCount, finished.IsWon, finished.IsCompleted = 5, true, true
+
+	tests := []struct {
+		threshold int
+		want      []int // Remaining guesses of the listed games, in order
+	}{
+		{1, []int{1}},
+		{2, []int{1, 2}},
+		{5, []int{1, 2, 3}},
+		{6, []int{1, 2, 3, 6}},
+	}
+	for _, tt := range tests {
+		games, err := service.GetAtRiskGames(tt.threshold, 50)
+		if err != nil {
+			t.Fatalf("GetAtRiskGames should not return error: %v", err)
+		}
+		if len(games) != len(tt.want) {
+			t.Fatalf("threshold %d: expected %d games, got %+v", tt.threshold, len(tt.want), games)
+		}
+		for i, remaining := range tt.want {
+			if games[i].ID != ids[remaining] || games[i].RemainingGuesses != remaining {
+				t.Errorf("threshold %d: expected game %d to have %d guesses left, got %+v", tt.threshold, i, remaining, games[i])
+			}
+		}
+	}
+
+	if _, err := service.GetAtRiskGames(0, 50); err == nil || !strings.Contains(err.Error(), "must be at least 1") {
+		t.Errorf("Expected a threshold below 1 to be rejected, got %v", err)
+	}
+}
+
+func TestGameServiceAuditsMutations(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	auditor := &MockAuditLogger{}
+	service.SetAuditLogger(auditor)
+	actor := AuditActor{PlayerID: "player-1", IP: "192.0.2.1", APIKey: apiKeyFingerprint("key")}
+	scoped := service.WithActor(actor)
+
+	game, err := scoped.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := scoped.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if err := scoped.SetMaxHints(game, 2); err != nil {
+		t.Fatalf("Failed to set max hints: %v", err)
+	}
+	if _, err := scoped.GetNudge(game.ID); err != nil {
+		t.Fatalf("Failed to get nudge: %v", err)
+	}
+	if _, changed, err := scoped.ReconcileGame(game.ID); err != nil || changed {
+		t.Fatalf("Expected nothing to reconcile, got changed=%v err=%v", changed, err)
+	}
+	if err := scoped.DeleteGame(game.ID); err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+
+	// Rejected mutations and reads are not audited
+	if _, err := scoped.MakeGuess(game.ID, "WORLD"); err == nil {
+		t.Fatal("Expected a guess on a deleted game to fail")
+	}
+	if _, err := scoped.GetGame(game.ID); err == nil {
+		t.Fatal("Expected the deleted game to be gone")
+	}
+
+	expected := []string{AuditActionGameCreated, AuditActionGuessCreated, AuditActionGameUpdated, AuditActionGameUpdated, AuditActionGameDeleted}
+	if !reflect.DeepEqual(auditor.actions(), expected) {
+		t.Fatalf("Expected audit actions %v, got %v", expected, auditor.actions())
+	}
+	for _, record := range auditor.records {
+		if record.GameID != game.ID || record.Actor != actor || record.Timestamp.IsZero() {
+			t.Errorf("Expected a timestamped record for %s by %+v, got %+v", game.ID, actor, record)
+		}
+	}
+
+	// The unscoped service attributes mutations to no one
+	if _, err := service.CreateNewGame(0); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if last := auditor.records[len(auditor.records)-1]; last.Actor != (AuditActor{}) {
+		t.Errorf("Expected no actor outside WithActor, got %+v", last.Actor)
+	}
+}
+
+func TestGameServiceLetterProbabilities(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	// QUICK misses entirely, leaving HELLO, WORLD, SLATE and BROWN
+	if _, err := service.MakeGuess(game.ID, "QUICK"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	positions, candidates, err := service.LetterProbabilities(game.ID)
+	if err != nil {
+		t.Fatalf("LetterProbabilities should not return error: %v", err)
+	}
+	if candidates != 4 {
+		t.Errorf("Expected 4 candidates, got %d", candidates)
+	}
+	if top := positions[3].Letters[0]; top.Letter != "L" || top.Probability != 0.5 {
+		t.Errorf("Expected L to lead position 4 at 0.5, got %+v", top)
+	}
+	for _, letter := range positions[0].Letters {
+		if letter.Probability != 0.25 {
+			t.Errorf("Expected every first letter at 0.25, got %+v", letter)
+		}
+	}
+
+	if _, _, err := service.LetterProbabilities("missing"); err == nil {
+		t.Error("Expected error for a missing game")
+	}
+}
+
+func TestMakeGuessAbsentLetters(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	response, err := service.MakeGuess(game.ID, "AUDIO")
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if !reflect.DeepEqual(response.AbsentLetters, []string{"A", "D", "I", "U"}) {
+		t.Errorf("Expected A, D, I, U absent, got %v", response.AbsentLetters)
+	}
+
+	// Accumulated across guesses, and never including O, which is in the word
+	response, err = service.MakeGuessWithOptions(game.ID, "BROWN", GuessOptions{Delta: true})
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if !reflect.DeepEqual(response.AbsentLetters, []string{"A", "B", "D", "I", "N", "R", "U", "W"}) {
+		t.Errorf("Expected absent letters from both guesses, got %v", response.AbsentLetters)
+	}
+}
+
+func TestGameServiceIDGenerator(t *testing.T) {
+	newService := func() *GameService {
+		config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+		service := NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), NewMockWordList(), config)
+		service.SetIDGenerator(NewSeededIDGenerator(7))
+		return service
+	}
+
+	// The service assigns IDs before insert, so a fixed generator gives the same IDs every run
+	play := func(service *GameService) (string, string) {
+		game, err := service.CreateNewGame(0)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		response, err := service.MakeGuess(game.ID, "WORLD")
+		if err != nil {
+			t.Fatalf("Failed to make guess: %v", err)
+		}
+		return game.ID, response.Guesses[0].ID
+	}
+
+	gameID, guessID := play(newService())
+	expected := NewSeededIDGenerator(7)
+	if want := expected.NewID(); gameID != want {
+		t.Errorf("Expected game ID %s from the generator, got %s", want, gameID)
+	}
+	if want := expected.NewID(); guessID != want {
+		t.Errorf("Expected guess ID %s from the generator, got %s", want, guessID)
+	}
+
+	if againGame, againGuess := play(newService()); againGame != gameID || againGuess != guessID {
+		t.Errorf("Expected deterministic IDs, got %s/%s then %s/%s", gameID, guessID, againGame, againGuess)
+	}
+}
+
+func TestGameServiceGetRecentGamesByOutcome(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Seven wins, two losses and one game still in progress
+	for i := 0; i < 10; i++ {
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		switch {
+		case i < 7:
+			game.IsWon, game.IsCompleted = true, true
+		case i < 9:
+			game.IsCompleted = true
+		}
+	}
+
+	buckets, total, err := service.GetRecentGamesByOutcome(50)
+	if err != nil {
+		t.Fatalf("GetRecentGamesByOutcome should not return error: %v", err)
+	}
+	if total != 10 {
+		t.Errorf("Expected 10 games grouped, got %d", total)
+	}
+
+	expected := map[string]int{OutcomeWon: 7, OutcomeLost: 2, OutcomeInProgress: 1}
+	for outcome, count := range expected {
+		bucket := buckets[outcome]
+		if bucket == nil || bucket.Count != count {
+			t.Errorf("Expected %d %s games, got %+v", count, outcome, bucket)
+			continue
+		}
+		if len(bucket.SampleIDs) != min(count, maxOutcomeSampleIDs) {
+			t.Errorf("Expected %d sample ids for %s, got %v", min(count, maxOutcomeSampleIDs), outcome, bucket.SampleIDs)
+		}
+		for _, id := range bucket.SampleIDs {
+			if gameRepo.games[id].Outcome() != outcome {
+				t.Errorf("Sample %s in %s bucket has outcome %s", id, outcome, gameRepo.games[id].Outcome())
+			}
+		}
+	}
+
+	// Empty buckets are still reported
+	buckets, _, err = NewGameServiceWithInterfaces(NewMockGameRepository(), guessRepo, wordList, config).GetRecentGamesByOutcome(50)
+	if err != nil {
+		t.Fatalf("GetRecentGamesByOutcome should not return error: %v", err)
+	}
+	if len(buckets) != 3 || buckets[OutcomeLost].Count != 0 || buckets[OutcomeLost].SampleIDs == nil {
+		t.Errorf("Expected three empty buckets, got %v", buckets)
+	}
+}
+
+func TestGameServiceGetDistinctGuessDistribution(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Three guesses each: all distinct, one repeat, and the same word throughout
+	seeded := [][]string{
+		{"CRANE", "SLATE", "HELLO"},
+		{"CRANE", "CRANE", "HELLO"},
+		{"AUDIO", "AUDIO", "AUDIO"},
+		{"SLATE", "WORLD", "HELLO"},
+	}
+	for _, words := range seeded {
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		for i, word := range words {
+			if _, err := guessRepo.CreateGuess("", game.ID, word, i+1, EvaluateGuess(word, "HELLO")); err != nil {
+				t.Fatalf("Failed to create guess: %v", err)
+			}
+		}
+	}
+
+	distribution, err := service.GetDistinctGuessDistribution()
+	if err != nil {
+		t.Fatalf("GetDistinctGuessDistribution should not return error: %v", err)
+	}
+
+	if distribution.Games != 4 {
+		t.Errorf("Expected 4 games, got %d", distribution.Games)
+	}
+	expected := map[int]int{1: 1, 2: 1, 3: 2, 4: 0, 5: 0, 6: 0}
+	if !reflect.DeepEqual(distribution.Distribution, expected) {
+		t.Errorf("Expected distribution %v, got %v", expected, distribution.Distribution)
+	}
+
+	guessRepo.shouldFailGet = true
+	if _, err := service.GetDistinctGuessDistribution(); err == nil {
+		t.Error("Expected error when the repository fails")
+	}
+}
+
+func TestGameServiceGetStatsSnapshot(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// Wins in 2, 3 and 3 guesses, a loss in 6 and a game still in progress,
+	// opened with CRANE, SLATE, CRANE, SLATE and AUDIO
+	seeded := []struct {
+		opener         string
+		guesses        int
+		won, completed bool
+	}{
+		{"CRANE", 2, true, true},
+		{"SLATE", 3, true, true},
+		{"CRANE", 3, true, true},
+		{"SLATE", 6, false, true},
+		{"AUDIO", 1, false, false},
+	}
+	for _, seed := range seeded {
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		game.GuessCount, game.IsWon, game.IsCompleted = seed.guesses, seed.won, seed.completed
+		for number := 1; number <= seed.guesses; number++ {
+			word := seed.opener
+			if number > 1 {
+				word = "WORLD"
+			}
+			if _, err := guessRepo.CreateGuess("", game.ID, word, number, EvaluateGuess(word, "HELLO")); err != nil {
+				t.Fatalf("Failed to create guess: %v", err)
+			}
+		}
+	}
+
+	snapshot, err := service.GetStatsSnapshot()
+	if err != nil {
+		t.Fatalf("GetStatsSnapshot should not return error: %v", err)
+	}
+
+	expectedTotals := SnapshotTotals{Games: 5, Completed: 4, Won: 3, Lost: 1, InProgress: 1}
+	if snapshot.Totals != expectedTotals {
+		t.Errorf("Expected totals %+v, got %+v", expectedTotals, snapshot.Totals)
+	}
+	if snapshot.WinRate != 75 {
+		t.Errorf("Expected win rate 75, got %v", snapshot.WinRate)
+	}
+	if math.Abs(snapshot.AverageGuesses-8.0/3) > 1e-9 {
+		t.Errorf("Expected average guesses 8/3, got %v", snapshot.AverageGuesses)
+	}
+
+	expectedDistribution := map[int]int{1: 0, 2: 1, 3: 2, 4: 0, 5: 0, 6: 0}
+	if !reflect.DeepEqual(snapshot.GuessDistribution, expectedDistribution) {
+		t.Errorf("Expected distribution %v, got %v", expectedDistribution, snapshot.GuessDistribution)
+	}
+
+	// Only first guesses count as openers; WORLD was never one
+	expectedOpeners := []WordCount{{"CRANE", 2}, {"SLATE", 2}, {"AUDIO", 1}}
+	if !reflect.DeepEqual(snapshot.TopOpeners, expectedOpeners) {
+		t.Errorf("Expected openers %v, got %v", expectedOpeners, snapshot.TopOpeners)
+	}
+
+	// With no games every section is still present and zeroed
+	snapshot, err = NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), wordList, config).GetStatsSnapshot()
+	if err != nil {
+		t.Fatalf("GetStatsSnapshot should not return error: %v", err)
+	}
+	if snapshot.Totals.Games != 0 || snapshot.WinRate != 0 || snapshot.AverageGuesses != 0 ||
+		len(snapshot.GuessDistribution) != 6 || snapshot.TopOpeners == nil {
+		t.Errorf("Expected an empty snapshot, got %+v", snapshot)
+	}
+}
+
+func TestValidateGuessLength(t *testing.T) {
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	service := NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), NewMockWordList(), config)
+
+	// ASCII
+	if err := service.ValidateGuessLength("HELLO", 5); err != nil {
+		t.Errorf("Expected HELLO to have 5 letters, got %v", err)
+	}
+	var lengthErr *ErrWrongLength
+	if err := service.ValidateGuessLength("HELL", 5); !errors.As(err, &lengthErr) || lengthErr.Got != 4 {
+		t.Errorf("Expected a wrong-length error for 4 letters, got %v", err)
+	}
+
+	// Multi-byte letters count once: ĞÜNEŞ is 5 letters in 8 bytes
+	if err := service.ValidateGuessLength("ĞÜNEŞ", 5); err != nil {
+		t.Errorf("Expected ĞÜNEŞ to have 5 letters, got %v", err)
+	}
+	if err := service.ValidateGuessLength(strings.Repeat("Ж", 20), 5); !errors.As(err, &lengthErr) || lengthErr.Got != 20 {
+		t.Errorf("Expected 20 two-byte letters to be a wrong-length guess, got %v", err)
+	}
+
+	// Oversized input is rejected as such, by bytes and then by letters
+	if err := service.ValidateGuessLength(strings.Repeat("A", 100), 5); err == nil || !strings.Contains(err.Error(), "at most 64 characters") {
+		t.Errorf("Expected the byte limit to reject 100 bytes, got %v", err)
+	}
+	if err := service.ValidateGuessLength(strings.Repeat("A", 40), 5); err == nil || !strings.Contains(err.Error(), "at most 32 letters") {
+		t.Errorf("Expected the letter limit to reject 40 letters, got %v", err)
+	}
+	if errors.As(service.ValidateGuessLength(strings.Repeat("A", 40), 5), &lengthErr) {
+		t.Error("Expected an oversized guess not to be reported as the wrong length")
+	}
+
+	config.MaxGuessRuneLength = 8
+	if err := service.ValidateGuessLength(strings.Repeat("Ж", 9), 5); err == nil || !strings.Contains(err.Error(), "at most 8 letters") {
+		t.Errorf("Expected the configured letter limit to apply, got %v", err)
+	}
+}
+
+func TestGameServiceGetTimeline(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for _, word := range []string{"WORLD", "HELLO"} {
+		if _, err := service.MakeGuess(game.ID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+
+	events, err := service.GetTimeline(game.ID)
+	if err != nil {
+		t.Fatalf("GetTimeline should not return error: %v", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected creation, two guesses and completion, got %+v", events)
+	}
+	if events[0].Type != TimelineCreated || events[3].Type != TimelineCompleted {
+		t.Errorf("Expected timeline to start with creation and end with completion, got %+v", events)
+	}
+	if events[1].GuessNumber != 1 || events[2].GuessNumber != 2 {
+		t.Errorf("Expected guesses in order, got %d then %d", events[1].GuessNumber, events[2].GuessNumber)
+	}
+	for i := 1; i < len(events); i++ {
+		if events[i].At.Before(events[i-1].At) {
+			t.Errorf("Event %d is out of chronological order", i)
+		}
+	}
+
+	if _, err := service.GetTimeline("missing"); err == nil {
+		t.Error("Expected error for unknown game")
+	}
+}
+
+func TestCreateGameStoresWordDifficulty(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	difficulty, stored := gameRepo.difficulties[game.ID]
+	if !stored {
+		t.Fatal("Expected word difficulty to be stored for the new game")
+	}
+	if difficulty != service.WordDifficulty(game.TargetWord) {
+		t.Errorf("Expected stored difficulty %v to match the estimate %v", difficulty, service.WordDifficulty(game.TargetWord))
+	}
+	if difficulty < 0 || difficulty > 1 {
+		t.Errorf("Expected difficulty between 0 and 1, got %v", difficulty)
+	}
+}
+
+func TestCreateGameRollsBackWithoutDifficulty(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, NewMockWordList(), config)
+	transactor := &MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo}
+	service.SetTransactor(transactor)
+	gameRepo.shouldFailDifficulty = true
+
+	create := map[string]func() (*Game, error){
+		"random":    func() (*Game, error) { return service.CreateNewGame(0) },
+		"challenge": func() (*Game, error) { return service.CreateChallengeGame(42) },
+		"custom": func() (*Game, error) {
+			return service.CreateCustomChallenge(CustomChallenge{TargetWord: "HELLO"})
+		},
+	}
+	for name, fn := range create {
+		if _, err := fn(); err == nil {
+			t.Errorf("%s: expected error when the difficulty cannot be stored", name)
+		}
+	}
+
+	if len(gameRepo.games) != 0 {
+		t.Errorf("Expected no games without a difficulty, got %d", len(gameRepo.games))
+	}
+	if transactor.rollbacks != len(create) {
+		t.Errorf("Expected %d rollbacks, got %d", len(create), transactor.rollbacks)
+	}
+}
+
+// txCheckingWordList counts the target word lookups the difficulty
+// simulation makes while transactor has a transaction open
+type txCheckingWordList struct {
+	*MockWordList
+	transactor *MockTransactor
+	readsInTx  int
+}
+
+func (w *txCheckingWordList) TargetWordsOfLength(length int) []string {
+	if w.transactor.open {
+		w.readsInTx++
+	}
+	return w.MockWordList.TargetWordsOfLength(length)
+}
+
+func TestCreateGameEstimatesDifficultyBeforeTransaction(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, MaxBoards: 4, AllowBulkCreate: true}
+
+	transactor := &MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo}
+	wordList := &txCheckingWordList{MockWordList: NewMockWordList(), transactor: transactor}
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	service.SetTransactor(transactor)
+	service.SetTutorial(&Tutorial{TargetWord: "CRANE"})
+
+	var ids []string
+	for name, create := range map[string]func() (*Game, error){
+		"random":      func() (*Game, error) { return service.CreateNewGame(0) },
+		"challenge":   func() (*Game, error) { return service.CreateChallengeGame(42) },
+		"multi-board": func() (*Game, error) { return service.CreateMultiBoardGame(2, 0) },
+		"tutorial": func() (*Game, error) {
+			response, err := service.CreateTutorialGame()
+			if err != nil {
+				return nil, err
+			}
+			return &response.Game, nil
+		},
+		"with guess": func() (*Game, error) {
+			response, err := service.CreateNewGameWithGuess("WORLD")
+			if err != nil {
+				return nil, err
+			}
+			return &response.Game, nil
+		},
+	} {
+		game, err := create()
+		if err != nil {
+			t.Fatalf("%s: failed to create game: %v", name, err)
+		}
+		ids = append(ids, game.ID)
+	}
+	bulk, err := service.CreateGames(3)
+	if err != nil {
+		t.Fatalf("CreateGames should not return error: %v", err)
+	}
+	ids = append(ids, bulk...)
+
+	for _, id := range ids {
+		if _, ok := gameRepo.difficulties[id]; !ok {
+			t.Errorf("Expected a stored difficulty for game %s", id)
+		}
+	}
+	if wordList.readsInTx != 0 {
+		t.Errorf("Expected no difficulty simulation inside a transaction, got %d word list reads", wordList.readsInTx)
+	}
+
+	// A multi-board game is as hard as its hardest board
+	multi, err := service.CreateMultiBoardGame(3, 0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	hardest := 0.0
+	for _, board := range multi.Boards {
+		hardest = max(hardest, service.WordDifficulty(board.Target))
+	}
+	if gameRepo.difficulties[multi.ID] != hardest {
+		t.Errorf("Expected the hardest board's difficulty %v, got %v", hardest, gameRepo.difficulties[multi.ID])
+	}
+}
+
+func TestMakeGuessPartialCredit(t *testing.T) {
+	play := func(partialCredit bool, words []string) *GameResponse {
+		gameRepo := NewMockGameRepository()
+		guessRepo := NewMockGuessRepository()
+		wordList := NewMockWordList()
+		wordList.words = append(wordList.words, "CELLO")
+		config := &GameConfig{MaxGuesses: 2, WordLength: 5, PartialCredit: partialCredit}
+
+		service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+		game, err := gameRepo.CreateGame("", "HELLO", 2)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+
+		var response *GameResponse
+		for _, word := range words {
+			response, err = service.MakeGuessWithOptions(game.ID, word, GuessOptions{Delta: true})
+			if err != nil {
+				t.Fatalf("Failed to make guess %s: %v", word, err)
+			}
+		}
+		return response
+	}
+
+	// A near miss: WORLD then CELLO, four letters in place in the best row
+	response := play(true, []string{"WORLD", "CELLO"})
+	if response.PartialCredit == nil || *response.PartialCredit != 4 {
+		t.Fatalf("Expected partial credit of 4 for a near miss, got %v", response.PartialCredit)
+	}
+	if !strings.Contains(response.Message, "Partial credit: 4/5") {
+		t.Errorf("Expected message to report partial credit, got %q", response.Message)
+	}
+
+	// No letter of the target in either guess
+	response = play(true, []string{"QUICK", "QUICK"})
+	if response.PartialCredit == nil || *response.PartialCredit != 0 {
+		t.Errorf("Expected zero partial credit for an all-absent loss, got %v", response.PartialCredit)
+	}
+
+	// Wins and the default mode carry no partial credit
+	if response := play(true, []string{"HELLO"}); response.PartialCredit != nil {
+		t.Errorf("Expected no partial credit for a win, got %d", *response.PartialCredit)
+	}
+	if response := play(false, []string{"WORLD", "CELLO"}); response.PartialCredit != nil {
+		t.Errorf("Expected no partial credit outside partial credit mode, got %d", *response.PartialCredit)
+	}
+}
+
+func TestMakeGuessStallingRules(t *testing.T) {
+	newService := func(config *GameConfig) (*GameService, string) {
+		gameRepo := NewMockGameRepository()
+		wordList := NewMockWordList()
+		wordList.words = append(wordList.words, "LEAST", "STEAL")
+		service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), wordList, config)
+		game, err := gameRepo.CreateGame("", "HELLO", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		return service, game.ID
+	}
+
+	// Off by default
+	service, gameID := newService(&GameConfig{MaxGuesses: 6, WordLength: 5})
+	for _, word := range []string{"SLATE", "SLATE", "LEAST"} {
+		if _, err := service.MakeGuess(gameID, word); err != nil {
+			t.Fatalf("Expected %s to be accepted by default, got %v", word, err)
+		}
+	}
+
+	// Repeats only
+	service, gameID = newService(&GameConfig{MaxGuesses: 6, WordLength: 5, RejectRepeatGuesses: true})
+	if _, err := service.MakeGuess(gameID, "SLATE"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if _, err := service.MakeGuess(gameID, "slate"); err == nil || !strings.Contains(err.Error(), "already guessed") {
+		t.Errorf("Expected repeated guess to be rejected, got %v", err)
+	}
+	if _, err := service.MakeGuess(gameID, "LEAST"); err != nil {
+		t.Errorf("Expected rearrangement to be allowed without the anagram rule, got %v", err)
+	}
+
+	// Anagrams of any earlier guess
+	service, gameID = newService(&GameConfig{MaxGuesses: 6, WordLength: 5, RejectAnagramGuesses: true})
+	for _, word := range []string{"SLATE", "CRANE"} {
+		if _, err := service.MakeGuess(gameID, word); err != nil {
+			t.Fatalf("Failed to make guess %s: %v", word, err)
+		}
+	}
+	for _, word := range []string{"STEAL", "LEAST"} {
+		_, err := service.MakeGuess(gameID, word)
+		if err == nil || !strings.Contains(err.Error(), "only rearranges earlier guess 'SLATE'") {
+			t.Errorf("Expected %s to be rejected as a rearrangement, got %v", word, err)
+		}
+	}
+	if _, err := service.MakeGuess(gameID, "SLATE"); err == nil || !strings.Contains(err.Error(), "already guessed") {
+		t.Errorf("Expected repeat to be rejected by the anagram rule, got %v", err)
+	}
+	response, err := service.MakeGuess(gameID, "WORLD")
+	if err != nil {
+		t.Fatalf("Expected a fresh word to be accepted, got %v", err)
+	}
+	if response.Game.GuessCount != 3 {
+		t.Errorf("Expected rejected guesses not to count, got guess count %d", response.Game.GuessCount)
+	}
+}
+
+func TestMakeGuessUsesGameWordLength(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	wordList.words = append(wordList.words, "PLANET", "PLANES")
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	// A six-letter game on a server whose default is five letters
+	game, err := gameRepo.CreateGame("", "PLANET", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	if !service.ValidateWordForGame(game, "PLANES") {
+		t.Error("Expected a 6-letter word to be valid for a 6-letter game")
+	}
+	if service.ValidateWordForGame(game, "CRANE") {
+		t.Error("Expected a 5-letter word to be invalid for a 6-letter game")
+	}
+	if service.ValidateWord("PLANES") {
+		t.Error("Expected ValidateWord to keep using the configured default length")
+	}
+
+	if _, err := service.MakeGuess(game.ID, "CRANE"); err == nil || !strings.Contains(err.Error(), "must be 6 letters long") {
+		t.Errorf("Expected 5-letter guess to be rejected with the game's length, got %v", err)
+	}
+
+	response, err := service.MakeGuess(game.ID, "PLANES")
+	if err != nil {
+		t.Fatalf("Expected 6-letter guess to be accepted, got %v", err)
+	}
+	if response.Game.GuessCount != 1 || len(response.Guesses) != 1 || len(response.Guesses[0].Result) != 6 {
+		t.Errorf("Expected a stored 6-tile guess, got %+v", response)
+	}
+
+	possible, err := service.IsStillPossible(game.ID, "PLANET")
+	if err != nil {
+		t.Fatalf("Expected 6-letter candidate to be checked, got %v", err)
+	}
+	if !possible {
+		t.Error("Expected the target to still be possible")
+	}
+}
+
+func TestMakeGuessCountRemaining(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	wordList.words = []string{"HELLO", "HELPS", "HELMS", "CELLO", "WORLD", "CRANE", "QUICK"}
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Not computed unless requested
+	response, err := service.MakeGuess(game.ID, "QUICK")
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if response.RemainingValidWords != nil {
+		t.Errorf("Expected no remaining count without the option, got %d", *response.RemainingValidWords)
+	}
+
+	// QUICK rules out only its own letters
+	opts := GuessOptions{Delta: true, CountRemaining: true}
+	response, err = service.MakeGuessWithOptions(game.ID, "CRANE", opts)
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if response.RemainingValidWords == nil {
+		t.Fatal("Expected a remaining count when requested")
+	}
+	// E present but not last, C, R, A and N absent: HELLO, HELPS and HELMS remain
+	if *response.RemainingValidWords != 3 {
+		t.Errorf("Expected 3 remaining words after CRANE, got %d", *response.RemainingValidWords)
+	}
+
+	response, err = service.MakeGuessWithOptions(game.ID, "HELPS", opts)
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if *response.RemainingValidWords != 1 {
+		t.Errorf("Expected the count to shrink to 1 after HELPS, got %d", *response.RemainingValidWords)
+	}
+}
+
+func TestMakeGuessFailedGameUpdateLeavesNoOrphanGuess(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	transactor := &MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo}
+	service.SetTransactor(transactor)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	gameRepo.shouldFailSave = true
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err == nil {
+		t.Fatal("Expected the failed game update to be reported")
+	}
+
+	if len(guessRepo.guesses[game.ID]) != 0 {
+		t.Errorf("Expected the guess to be rolled back, got %d stored guesses", len(guessRepo.guesses[game.ID]))
+	}
+	if transactor.commits != 0 || transactor.rollbacks != 1 {
+		t.Errorf("Expected no commits and 1 rollback, got %d and %d", transactor.commits, transactor.rollbacks)
+	}
+}
+
+func TestMakeGuessFailedGuessWriteLeavesGameUnchanged(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	transactor := &MockTransactor{gameRepo: gameRepo, guessRepo: guessRepo}
+	service.SetTransactor(transactor)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+
+	guessRepo.shouldFailSave = true
+	if _, err := service.MakeGuess(game.ID, "CRANE"); err == nil || !strings.Contains(err.Error(), "failed to save guess") {
+		t.Fatalf("Expected the failed guess write to be reported, got %v", err)
+	}
+
+	stored, err := gameRepo.GetGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if stored.GuessCount != 1 {
+		t.Errorf("Expected guess count to stay at 1, got %d", stored.GuessCount)
+	}
+	if len(guessRepo.guesses[game.ID]) != 1 {
+		t.Errorf("Expected 1 stored guess, got %d", len(guessRepo.guesses[game.ID]))
+	}
+	if transactor.commits != 1 || transactor.rollbacks != 1 {
+		t.Errorf("Expected 1 commit and 1 rollback, got %d and %d", transactor.commits, transactor.rollbacks)
+	}
+
+	// The guess is written before the game update fails, so only rolling
+	// back discards it
+	guessRepo.shouldFailSave = false
+	gameRepo.shouldFailUpdate = true
+	if _, err := service.MakeGuess(game.ID, "CRANE"); err == nil || !strings.Contains(err.Error(), "failed to update game") {
+		t.Fatalf("Expected the failed game update to be reported, got %v", err)
+	}
+	if got := len(guessRepo.guesses[game.ID]); got != 1 {
+		t.Errorf("Expected the guess to be rolled back with the game update, got %d stored guesses", got)
+	}
+	if transactor.commits != 1 || transactor.rollbacks != 2 {
+		t.Errorf("Expected 1 commit and 2 rollbacks, got %d and %d", transactor.commits, transactor.rollbacks)
+	}
+
+	// The game still accepts guesses once writes recover
+	gameRepo.shouldFailUpdate = false
+	response, err := service.MakeGuess(game.ID, "CRANE")
+	if err != nil {
+		t.Fatalf("Expected guess to succeed after recovery, got %v", err)
+	}
+	if response.Game.GuessCount != 2 {
+		t.Errorf("Expected guess count 2, got %d", response.Game.GuessCount)
+	}
+	if gameRepo.lockedReads != 4 {
+		t.Errorf("Expected every guess to lock the game it updates, got %d locked reads", gameRepo.lockedReads)
+	}
+}
+
+func TestMakeGuessRechecksGameUnderLock(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, NewMockWordList(), config)
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// A concurrent guess that wins the game between validation and the
+	// locked read leaves nothing to record
+	guessWord, err := service.validateGuess(gameRepo, guessRepo, game.ID, "world", GuessOptions{})
+	if err != nil {
+		t.Fatalf("Expected guess to validate, got %v", err)
+	}
+	if _, err := service.MakeGuess(game.ID, "HELLO"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if _, _, err := service.recordGuess(gameRepo, guessRepo, game.ID, guessWord); err == nil || !strings.Contains(err.Error(), "already completed") {
+		t.Errorf("Expected the completed game to be rechecked, got %v", err)
+	}
+	if got := len(guessRepo.guesses[game.ID]); got != 1 {
+		t.Errorf("Expected only the winning guess, got %d", got)
+	}
+}
+
+func TestGameServiceGetWordGameOutcomes(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	start := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	seed := func(word string, age time.Duration, completed, won bool) *Game {
+		game, err := gameRepo.CreateGame("", word, 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		stored := gameRepo.games[game.ID]
+		stored.CreatedAt = start.Add(-age)
+		stored.IsCompleted, stored.IsWon = completed, won
+		if completed {
+			completedAt := stored.CreatedAt.Add(time.Minute)
+			stored.CompletedAt = &completedAt
+			stored.GuessCount = 4
+		}
+		return stored
+	}
+
+	lost := seed("CRANE", 3*time.Hour, true, false)
+	won := seed("CRANE", 2*time.Hour, true, true)
+	playing := seed("CRANE", time.Hour, false, false)
+	seed("SLATE", 0, true, true)
+
+	outcomes, err := service.GetWordGameOutcomes(" crane ", 0)
+	if err != nil {
+		t.Fatalf("GetWordGameOutcomes should not return error: %v", err)
+	}
+
+	want := []struct {
+		id      string
+		outcome string
+	}{
+		{playing.ID, OutcomeInProgress},
+		{won.ID, OutcomeWon},
+		{lost.ID, OutcomeLost},
+	}
+	if len(outcomes) != len(want) {
+		t.Fatalf("Expected %d games for CRANE, got %+v", len(want), outcomes)
+	}
+	for i, w := range want {
+		if outcomes[i].ID != w.id || outcomes[i].Outcome != w.outcome {
+			t.Errorf("Game %d: expected %s %s, got %s %s", i, w.id, w.outcome, outcomes[i].ID, outcomes[i].Outcome)
+		}
+	}
+	if outcomes[0].CompletedAt != nil {
+		t.Error("Expected no completion time for the game in progress")
+	}
+	if outcomes[1].CompletedAt == nil || !outcomes[1].CompletedAt.Equal(*won.CompletedAt) || !outcomes[1].CreatedAt.Equal(won.CreatedAt) {
+		t.Errorf("Expected timestamps of the won game, got %+v", outcomes[1])
+	}
+
+	if _, err := service.GetWordGameOutcomes("  ", 0); err == nil {
+		t.Error("Expected error for empty word")
+	}
+}
+
+func TestMakeGuessBestProgress(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	wordList.words = append(wordList.words, "CELLO", "HELPS")
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	steps := []struct {
+		word string
+		best float64
+	}{
+		{"HELPS", 60}, // H, E, L in place
+		{"CELLO", 80}, // E, L, L, O in place
+		{"QUICK", 80}, // nothing in place; the best is kept
+	}
+	for _, step := range steps {
+		response, err := service.MakeGuessWithOptions(game.ID, step.word, GuessOptions{Delta: true})
+		if err != nil {
+			t.Fatalf("Failed to make guess %s: %v", step.word, err)
+		}
+		if response.BestProgress == nil || *response.BestProgress != step.best {
+			t.Errorf("After %s: expected best progress %v, got %v", step.word, step.best, response.BestProgress)
+		}
+	}
+}
+
+func TestGameServiceWordNeighbors(t *testing.T) {
+	wordList := NewMockWordList()
+	wordList.words = []string{"crane", "crate", "grace", "brane", "crone", "cranes", "trace", "craze"}
+	service := NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), wordList, &GameConfig{MaxGuesses: 6, WordLength: 5})
+
+	neighbors, err := service.WordNeighbors(" Crane ")
+	if err != nil {
+		t.Fatalf("Failed to get neighbors: %v", err)
+	}
+	expected := []string{"BRANE", "CRATE", "CRAZE", "CRONE"}
+	if strings.Join(neighbors, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected neighbors %v, got %v", expected, neighbors)
+	}
+	for _, word := range neighbors {
+		if word == "CRANE" {
+			t.Error("Expected the word itself to be excluded")
+		}
+	}
+
+	if neighbors, err := service.WordNeighbors("zzzzz"); err != nil || len(neighbors) != 0 {
+		t.Errorf("Expected no neighbors for an isolated word, got %v (err %v)", neighbors, err)
+	}
+	for _, word := range []string{"", "cr4ne"} {
+		if _, err := service.WordNeighbors(word); err == nil {
+			t.Errorf("Expected error for %q", word)
+		}
+	}
+}
+
+func TestGameServiceGameModes(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	answerRepo := NewMockAnswerRepository()
+	answerRepo.answers["2024-03-01"] = "slate"
+	service.SetAnswerRepository(answerRepo)
+
+	practice, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create practice game: %v", err)
+	}
+	daily, err := service.CreateOrGetDailyGame("", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Failed to create daily game: %v", err)
+	}
+	challenge, err := service.CreateChallengeGame(42)
+	if err != nil {
+		t.Fatalf("Failed to create challenge game: %v", err)
+	}
+
+	for _, tt := range []struct {
+		game *Game
+		mode string
+	}{
+		{practice, GameModePractice},
+		{daily, GameModeDaily},
+		{challenge, GameModeChallenge},
+	} {
+		if tt.game.Mode != tt.mode {
+			t.Errorf("Expected game %s to have mode %s, got %q", tt.game.ID, tt.mode, tt.game.Mode)
+		}
+
+		games, err := service.GetRecentGamesByMode(tt.mode, 10)
+		if err != nil {
+			t.Fatalf("Failed to list %s games: %v", tt.mode, err)
+		}
+		if len(games) != 1 || games[0].ID != tt.game.ID {
+			t.Errorf("Expected only game %s in mode %s, got %v", tt.game.ID, tt.mode, games)
+		}
+	}
+
+	// The same seed always picks the same word
+	again, err := service.CreateChallengeGame(42)
+	if err != nil {
+		t.Fatalf("Failed to create challenge game: %v", err)
+	}
+	if again.TargetWord != challenge.TargetWord {
+		t.Errorf("Expected seed 42 to pick %s again, got %s", challenge.TargetWord, again.TargetWord)
+	}
+
+	if all, err := service.GetRecentGamesByMode("", 10); err != nil || len(all) != 4 {
+		t.Errorf("Expected no mode to list all 4 games, got %d (err %v)", len(all), err)
+	}
+	if _, err := service.GetRecentGamesByMode("ranked", 10); err == nil || !strings.Contains(err.Error(), "invalid mode") {
+		t.Errorf("Expected invalid mode error, got %v", err)
+	}
+}
+
+func TestGameServiceGetGamesWonInGuesses(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+
+	seed := func(guessCount int, won bool) *Game {
+		game, err := gameRepo.CreateGame("", "CRANE", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		game.GuessCount, game.IsCompleted, game.IsWon = guessCount, true, won
+		return game
+	}
+	twoGuessWins := map[string]bool{seed(2, true).ID: true, seed(2, true).ID: true}
+	seed(3, true)
+	seed(1, true)
+	seed(2, false) // a loss with two guesses is not a win
+	seed(6, true)
+
+	games, err := service.GetGamesWonInGuesses(2, 10)
+	if err != nil {
+		t.Fatalf("GetGamesWonInGuesses should not return error: %v", err)
+	}
+	if len(games) != len(twoGuessWins) {
+		t.Fatalf("Expected %d games, got %d", len(twoGuessWins), len(games))
+	}
+	for _, game := range games {
+		if !twoGuessWins[game.ID] {
+			t.Errorf("Unexpected game %s (won=%v, guesses=%d)", game.ID, game.IsWon, game.GuessCount)
+		}
+	}
+
+	if _, err := service.GetGamesWonInGuesses(0, 10); err == nil {
+		t.Error("Expected error for zero guesses")
+	}
+}
+
+// racingDailyRepository misses the player's daily game on the first lookup, as
+// if a concurrent request created it between the lookup and the insert
+type racingDailyRepository struct {
+	*MockGameRepository
+	misses int
+}
+
+func (r *racingDailyRepository) GetPlayerDailyGame(playerID string, date time.Time) (*Game, error) {
+	if r.misses > 0 {
+		r.misses--
+		return nil, fmt.Errorf("daily game not found for player %s: %s", playerID, dateKey(date))
+	}
+	return r.MockGameRepository.GetPlayerDailyGame(playerID, date)
+}
+
+func TestGameServiceCreateOrGetPlayerDailyGame(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, DailyGamePerPlayer: true}
+
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	answerRepo := NewMockAnswerRepository()
+	answerRepo.answers["2024-03-01"] = "slate"
+	service.SetAnswerRepository(answerRepo)
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	first, err := service.CreateOrGetDailyGame("player-1", day)
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if first.PlayerID == nil || *first.PlayerID != "player-1" || first.Mode != GameModeDaily {
+		t.Errorf("Expected a daily game owned by player-1, got %+v", first)
+	}
+
+	second, err := service.CreateOrGetDailyGame("player-1", day.Add(5*time.Hour))
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("Expected the same daily game %s for the same player and date, got %s", first.ID, second.ID)
+	}
+
+	// Other players and the shared daily game are separate
+	other, err := service.CreateOrGetDailyGame("player-2", day)
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	shared, err := service.CreateOrGetDailyGame("", day)
+	if err != nil {
+		t.Fatalf("CreateOrGetDailyGame should not return error: %v", err)
+	}
+	if other.ID == first.ID || shared.ID == first.ID || shared.ID == other.ID || shared.PlayerID != nil {
+		t.Errorf("Expected distinct games per player and a shared game, got %s, %s, %s", first.ID, other.ID, shared.ID)
+	}
+
+	// Losing a creation race returns the game the other request created
+	racing := &racingDailyRepository{MockGameRepository: gameRepo, misses: 1}
+	service = NewGameServiceWithInterfaces(racing, guessRepo, wordList, config)
+	service.SetAnswerRepository(answerRepo)
+	raced, err := service.CreateOrGetDailyGame("player-1", day)
+	if err != nil {
+		t.Fatalf("Expected the unique violation to be handled, got %v", err)
+	}
+	if raced.ID != first.ID {
+		t.Errorf("Expected existing game %s after the race, got %s", first.ID, raced.ID)
+	}
+
+	// Disabled, players share the daily game
+	config.DailyGamePerPlayer = false
+	if game, err := service.CreateOrGetDailyGame("player-3", day); err != nil || game.ID != shared.ID {
+		t.Errorf("Expected the shared daily game %s when disabled, got %v (err %v)", shared.ID, game, err)
+	}
+}
+
+func TestGameServiceSuggestNextSeed(t *testing.T) {
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	wordList := NewMockWordList() // HELLO, WORLD, CRANE, SLATE, AUDIO, QUICK, BROWN
 	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
 
 	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
 
-	_, err := service.CreateNewGame()
-	if err == nil {
-		t.Error("Expected error when no words available")
+	// The player has played every word but one
+	played := map[string]bool{}
+	for _, word := range []string{"HELLO", "WORLD", "CRANE", "SLATE", "AUDIO", "QUICK"} {
+		game, err := gameRepo.CreateGame("", word, 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		gameRepo.playerGames[game.ID] = "player-1"
+		played[word] = true
 	}
-	if !strings.Contains(err.Error(), "no five-letter words available") {
-		t.Errorf("Expected specific error message, got: %v", err)
+
+	for i := 0; i < 20; i++ {
+		seed, err := service.SuggestNextSeed("player-1")
+		if err != nil {
+			t.Fatalf("SuggestNextSeed should not return error: %v", err)
+		}
+		game, err := service.CreateChallengeGame(seed)
+		if err != nil {
+			t.Fatalf("Failed to create challenge game from seed %d: %v", seed, err)
+		}
+		if played[game.TargetWord] {
+			t.Fatalf("Seed %d picked already played word %s", seed, game.TargetWord)
+		}
+		// Challenge games aren't linked to the player, so BROWN stays unplayed
+		delete(gameRepo.games, game.ID)
+	}
+
+	// Once everything is played there is nothing to suggest
+	brown, _ := gameRepo.CreateGame("", "BROWN", 6)
+	gameRepo.playerGames[brown.ID] = "player-1"
+	if _, err := service.SuggestNextSeed("player-1"); err == nil || !strings.Contains(err.Error(), "no unplayed") {
+		t.Errorf("Expected no unplayed words error, got %v", err)
 	}
 }
 
-func TestGameServiceMakeGuessValid(t *testing.T) {
+func TestGameServiceHintLimit(t *testing.T) {
 	gameRepo := NewMockGameRepository()
 	guessRepo := NewMockGuessRepository()
 	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, MaxHintsPerGame: 2}
 
 	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
 
-	// Create a game first
-	game, err := service.CreateNewGame()
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
 
-	// Make a valid guess
-	response, err := service.MakeGuess(game.ID, "WORLD")
-	if err != nil {
-		t.Fatalf("MakeGuess should not return error: %v", err)
+	for _, expected := range []int{1, 0} {
+		nudge, err := service.GetNudge(game.ID)
+		if err != nil {
+			t.Fatalf("GetNudge should not return error: %v", err)
+		}
+		if nudge.HintsRemaining == nil || *nudge.HintsRemaining != expected {
+			t.Errorf("Expected %d hints remaining, got %v", expected, nudge.HintsRemaining)
+		}
+	}
+	if _, err := service.GetNudge(game.ID); err == nil || !strings.Contains(err.Error(), "hint limit reached") {
+		t.Errorf("Expected hint limit error, got %v", err)
+	}
+	if gameRepo.games[game.ID].HintsUsed != 2 {
+		t.Errorf("Expected refused hints not to be counted, got %d used", gameRepo.games[game.ID].HintsUsed)
 	}
 
-	if response.Game.GuessCount != 1 {
-		t.Errorf("Expected guess count 1, got %d", response.Game.GuessCount)
+	// A per-game limit overrides the configured one, and 0 is unlimited
+	override, _ := gameRepo.CreateGame("", "HELLO", 6)
+	if err := service.SetMaxHints(override, 0); err != nil {
+		t.Fatalf("SetMaxHints should not return error: %v", err)
 	}
-	if response.Game.IsWon {
-		t.Error("Game should not be won with incorrect guess")
+	if _, err := service.MakeGuess(override.ID, "WORLD"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
 	}
-	if response.Game.IsCompleted {
-		t.Error("Game should not be completed after one guess")
+	for i := 0; i < 3; i++ {
+		nudge, err := service.GetNudge(override.ID)
+		if err != nil {
+			t.Fatalf("Expected unlimited hints, got %v", err)
+		}
+		if nudge.HintsRemaining != nil {
+			t.Errorf("Expected no remaining count for unlimited hints, got %d", *nudge.HintsRemaining)
+		}
 	}
-	if !strings.Contains(response.Message, "5 guess(es) remaining") {
-		t.Errorf("Expected remaining guesses message, got: %s", response.Message)
+	if err := service.SetMaxHints(override, -1); err == nil {
+		t.Error("Expected error for a negative hint limit")
 	}
 }
 
-func TestGameServiceMakeGuessWinning(t *testing.T) {
+func TestGameServiceResolvePlayer(t *testing.T) {
 	gameRepo := NewMockGameRepository()
-	guessRepo := NewMockGuessRepository()
-	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	playerRepo := NewMockPlayerRepository()
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, AutoCreatePlayers: true}
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), config)
+	service.SetPlayerRepository(playerRepo)
 
-	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	// The first game with a new username creates the player
+	first, created, err := service.ResolvePlayer("  alice ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !created || first.Username != "alice" || first.ID == "" {
+		t.Errorf("Expected new player alice, got %+v (created %v)", first, created)
+	}
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := service.AssignPlayer(game.ID, first.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gameRepo.playerGames[game.ID] != first.ID {
+		t.Errorf("Expected game to belong to %s, got %q", first.ID, gameRepo.playerGames[game.ID])
+	}
 
-	// Create a game
-	game, err := service.CreateNewGame()
+	// A second game reuses the player
+	second, created, err := service.ResolvePlayer("alice")
 	if err != nil {
-		t.Fatalf("Failed to create game: %v", err)
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created || second.ID != first.ID {
+		t.Errorf("Expected existing player %s, got %+v (created %v)", first.ID, second, created)
+	}
+	if playerRepo.created != 1 {
+		t.Errorf("Expected 1 player created, got %d", playerRepo.created)
 	}
 
-	// Make winning guess (same as target word)
-	response, err := service.MakeGuess(game.ID, "HELLO")
+	// Losing the race to create a username returns the winner's player
+	playerRepo.raceOnce = true
+	raced, created, err := service.ResolvePlayer("bob")
 	if err != nil {
-		t.Fatalf("MakeGuess should not return error: %v", err)
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created || raced.ID != "racer" {
+		t.Errorf("Expected the concurrently created player, got %+v (created %v)", raced, created)
 	}
 
-	if response.Game.GuessCount != 1 {
-		t.Errorf("Expected guess count 1, got %d", response.Game.GuessCount)
+	// Invalid usernames are rejected
+	for _, username := range []string{"   ", strings.Repeat("a", maxUsernameLength+1)} {
+		if _, _, err := service.ResolvePlayer(username); err == nil || !strings.Contains(err.Error(), "username") {
+			t.Errorf("Expected username error for %q, got %v", username, err)
+		}
 	}
-	if !response.Game.IsWon {
-		t.Error("Game should be won with correct guess")
+
+	// Disabled, only existing players are found
+	config.AutoCreatePlayers = false
+	if _, _, err := service.ResolvePlayer("carol"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
 	}
-	if !response.Game.IsCompleted {
-		t.Error("Game should be completed when won")
+	if _, ok := playerRepo.players["carol"]; ok {
+		t.Error("Expected no player to be created when disabled")
 	}
-	if !strings.Contains(response.Message, "Congratulations") {
-		t.Errorf("Expected congratulations message, got: %s", response.Message)
+	if _, _, err := service.ResolvePlayer("alice"); err != nil {
+		t.Errorf("Expected existing player when disabled, got %v", err)
+	}
+
+	// Without a player repository there is nothing to resolve
+	service.SetPlayerRepository(nil)
+	if _, _, err := service.ResolvePlayer("alice"); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("Expected not configured error, got %v", err)
 	}
 }
 
-func TestGameServiceMakeGuessInvalidWord(t *testing.T) {
-	gameRepo := NewMockGameRepository()
-	guessRepo := NewMockGuessRepository()
-	wordList := NewMockWordList()
+func TestGameServiceRevealedAnagrams(t *testing.T) {
+	wordList := &MockWordList{words: []string{"CRANE", "NACRE", "CANER", "HELLO", "SLATE"}}
 	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	service := NewGameServiceWithInterfaces(NewMockGameRepository(), NewMockGuessRepository(), wordList, config)
 
-	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	crane := &Game{TargetWord: "CRANE", IsCompleted: true, MaxGuesses: 6}
+	if anagrams := service.RevealedAnagrams(crane); anagrams != nil {
+		t.Errorf("Expected no anagrams while disabled, got %v", anagrams)
+	}
 
-	// Create a game
-	game, err := service.CreateNewGame()
-	if err != nil {
-		t.Fatalf("Failed to create game: %v", err)
+	config.RevealAnagrams = true
+	anagrams := service.RevealedAnagrams(crane)
+	if len(anagrams) != 2 || anagrams[0] != "CANER" || anagrams[1] != "NACRE" {
+		t.Errorf("Expected [CANER NACRE], got %v", anagrams)
 	}
 
-	// Try invalid word
-	_, err = service.MakeGuess(game.ID, "ZZZZZ")
-	if err == nil {
-		t.Error("Expected error for invalid word")
+	hello := &Game{TargetWord: "HELLO", IsCompleted: true, MaxGuesses: 6}
+	if anagrams := service.RevealedAnagrams(hello); anagrams == nil || len(anagrams) != 0 {
+		t.Errorf("Expected an empty list for HELLO, got %#v", anagrams)
 	}
-	if !strings.Contains(err.Error(), "not a valid word") {
-		t.Errorf("Expected specific error message, got: %v", err)
+
+	// Games in progress would give the answer away
+	crane.IsCompleted = false
+	if anagrams := service.RevealedAnagrams(crane); anagrams != nil {
+		t.Errorf("Expected no anagrams before the game ends, got %v", anagrams)
 	}
 }
 
-func TestGameServiceMakeGuessWrongLength(t *testing.T) {
+func TestMakeGuessRevealsAnagramsOnCompletion(t *testing.T) {
 	gameRepo := NewMockGameRepository()
-	guessRepo := NewMockGuessRepository()
-	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
-
-	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	wordList := &MockWordList{words: []string{"CRANE", "NACRE", "CANER", "HELLO"}}
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), wordList, &GameConfig{MaxGuesses: 6, WordLength: 5, RevealAnagrams: true})
 
-	// Create a game
-	game, err := service.CreateNewGame()
+	game, err := service.CreateNewGame(0)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
 
-	// Try wrong length word
-	_, err = service.MakeGuess(game.ID, "HI")
-	if err == nil {
-		t.Error("Expected error for wrong length word")
+	response, err := service.MakeGuess(game.ID, "HELLO")
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
 	}
-	if !strings.Contains(err.Error(), "must be 5 letters long") {
-		t.Errorf("Expected specific error message, got: %v", err)
+	if response.Anagrams != nil {
+		t.Errorf("Expected no anagrams before the game ends, got %v", response.Anagrams)
+	}
+
+	response, err = service.MakeGuess(game.ID, game.TargetWord)
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if !response.Game.IsCompleted || len(response.Anagrams) != 2 {
+		t.Errorf("Expected the target's two anagrams once won, got %v", response.Anagrams)
 	}
 }
 
-func TestGameServiceMakeGuessGameNotFound(t *testing.T) {
+func TestGameServiceRankGuesses(t *testing.T) {
 	gameRepo := NewMockGameRepository()
-	guessRepo := NewMockGuessRepository()
-	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	wordList := &MockWordList{words: []string{"WATCH", "BATCH", "CATCH", "HATCH", "LATCH", "MATCH", "PATCH", "CHAMP", "BLOWN", "SLATE"}}
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), wordList, &GameConfig{MaxGuesses: 6, WordLength: 5})
 
-	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	// SLATE against WATCH rules out L, so LATCH, and leaves six ?ATCH candidates
+	if _, err := service.MakeGuess(game.ID, "SLATE"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
 
-	// Try to make guess on non-existent game
-	_, err := service.MakeGuess("nonexistent", "HELLO")
-	if err == nil {
-		t.Error("Expected error for non-existent game")
+	ranking, err := service.RankGuesses(game.ID, 0)
+	if err != nil {
+		t.Fatalf("RankGuesses failed: %v", err)
 	}
-	if !strings.Contains(err.Error(), "failed to get game") {
-		t.Errorf("Expected specific error message, got: %v", err)
+	if ranking.Candidates != 6 {
+		t.Errorf("Expected 6 candidates, got %d", ranking.Candidates)
+	}
+	expected := []string{"CHAMP", "BLOWN", "BATCH", "CATCH", "HATCH", "MATCH", "PATCH", "WATCH", "LATCH", "SLATE"}
+	if len(ranking.Guesses) != len(expected) {
+		t.Fatalf("Expected %d ranked guesses, got %+v", len(expected), ranking.Guesses)
+	}
+	for i, guess := range expected {
+		if ranking.Guesses[i].Guess != guess {
+			t.Errorf("Rank %d: expected %s, got %s", i+1, guess, ranking.Guesses[i].Guess)
+		}
+	}
+	if top := ranking.Guesses[0]; top.ExpectedRemaining != 2 || top.Candidate {
+		t.Errorf("Expected CHAMP to leave 2 candidates on average, got %+v", top)
+	}
+
+	if ranking, err := service.RankGuesses(game.ID, 3); err != nil || len(ranking.Guesses) != 3 {
+		t.Errorf("Expected the top 3 guesses, got %+v, %v", ranking, err)
+	}
+
+	if _, err := service.MakeGuess(game.ID, "WATCH"); err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if _, err := service.RankGuesses(game.ID, 0); err == nil || !strings.Contains(err.Error(), "already completed") {
+		t.Errorf("Expected already completed error, got %v", err)
+	}
+	if _, err := service.RankGuesses("missing", 0); err == nil {
+		t.Error("Expected error for a missing game")
 	}
 }
 
-func TestGameServiceMakeGuessGameCompleted(t *testing.T) {
+func TestGameServiceMultiBoardGame(t *testing.T) {
 	gameRepo := NewMockGameRepository()
 	guessRepo := NewMockGuessRepository()
 	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, MaxBoards: 4}
 
 	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
 
-	// Create and complete a game
-	game, err := service.CreateNewGame()
+	game, err := gameRepo.CreateMultiBoardGame("", []string{"CRANE", "SLATE"}, 7)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
 
-	// Manually mark game as completed
-	game.IsCompleted = true
-	gameRepo.UpdateGame(game)
-
-	// Try to make guess on completed game
-	_, err = service.MakeGuess(game.ID, "WORLD")
-	if err == nil {
-		t.Error("Expected error for completed game")
+	// A miss returns a result set per board
+	response, err := service.MakeGuess(game.ID, "AUDIO")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
 	}
-	if !strings.Contains(err.Error(), "already completed") {
-		t.Errorf("Expected specific error message, got: %v", err)
+	if len(response.BoardResults) != 2 {
+		t.Fatalf("Expected 2 board results, got %+v", response.BoardResults)
+	}
+	for i, target := range []string{"CRANE", "SLATE"} {
+		expected := BoardResult{Board: i, Result: EvaluateGuess("AUDIO", target)}
+		if !reflect.DeepEqual(response.BoardResults[i], expected) {
+			t.Errorf("Expected board %d result %+v, got %+v", i, expected, response.BoardResults[i])
+		}
 	}
-}
-
-func TestGameServiceValidateWord(t *testing.T) {
-	gameRepo := NewMockGameRepository()
-	guessRepo := NewMockGuessRepository()
-	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
 
-	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	// Solving one board doesn't win the game
+	response, err = service.MakeGuess(game.ID, "SLATE")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if response.Game.IsCompleted || response.Game.IsWon {
+		t.Error("Game should continue until every board is solved")
+	}
+	if response.BoardResults[0].Solved || !response.BoardResults[1].Solved {
+		t.Errorf("Expected only the second board solved, got %+v", response.BoardResults)
+	}
+	if response.BoardResults[1].Result.Progress() != 100 {
+		t.Errorf("Expected an all-correct result on the solved board, got %+v", response.BoardResults[1].Result)
+	}
 
-	// Test valid word
-	if !service.ValidateWord("HELLO") {
-		t.Error("Expected 'HELLO' to be valid")
+	// Every guess carries its results against each board as they stood then,
+	// and each board keeps its own keyboard
+	if len(response.Guesses) != 2 || len(response.Guesses[0].BoardResults) != 2 || response.Guesses[0].BoardResults[1].Solved {
+		t.Errorf("Expected unsolved board results for the first guess, got %+v", response.Guesses)
+	}
+	if !reflect.DeepEqual(response.Guesses[1].BoardResults, response.BoardResults) {
+		t.Errorf("Expected the new guess's board results %+v, got %+v", response.BoardResults, response.Guesses[1].BoardResults)
+	}
+	if len(response.BoardKeyboards) != 2 || response.BoardKeyboards[0]["L"] != "absent" || response.BoardKeyboards[1]["L"] != "correct" {
+		t.Errorf("Expected L absent on the first board and correct on the second, got %+v", response.BoardKeyboards)
 	}
 
-	// Test invalid word
-	if service.ValidateWord("ZZZZZ") {
-		t.Error("Expected 'ZZZZZ' to be invalid")
+	// Fetching the game derives the same from the stored guesses
+	guesses, err := guessRepo.GetGuessesByGameID(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get guesses: %v", err)
+	}
+	fetched := GameResponse{Game: response.Game, Guesses: guesses}
+	service.AddBoards(&fetched)
+	if !reflect.DeepEqual(fetched.Guesses, response.Guesses) || !reflect.DeepEqual(fetched.BoardKeyboards, response.BoardKeyboards) {
+		t.Errorf("Expected the guess response's boards, got %+v and %+v", fetched.Guesses, fetched.BoardKeyboards)
 	}
 
-	// Test wrong length
-	if service.ValidateWord("HI") {
-		t.Error("Expected 'HI' to be invalid due to length")
+	// Solving the other board completes it
+	response, err = service.MakeGuess(game.ID, "CRANE")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if !response.Game.IsCompleted || !response.Game.IsWon {
+		t.Errorf("Expected the game won once both boards are solved, got %+v", response.Game)
+	}
+	expectedBoards := Boards{{Target: "CRANE", SolvedAt: 3}, {Target: "SLATE", SolvedAt: 2}}
+	if !reflect.DeepEqual(response.Game.Boards, expectedBoards) {
+		t.Errorf("Expected boards %+v, got %+v", expectedBoards, response.Game.Boards)
 	}
 
-	// Test with whitespace
-	if !service.ValidateWord(" HELLO ") {
-		t.Error("Expected 'HELLO' with whitespace to be valid")
+	// Running out of guesses with a board unsolved loses
+	lost, err := gameRepo.CreateMultiBoardGame("", []string{"CRANE", "SLATE"}, 2)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if _, err := service.MakeGuess(lost.ID, "CRANE"); err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	response, err = service.MakeGuess(lost.ID, "WORLD")
+	if err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if !response.Game.IsLoss || !strings.Contains(response.Message, "CRANE, SLATE") {
+		t.Errorf("Expected a loss naming both words, got %+v: %s", response.Game, response.Message)
 	}
 }
 
-func TestGameServiceGetGameStats(t *testing.T) {
+func TestGameServiceCreateMultiBoardGame(t *testing.T) {
 	gameRepo := NewMockGameRepository()
 	guessRepo := NewMockGuessRepository()
 	wordList := NewMockWordList()
 	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
 
 	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	if _, err := service.CreateMultiBoardGame(2, 0); !errors.Is(err, ErrMultiBoardDisabled) {
+		t.Errorf("Expected multi-board games to be disabled without MaxBoards, got %v", err)
+	}
 
-	stats, err := service.GetGameStats()
+	config.MaxBoards = 4
+	game, err := service.CreateMultiBoardGame(3, 0)
 	if err != nil {
-		t.Fatalf("GetGameStats should not return error: %v", err)
+		t.Fatalf("CreateMultiBoardGame should not return error: %v", err)
 	}
-
-	expectedStats := map[string]interface{}{
-		"total_words":        7, // From mock word list
-		"five_letter_words":  7,
-		"max_guesses":       6,
-		"word_length":       5,
+	if len(game.Boards) != 3 || game.TargetWord != game.Boards[0].Target {
+		t.Errorf("Expected 3 boards led by the target word, got %+v", game)
+	}
+	seen := make(map[string]bool)
+	for _, board := range game.Boards {
+		if seen[board.Target] {
+			t.Errorf("Expected distinct targets, got %+v", game.Boards)
+		}
+		seen[board.Target] = true
+	}
+	if game.MaxGuesses != 8 {
+		t.Errorf("Expected 6 guesses plus one per extra board, got %d", game.MaxGuesses)
 	}
 
-	for key, expected := range expectedStats {
-		if stats[key] != expected {
-			t.Errorf("Expected %s to be %v, got %v", key, expected, stats[key])
+	if game, err := service.CreateMultiBoardGame(2, 5); err != nil || game.MaxGuesses != 5 {
+		t.Errorf("Expected a requested 5 guesses, got %+v, %v", game, err)
+	}
+	for _, boards := range []int{1, 5} {
+		if _, err := service.CreateMultiBoardGame(boards, 0); !errors.Is(err, ErrBoardCount) || !strings.Contains(err.Error(), "boards must be between 2 and 4") {
+			t.Errorf("Expected a boards range error for %d, got %v", boards, err)
 		}
 	}
 }
 
-func TestGameServiceGetRecentGames(t *testing.T) {
+func TestGameServiceCreateNewGameMaxGuesses(t *testing.T) {
 	gameRepo := NewMockGameRepository()
-	guessRepo := NewMockGuessRepository()
-	wordList := NewMockWordList()
-	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), &GameConfig{MaxGuesses: 6, WordLength: 5})
 
-	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, wordList, config)
+	game, err := service.CreateNewGame(0)
+	if err != nil || game.MaxGuesses != 6 {
+		t.Errorf("Expected the configured 6 guesses, got %+v, %v", game, err)
+	}
+	for _, maxGuesses := range []int{1, 3, 12} {
+		game, err := service.CreateNewGame(maxGuesses)
+		if err != nil || game.MaxGuesses != maxGuesses {
+			t.Errorf("Expected %d guesses, got %+v, %v", maxGuesses, game, err)
+		}
+	}
 
-	// Create some games
-	_, err := service.CreateNewGame()
-	if err != nil {
-		t.Fatalf("Failed to create first game: %v", err)
+	before := len(gameRepo.games)
+	for _, maxGuesses := range []int{-1, 13} {
+		if _, err := service.CreateNewGame(maxGuesses); err == nil || !strings.Contains(err.Error(), "must be between 1 and 12") {
+			t.Errorf("Expected a range error for %d, got %v", maxGuesses, err)
+		}
 	}
-	_, err = service.CreateNewGame()
-	if err != nil {
-		t.Fatalf("Failed to create second game: %v", err)
+	if len(gameRepo.games) != before {
+		t.Error("Expected no game to be created for an invalid max_guesses")
 	}
 
-	// Test with valid limit
-	games, err := service.GetRecentGames(10)
-	if err != nil {
-		t.Fatalf("GetRecentGames should not return error: %v", err)
+	response, err := service.CreateNewGameWithGuessForClient("WORLD", 2, nil)
+	if err != nil || response.Game.MaxGuesses != 2 {
+		t.Errorf("Expected a 2-guess game with its first guess, got %+v, %v", response, err)
 	}
+}
 
-	if len(games) != 2 {
-		t.Errorf("Expected 2 games, got %d", len(games))
+// racingDailyGameRepository loses every race to create the shared daily game:
+// just before each insert, a concurrent request's game appears, unless
+// winnerMissing hides it from the following fetch too
+type racingDailyGameRepository struct {
+	*MockGameRepository
+	inserts       int
+	winnerMissing bool
+}
+
+func (r *racingDailyGameRepository) CreateDailyGame(id, targetWord string, maxGuesses int, date time.Time) (*Game, error) {
+	r.inserts++
+	if !r.winnerMissing {
+		if _, err := r.MockGameRepository.CreateDailyGame("winner", targetWord, maxGuesses, date); err != nil {
+			return nil, err
+		}
 	}
+	return nil, fmt.Errorf("daily game already exists: %s", dateKey(date))
+}
 
-	// Test with limit bounds
-	games, err = service.GetRecentGames(0)
+func TestGameServiceCreateOrGetDailyGameRace(t *testing.T) {
+	gameRepo := &racingDailyGameRepository{MockGameRepository: NewMockGameRepository()}
+	answerRepo := NewMockAnswerRepository()
+	answerRepo.answers["2024-03-01"] = "slate"
+	auditor := &MockAuditLogger{}
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), &GameConfig{MaxGuesses: 6, WordLength: 5})
+	service.SetAnswerRepository(answerRepo)
+	service.SetAuditLogger(auditor)
+
+	// The unique violation is not an error: the concurrently created game is returned
+	day := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	game, err := service.CreateOrGetDailyGame("", day)
 	if err != nil {
-		t.Fatalf("GetRecentGames should not return error: %v", err)
+		t.Fatalf("Expected the existing daily game, got %v", err)
 	}
-	// Should default to 10
-	if len(games) > 10 {
-		t.Errorf("Expected at most 10 games with limit 0, got %d", len(games))
+	if game.ID != "winner" || game.TargetWord != "SLATE" {
+		t.Errorf("Expected the winner's SLATE game, got %+v", game)
+	}
+	if gameRepo.inserts != 1 {
+		t.Errorf("Expected one insert attempt, got %d", gameRepo.inserts)
+	}
+	if len(auditor.records) != 0 {
+		t.Errorf("Expected the losing request to record no creation, got %+v", auditor.records)
 	}
 
-	games, err = service.GetRecentGames(200)
-	if err != nil {
-		t.Fatalf("GetRecentGames should not return error: %v", err)
+	// Retries are bounded when the existing game can't be fetched
+	gameRepo = &racingDailyGameRepository{MockGameRepository: NewMockGameRepository(), winnerMissing: true}
+	service = NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), &GameConfig{MaxGuesses: 6, WordLength: 5, DailyCreateRetries: 2})
+	service.SetAnswerRepository(answerRepo)
+	if _, err := service.CreateOrGetDailyGame("", day); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected the unique violation once retries run out, got %v", err)
 	}
-	// Should default to 10
-	if len(games) > 10 {
-		t.Errorf("Expected at most 10 games with limit 200, got %d", len(games))
+	if gameRepo.inserts != 3 {
+		t.Errorf("Expected the first insert and 2 retries, got %d", gameRepo.inserts)
 	}
 }
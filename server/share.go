@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dailyPuzzleEpoch is the date of daily puzzle number 0
+var dailyPuzzleEpoch = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+// shareTiles maps a letter status to its share grid tile
+var shareTiles = map[string]string{
+	"correct": "🟩",
+	"present": "🟨",
+	"absent":  "⬛",
+}
+
+// ShareOptions controls what ShareText includes
+type ShareOptions struct {
+	Player       *Player // Player associated with the game; nil when anonymous
+	IncludeStats bool    // Add a streak and score line when a player is associated
+}
+
+// ShareText renders a spoiler-free summary of a game in the familiar format:
+//
+//	Wordle 123 4/6
+//	🔥 streak: 7 · score: 3
+//
+//	⬛🟨⬛⬛⬛
+//	...
+//
+// Daily games are numbered from dailyPuzzleEpoch; other games are labelled
+// "Practice". Lost games show X instead of the guess count. The streak line
+// only appears when requested and a player is associated with the game.
+func ShareText(game Game, guesses []Guess, options ShareOptions) string {
+	var text strings.Builder
+
+	label := "Practice"
+	if game.DailyDate != nil {
+		label = fmt.Sprintf("%d", PuzzleNumber(*game.DailyDate))
+	}
+	attempts := "X"
+	if game.IsWon {
+		attempts = fmt.Sprintf("%d", game.GuessCount)
+	}
+	fmt.Fprintf(&text, "Wordle %s %s/%d\n", label, attempts, game.MaxGuesses)
+
+	if options.IncludeStats && options.Player != nil {
+		fmt.Fprintf(&text, "🔥 streak: %d · score: %d\n", options.Player.CurrentStreak, game.Score())
+	}
+
+	text.WriteString("\n")
+	for _, guess := range guesses {
+		for _, letter := range guess.Result {
+			text.WriteString(shareTiles[letter.Status])
+		}
+		text.WriteString("\n")
+	}
+
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// PuzzleNumber returns the daily puzzle number for date
+func PuzzleNumber(date time.Time) int {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(dailyPuzzleEpoch).Hours() / 24)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func shareGuess(word, target string) Guess {
+	return Guess{GuessWord: word, Result: EvaluateGuess(word, target)}
+}
+
+func TestShareText(t *testing.T) {
+	daily := time.Date(2021, time.October, 25, 0, 0, 0, 0, time.UTC)
+	won := Game{IsCompleted: true, IsWon: true, GuessCount: 2, MaxGuesses: 6, DailyDate: &daily}
+	guesses := []Guess{shareGuess("WORLD", "HELLO"), shareGuess("HELLO", "HELLO")}
+	player := &Player{CurrentStreak: 7}
+
+	tests := []struct {
+		name     string
+		game     Game
+		options  ShareOptions
+		expected string
+	}{
+		{
+			name:     "Daily without stats",
+			game:     won,
+			options:  ShareOptions{Player: player},
+			expected: "Wordle 128 2/6\n\n⬛🟨⬛🟩⬛\n🟩🟩🟩🟩🟩",
+		},
+		{
+			name:     "Daily with stats and player",
+			game:     won,
+			options:  ShareOptions{Player: player, IncludeStats: true},
+			expected: "Wordle 128 2/6\n🔥 streak: 7 · score: 5\n\n⬛🟨⬛🟩⬛\n🟩🟩🟩🟩🟩",
+		},
+		{
+			name:     "Stats requested without player",
+			game:     won,
+			options:  ShareOptions{IncludeStats: true},
+			expected: "Wordle 128 2/6\n\n⬛🟨⬛🟩⬛\n🟩🟩🟩🟩🟩",
+		},
+		{
+			name:     "Lost practice game",
+			game:     Game{IsCompleted: true, GuessCount: 6, MaxGuesses: 6},
+			options:  ShareOptions{Player: player, IncludeStats: true},
+			expected: "Wordle Practice X/6\n🔥 streak: 7 · score: 0\n\n⬛🟨⬛🟩⬛\n🟩🟩🟩🟩🟩",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShareText(tt.game, guesses, tt.options); got != tt.expected {
+				t.Errorf("ShareText() =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShareTextOmitsLetters(t *testing.T) {
+	game := Game{IsCompleted: true, IsWon: true, GuessCount: 1, MaxGuesses: 6}
+	text := ShareText(game, []Guess{shareGuess("CRANE", "CRANE")}, ShareOptions{})
+	if strings.Contains(text, "CRANE") {
+		t.Errorf("Share text must not reveal guesses: %s", text)
+	}
+}
+
+func TestPuzzleNumber(t *testing.T) {
+	if got := PuzzleNumber(dailyPuzzleEpoch); got != 0 {
+		t.Errorf("Expected epoch to be puzzle 0, got %d", got)
+	}
+	// The time of day does not matter
+	if got := PuzzleNumber(time.Date(2021, time.June, 20, 23, 30, 0, 0, time.UTC)); got != 1 {
+		t.Errorf("Expected puzzle 1, got %d", got)
+	}
+}
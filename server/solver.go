@@ -0,0 +1,216 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// Bounds on SolvePath, so coaching a stuck player stays cheap
+const (
+	// maxSolutionDepth caps the number of guesses on a solution path
+	maxSolutionDepth = 10
+	// maxSolverCandidates caps how many remaining candidates are scored as the
+	// next guess at each step; scoring is quadratic in the candidate count
+	maxSolverCandidates = 500
+)
+
+// maxRankedGuesses caps how many allowed guesses RankByExpectedRemaining
+// scores; each is scored against up to maxSolverCandidates candidates
+const maxRankedGuesses = 2000
+
+// RankedGuess is a guess and how many candidates are expected to remain after it
+type RankedGuess struct {
+	Guess             string  `json:"guess"`
+	ExpectedRemaining float64 `json:"expected_remaining"`
+	Candidate         bool    `json:"candidate"` // The guess could itself be the answer
+}
+
+// GuessRanking lists the best guesses against a set of candidates
+type GuessRanking struct {
+	Guesses    []RankedGuess `json:"guesses"`
+	Candidates int           `json:"candidates"`
+}
+
+// SolutionStep is one guess on a solution path and the candidates it leaves
+type SolutionStep struct {
+	Guess      string      `json:"guess"`
+	Result     GuessResult `json:"result"`
+	Candidates int         `json:"candidates"` // Candidates before the guess
+	Remaining  int         `json:"remaining"`  // Candidates consistent with its feedback
+}
+
+// SolutionPath is a sequence of guesses leading to the answer. Solved is false
+// when the depth ran out before the answer was guessed.
+type SolutionPath struct {
+	Steps      []SolutionStep `json:"steps"`
+	Candidates int            `json:"candidates"`
+	Solved     bool           `json:"solved"`
+}
+
+// SolvePath plays a greedy solver against target for at most maxDepth guesses.
+// It only guesses remaining candidates, picking the one whose feedback carries
+// the most information about the others (highest entropy; ties alphabetical),
+// then keeps the candidates that would have given the same feedback. Every
+// wrong guess rules at least itself out, so each step narrows the candidates.
+// Candidates must use the same case as target, which is added if missing.
+func SolvePath(target string, candidates []string, maxDepth int) SolutionPath {
+	targetRunes := []rune(target)
+	remaining := make([][]rune, 0, len(candidates)+1)
+	seen := make(map[string]bool)
+	for _, word := range candidates {
+		runes := []rune(word)
+		if len(runes) != len(targetRunes) || seen[word] {
+			continue
+		}
+		seen[word] = true
+		remaining = append(remaining, runes)
+	}
+	if !seen[target] {
+		remaining = append(remaining, targetRunes)
+	}
+	sort.Slice(remaining, func(i, j int) bool {
+		return string(remaining[i]) < string(remaining[j])
+	})
+
+	path := SolutionPath{Steps: []SolutionStep{}, Candidates: len(remaining)}
+	for len(path.Steps) < maxDepth {
+		guess := mostInformativeGuess(remaining)
+		step := SolutionStep{
+			Guess:      string(guess),
+			Result:     EvaluateGuess(string(guess), target),
+			Candidates: len(remaining),
+		}
+
+		pattern := feedbackPattern(guess, targetRunes)
+		next := remaining[:0:0]
+		for _, word := range remaining {
+			if feedbackPattern(guess, word) == pattern {
+				next = append(next, word)
+			}
+		}
+		remaining = next
+		step.Remaining = len(remaining)
+		path.Steps = append(path.Steps, step)
+
+		if string(guess) == target {
+			path.Solved = true
+			break
+		}
+	}
+	return path
+}
+
+// mostInformativeGuess returns the word whose feedback against words has the
+// highest entropy. At most maxSolverCandidates evenly spaced words are tried
+// as the guess, but each is scored against all of words.
+func mostInformativeGuess(words [][]rune) []rune {
+	step := 1
+	if len(words) > maxSolverCandidates {
+		step = (len(words) + maxSolverCandidates - 1) / maxSolverCandidates
+	}
+
+	var best []rune
+	bestEntropy := -1.0
+	for i := 0; i < len(words); i += step {
+		guess := words[i]
+		sizes := make(map[int]int)
+		for _, word := range words {
+			sizes[feedbackPattern(guess, word)]++
+		}
+
+		entropy := 0.0
+		for _, size := range sizes {
+			p := float64(size) / float64(len(words))
+			entropy -= p * math.Log2(p)
+		}
+		if entropy > bestEntropy {
+			best, bestEntropy = guess, entropy
+		}
+	}
+	return best
+}
+
+// RankByExpectedRemaining ranks guesses by the expected number of candidates
+// left after their feedback, assuming each candidate is equally likely to be
+// the answer: the sum of the squared feedback group sizes over the candidate
+// count. Fewer is better; ties prefer guesses that are themselves candidates,
+// then alphabetical. The best limit guesses are returned. To bound the work,
+// at most maxRankedGuesses evenly spaced guesses are scored, against at most
+// maxSolverCandidates evenly spaced candidates. Words must share one case.
+func RankByExpectedRemaining(guesses, candidates []string, limit int) []RankedGuess {
+	candidateSet := make(map[string]bool, len(candidates))
+	for _, word := range candidates {
+		candidateSet[word] = true
+	}
+	sample := make([][]rune, 0, min(len(candidateSet), maxSolverCandidates))
+	for _, word := range evenlySpaced(sortedKeys(candidateSet), maxSolverCandidates) {
+		sample = append(sample, []rune(word))
+	}
+
+	seen := make(map[string]bool)
+	var unique []string
+	for _, word := range guesses {
+		if !seen[word] {
+			seen[word] = true
+			unique = append(unique, word)
+		}
+	}
+	sort.Strings(unique)
+
+	ranked := []RankedGuess{}
+	if len(sample) == 0 {
+		return ranked
+	}
+	for _, word := range evenlySpaced(unique, maxRankedGuesses) {
+		guess := []rune(word)
+		sizes := make(map[int]int)
+		for _, candidate := range sample {
+			if len(candidate) == len(guess) {
+				sizes[feedbackPattern(guess, candidate)]++
+			}
+		}
+		sumOfSquares := 0
+		for _, size := range sizes {
+			sumOfSquares += size * size
+		}
+		ranked = append(ranked, RankedGuess{
+			Guess:             word,
+			ExpectedRemaining: float64(sumOfSquares) / float64(len(sample)),
+			Candidate:         candidateSet[word],
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].ExpectedRemaining != ranked[j].ExpectedRemaining {
+			return ranked[i].ExpectedRemaining < ranked[j].ExpectedRemaining
+		}
+		return ranked[i].Candidate && !ranked[j].Candidate
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// evenlySpaced returns at most limit evenly spaced words of words
+func evenlySpaced(words []string, limit int) []string {
+	if len(words) <= limit {
+		return words
+	}
+	step := (len(words) + limit - 1) / limit
+	spaced := make([]string, 0, limit)
+	for i := 0; i < len(words); i += step {
+		spaced = append(spaced, words[i])
+	}
+	return spaced
+}
+
+// sortedKeys returns the keys of set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolvePath(t *testing.T) {
+	for _, target := range latchFamily {
+		path := SolvePath(target, latchFamily, maxSolutionDepth)
+
+		if !path.Solved {
+			t.Fatalf("%s: expected path to be solved, got %+v", target, path.Steps)
+		}
+		if path.Candidates != len(latchFamily) {
+			t.Errorf("%s: expected %d candidates, got %d", target, len(latchFamily), path.Candidates)
+		}
+		if last := path.Steps[len(path.Steps)-1]; last.Guess != target || last.Remaining != 1 {
+			t.Errorf("%s: expected path to end guessing the target, got %+v", target, last)
+		}
+
+		// Each step starts from the candidates the previous one left, and narrows them
+		candidates := path.Candidates
+		for _, step := range path.Steps {
+			if step.Candidates != candidates {
+				t.Errorf("%s: step %s expected to start from %d candidates, got %d", target, step.Guess, candidates, step.Candidates)
+			}
+			if step.Guess != target && step.Remaining >= step.Candidates {
+				t.Errorf("%s: step %s did not narrow the candidates (%d -> %d)", target, step.Guess, step.Candidates, step.Remaining)
+			}
+			candidates = step.Remaining
+		}
+	}
+}
+
+func TestSolvePathBounds(t *testing.T) {
+	path := SolvePath("WATCH", latchFamily, 2)
+	if path.Solved || len(path.Steps) != 2 {
+		t.Errorf("Expected an unsolved two-step path, got solved=%v with %d steps", path.Solved, len(path.Steps))
+	}
+
+	// A target missing from the candidates is still reached
+	path = SolvePath("ZEBRA", []string{"CRANE", "SLATE"}, maxSolutionDepth)
+	if !path.Solved || path.Candidates != 3 {
+		t.Errorf("Expected ZEBRA to be added and solved, got %+v", path)
+	}
+}
+
+func TestRankByExpectedRemaining(t *testing.T) {
+	candidates := []string{"BATCH", "CATCH", "HATCH", "LATCH", "MATCH", "PATCH", "WATCH"}
+	guesses := []string{"CATCH", "BATCH", "CHAMP", "BLOWN", "CATCH"}
+
+	// BLOWN and CHAMP both split the seven candidates into groups of 1, 1, 1
+	// and 4; BATCH and CATCH only tell their own word from the other six
+	ranked := RankByExpectedRemaining(guesses, candidates, 10)
+	expected := []RankedGuess{
+		{"BLOWN", 19.0 / 7, false},
+		{"CHAMP", 19.0 / 7, false},
+		{"BATCH", 37.0 / 7, true},
+		{"CATCH", 37.0 / 7, true},
+	}
+	if len(ranked) != len(expected) {
+		t.Fatalf("Expected %d ranked guesses, got %+v", len(expected), ranked)
+	}
+	for i, want := range expected {
+		got := ranked[i]
+		if got.Guess != want.Guess || got.Candidate != want.Candidate || math.Abs(got.ExpectedRemaining-want.ExpectedRemaining) > 1e-9 {
+			t.Errorf("Rank %d: expected %+v, got %+v", i+1, want, got)
+		}
+	}
+
+	// Ties prefer guesses that could be the answer
+	ranked = RankByExpectedRemaining([]string{"WHACK", "WATCH"}, []string{"WATCH", "HATCH"}, 10)
+	if ranked[0].Guess != "WATCH" || ranked[0].ExpectedRemaining != ranked[1].ExpectedRemaining {
+		t.Errorf("Expected the candidate WATCH first in a tie, got %+v", ranked)
+	}
+
+	if ranked := RankByExpectedRemaining(guesses, candidates, 2); len(ranked) != 2 || ranked[0].Guess != "BLOWN" {
+		t.Errorf("Expected the top 2 guesses, got %+v", ranked)
+	}
+	if ranked := RankByExpectedRemaining(guesses, nil, 10); ranked == nil || len(ranked) != 0 {
+		t.Errorf("Expected no ranking without candidates, got %#v", ranked)
+	}
+}
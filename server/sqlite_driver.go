@@ -0,0 +1,7 @@
+//go:build sqlite
+
+package main
+
+// Registers the "sqlite3" driver used by the SQLite backend. The driver needs
+// cgo, so it is only linked into builds that ask for it with -tags sqlite.
+import _ "github.com/mattn/go-sqlite3"
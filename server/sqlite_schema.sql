@@ -0,0 +1,85 @@
+-- SQLite schema for the embedded storage backend (DB_BACKEND=sqlite).
+-- Mirrors db/init/01-create-tables.sql; keep the two in sync.
+-- IDs are random version 4 UUIDs rendered as text.
+
+CREATE TABLE IF NOT EXISTS players (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    username VARCHAR(50) UNIQUE,
+    email VARCHAR(255) UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    games_played INTEGER DEFAULT 0,
+    games_won INTEGER DEFAULT 0,
+    current_streak INTEGER DEFAULT 0,
+    max_streak INTEGER DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS games (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    target_word VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    is_completed BOOLEAN DEFAULT FALSE,
+    is_won BOOLEAN DEFAULT FALSE,
+    guess_count INTEGER DEFAULT 0,
+    max_guesses INTEGER DEFAULT 6,
+    daily_date DATE,
+    client_ip VARCHAR(45),
+    user_agent VARCHAR(512),
+    is_tutorial BOOLEAN NOT NULL DEFAULT FALSE,
+    scramble VARCHAR(16) NOT NULL DEFAULT '',
+    mode VARCHAR(16) NOT NULL DEFAULT 'practice' CHECK (mode IN ('practice', 'daily', 'challenge')),
+    player_id TEXT REFERENCES players(id) ON DELETE CASCADE,
+    hints_used INTEGER NOT NULL DEFAULT 0,
+    max_hints INTEGER,
+    keyboard_state TEXT NOT NULL DEFAULT '{}',
+    boards TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE IF NOT EXISTS guesses (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    guess_word VARCHAR(16) NOT NULL,
+    guess_number INTEGER NOT NULL,
+    result TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(game_id, guess_number)
+);
+
+CREATE TABLE IF NOT EXISTS answers (
+    puzzle_date DATE PRIMARY KEY,
+    target_word VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    api_key VARCHAR(128) PRIMARY KEY,
+    daily_game_quota INTEGER NOT NULL CHECK (daily_game_quota >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key VARCHAR(128) NOT NULL REFERENCES api_keys(api_key) ON DELETE CASCADE,
+    usage_date DATE NOT NULL,
+    games_created INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (api_key, usage_date)
+);
+
+CREATE TABLE IF NOT EXISTS game_stats (
+    id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    player_id TEXT REFERENCES players(id) ON DELETE SET NULL,
+    word_difficulty FLOAT,
+    solve_time_seconds INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_created_at ON games(created_at);
+CREATE INDEX IF NOT EXISTS idx_games_target_word ON games(target_word);
+CREATE INDEX IF NOT EXISTS idx_games_mode_created_at ON games(mode, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_games_daily_date ON games(daily_date) WHERE daily_date IS NOT NULL AND player_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_games_player_daily_date ON games(player_id, daily_date) WHERE mode = 'daily' AND player_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_guesses_game_id ON guesses(game_id);
+CREATE INDEX IF NOT EXISTS idx_guesses_created_at ON guesses(created_at);
+CREATE INDEX IF NOT EXISTS idx_players_username ON players(username);
+CREATE INDEX IF NOT EXISTS idx_game_stats_game_id ON game_stats(game_id);
+CREATE INDEX IF NOT EXISTS idx_game_stats_player_id ON game_stats(player_id);
@@ -0,0 +1,546 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupSQLiteTestDB opens a fresh in-memory SQLite database with the schema
+// applied. The tests are skipped unless the binary was built with -tags sqlite.
+func setupSQLiteTestDB(t *testing.T) *DB {
+	if !driverRegistered(sqliteDriverName) {
+		t.Skip("Skipping SQLite tests: build with -tags sqlite to enable the driver")
+	}
+
+	db, err := NewDB(&DatabaseConfig{Backend: BackendSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to apply SQLite schema: %v", err)
+	}
+
+	return db
+}
+
+func TestSQLiteUnavailableWithoutDriver(t *testing.T) {
+	if driverRegistered(sqliteDriverName) {
+		t.Skip("SQLite driver is compiled in")
+	}
+
+	_, err := NewDB(&DatabaseConfig{Backend: BackendSQLite})
+	if err == nil || !strings.Contains(err.Error(), "-tags sqlite") {
+		t.Errorf("Expected build tag hint, got %v", err)
+	}
+}
+
+func TestSQLiteRepositoryFlow(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	gameRepo := NewGameRepository(db)
+	guessRepo := NewGuessRepository(db)
+
+	game, err := gameRepo.CreateGame(UUIDGenerator{}.NewID(), "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if game.ID == "" || game.TargetWord != "HELLO" || game.MaxGuesses != 6 || game.IsCompleted {
+		t.Fatalf("Unexpected new game: %+v", game)
+	}
+
+	result := GuessResult{
+		{Letter: "H", Status: "correct"},
+		{Letter: "E", Status: "correct"},
+		{Letter: "L", Status: "correct"},
+		{Letter: "P", Status: "absent"},
+		{Letter: "S", Status: "absent"},
+	}
+	guess, err := guessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "HELPS", 1, result)
+	if err != nil {
+		t.Fatalf("Failed to create guess: %v", err)
+	}
+	if guess.GameID != game.ID || len(guess.Result) != 5 || guess.Result[3].Status != "absent" {
+		t.Errorf("Unexpected guess: %+v", guess)
+	}
+
+	// The unique (game_id, guess_number) constraint is classified for SQLite too
+	if _, err := guessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "HELLO", 1, result); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate guess number error, got %v", err)
+	}
+
+	now := time.Now().UTC()
+	game.IsCompleted = true
+	game.IsWon = true
+	game.CompletedAt = &now
+	game.GuessCount = 1
+	game.KeyboardState = KeyboardState{"H": "correct", "P": "absent"}
+	if err := gameRepo.UpdateGame(game); err != nil {
+		t.Fatalf("Failed to update game: %v", err)
+	}
+
+	withGuesses, err := gameRepo.GetGameWithGuesses(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game with guesses: %v", err)
+	}
+	if !withGuesses.Game.IsWon || withGuesses.Game.CompletedAt == nil || withGuesses.Game.KeyboardState["P"] != "absent" {
+		t.Errorf("Expected update to be stored, got %+v", withGuesses.Game)
+	}
+	if len(withGuesses.Guesses) != 1 || withGuesses.Guesses[0].GuessWord != "HELPS" {
+		t.Errorf("Expected one guess, got %+v", withGuesses.Guesses)
+	}
+
+	// Deleting a game cascades to its guesses (foreign keys are enabled)
+	if err := gameRepo.DeleteGame(game.ID); err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+	guesses, err := guessRepo.GetGuessesByGameID(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to list guesses: %v", err)
+	}
+	if len(guesses) != 0 {
+		t.Errorf("Expected guesses to be deleted with the game, got %d", len(guesses))
+	}
+}
+
+func TestSQLiteTransactorRollback(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	gameRepo := NewGameRepository(db)
+
+	game, err := gameRepo.CreateGame(UUIDGenerator{}.NewID(), "CRANE", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	errAbort := errors.New("abort")
+	err = NewTransactor(db).WithinTransaction(func(txGames GameRepositoryInterface, txGuesses GuessRepositoryInterface) error {
+		if _, err := txGuesses.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "SLATE", 1, GuessResult{}); err != nil {
+			return err
+		}
+		game.GuessCount = 1
+		if err := txGames.UpdateGame(game); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Expected abort error, got %v", err)
+	}
+
+	stored, err := gameRepo.GetGameWithGuesses(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if stored.Game.GuessCount != 0 || len(stored.Guesses) != 0 {
+		t.Errorf("Expected rollback, got %d guesses and guess count %d", len(stored.Guesses), stored.Game.GuessCount)
+	}
+}
+
+func TestSQLiteAPIKeyQuota(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	apiKeyRepo := NewAPIKeyRepository(db)
+
+	if _, err := db.Exec(`INSERT INTO api_keys (api_key, daily_game_quota) VALUES ($1, $2)`, "tenant-key", 2); err != nil {
+		t.Fatalf("Failed to insert api key: %v", err)
+	}
+
+	today := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		if err := apiKeyRepo.UseGameQuota("tenant-key", today); err != nil {
+			t.Fatalf("Expected game %d within quota to be allowed, got %v", i+1, err)
+		}
+	}
+	if err := apiKeyRepo.UseGameQuota("tenant-key", today); err == nil || !strings.Contains(err.Error(), "quota") {
+		t.Errorf("Expected quota error, got %v", err)
+	}
+
+	// Usage is counted per day
+	if err := apiKeyRepo.UseGameQuota("tenant-key", today.AddDate(0, 0, 1)); err != nil {
+		t.Errorf("Expected a fresh quota the next day, got %v", err)
+	}
+	// A refunded creation can be used again
+	if err := apiKeyRepo.RefundGameQuota("tenant-key", today); err != nil {
+		t.Fatalf("Failed to refund quota: %v", err)
+	}
+	if err := apiKeyRepo.UseGameQuota("tenant-key", today); err != nil {
+		t.Errorf("Expected the refunded creation to be allowed, got %v", err)
+	}
+	if err := apiKeyRepo.UseGameQuota("unknown-key", today); err == nil || !strings.Contains(err.Error(), "unknown api key") {
+		t.Errorf("Expected unknown key error, got %v", err)
+	}
+}
+
+func TestSQLitePlayers(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	playerRepo := NewPlayerRepository(db)
+	gameRepo := NewGameRepository(db)
+
+	player, err := playerRepo.CreatePlayer("player-1", "alice")
+	if err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+	if _, err := playerRepo.CreatePlayer("player-2", "alice"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate username error, got %v", err)
+	}
+	found, err := playerRepo.GetPlayerByUsername("alice")
+	if err != nil || found.ID != player.ID {
+		t.Errorf("Expected player %s, got %+v, %v", player.ID, found, err)
+	}
+	if _, err := playerRepo.GetPlayerByUsername("bob"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	// Games are linked whether or not they already have a stats row
+	game, err := gameRepo.CreateGame("game-1", "CRANE", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := gameRepo.SetGamePlayer(game.ID, player.ID); err != nil {
+			t.Fatalf("Failed to set game player: %v", err)
+		}
+	}
+	owner, err := gameRepo.GetPlayerForGame(game.ID)
+	if err != nil || owner.ID != player.ID {
+		t.Errorf("Expected game to belong to %s, got %+v, %v", player.ID, owner, err)
+	}
+}
+
+func TestSQLitePlayerStatsFromPlayedGames(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, DailyGamePerPlayer: true, DailyDerivedWords: true}
+	service := NewGameServiceWithInterfaces(NewGameRepository(db), NewGuessRepository(db), NewMockWordList(), config)
+	service.SetTransactor(NewTransactor(db))
+	playerRepo := NewPlayerRepository(db)
+	player, err := playerRepo.CreatePlayer("player-1", "alice")
+	if err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+
+	// Two wins, a loss, then a win of the player's own daily game
+	play := func(game *Game, words ...string) {
+		for _, word := range words {
+			if _, err := service.MakeGuess(game.ID, word); err != nil {
+				t.Fatalf("Failed to make guess %s: %v", word, err)
+			}
+		}
+	}
+	var last *Game
+	for _, won := range []bool{true, true, false} {
+		game, err := service.CreateNewGame(1)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		if err := service.AssignPlayer(game.ID, player.ID); err != nil {
+			t.Fatalf("Failed to assign player: %v", err)
+		}
+		guess := game.TargetWord
+		if !won {
+			guess = "AUDIO"
+			if game.TargetWord == guess {
+				guess = "CRANE"
+			}
+		}
+		play(game, guess)
+		last = game
+	}
+	daily, err := service.CreateOrGetDailyGame(player.ID, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Failed to create daily game: %v", err)
+	}
+	play(daily, daily.TargetWord)
+
+	stats, err := playerRepo.GetPlayerByUsername("alice")
+	if err != nil {
+		t.Fatalf("Failed to get player: %v", err)
+	}
+	if stats.GamesPlayed != 4 || stats.GamesWon != 3 || stats.CurrentStreak != 1 || stats.MaxStreak != 2 {
+		t.Errorf("Expected 4 played, 3 won, a streak of 1 and a best of 2, got %+v", stats)
+	}
+
+	// The share text reports the streak as it stands
+	text, err := service.GetShareText(last.ID, true)
+	if err != nil || !strings.Contains(text, "streak: 1") {
+		t.Errorf("Expected the current streak in the share text, got %q (err %v)", text, err)
+	}
+}
+
+func TestSQLiteCreateGameForPlayerInOneTransaction(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5, AutoCreatePlayers: true}
+	service := NewGameServiceWithInterfaces(NewGameRepository(db), NewGuessRepository(db), NewMockWordList(), config)
+	service.SetTransactor(NewTransactor(db))
+	service.SetPlayerRepository(NewPlayerRepository(db))
+
+	errAbort := errors.New("abort")
+	var gameID string
+	err := service.withTransaction(func(tx *GameService) error {
+		player, created, err := tx.ResolvePlayer("dana")
+		if err != nil || !created {
+			t.Fatalf("Expected a new player, got %+v, %v", player, err)
+		}
+		game, err := tx.CreateNewGame(0)
+		if err != nil {
+			return err
+		}
+		gameID = game.ID
+		if err := tx.AssignPlayer(game.ID, player.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Expected abort error, got %v", err)
+	}
+	if _, err := NewPlayerRepository(db).GetPlayerByUsername("dana"); err == nil {
+		t.Error("Expected the player to be rolled back")
+	}
+	if _, err := NewGameRepository(db).GetGame(gameID); err == nil {
+		t.Error("Expected the game to be rolled back")
+	}
+
+	// A taken username leaves the transaction usable
+	if _, err := NewPlayerRepository(db).CreatePlayer("player-1", "erin"); err != nil {
+		t.Fatalf("Failed to create player: %v", err)
+	}
+	err = service.withTransaction(func(tx *GameService) error {
+		if _, err := tx.playerRepo.CreatePlayer("player-2", "erin"); err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("Expected duplicate username error, got %v", err)
+		}
+		player, created, err := tx.ResolvePlayer("erin")
+		if err != nil || created || player.ID != "player-1" {
+			t.Errorf("Expected the existing player, got %+v, %v", player, err)
+		}
+		game, err := tx.CreateNewGame(0)
+		if err != nil {
+			return err
+		}
+		return tx.AssignPlayer(game.ID, "player-1")
+	})
+	if err != nil {
+		t.Fatalf("Expected the transaction to commit, got %v", err)
+	}
+}
+
+func TestSQLitePlayerRank(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	playerRepo := NewPlayerRepository(db)
+	service := NewGameServiceWithInterfaces(NewGameRepository(db), NewGuessRepository(db), NewMockWordList(), &GameConfig{MaxGuesses: 6, WordLength: 5})
+	service.SetTransactor(NewTransactor(db))
+
+	// Each player finishes their games in order; true is a win
+	results := [][]bool{
+		{true},
+		{true, true, true, true},
+		{true, true, false, true},
+		{false},
+		{true, true, true, true, true},
+	}
+	for i, games := range results {
+		id := fmt.Sprintf("player-%d", i)
+		if _, err := playerRepo.CreatePlayer(id, fmt.Sprintf("user-%d", i)); err != nil {
+			t.Fatalf("Failed to create player: %v", err)
+		}
+		for _, won := range games {
+			game, err := service.CreateNewGame(1)
+			if err != nil {
+				t.Fatalf("Failed to create game: %v", err)
+			}
+			if err := service.AssignPlayer(game.ID, id); err != nil {
+				t.Fatalf("Failed to assign player: %v", err)
+			}
+			guess := game.TargetWord
+			if !won {
+				guess = "AUDIO"
+				if game.TargetWord == guess {
+					guess = "CRANE"
+				}
+			}
+			if _, err := service.MakeGuess(game.ID, guess); err != nil {
+				t.Fatalf("Failed to make guess: %v", err)
+			}
+		}
+	}
+
+	rank, err := playerRepo.GetPlayerRank("player-2", PlayerMetricMaxStreak)
+	if err != nil {
+		t.Fatalf("Failed to get player rank: %v", err)
+	}
+	if rank.Value != 2 || rank.Rank != 3 || rank.Players != 5 || rank.Percentile != 50 {
+		t.Errorf("Expected the middle player at rank 3 of 5 and the 50th percentile, got %+v", rank)
+	}
+	rank, err = playerRepo.GetPlayerRank("player-2", PlayerMetricGamesWon)
+	if err != nil {
+		t.Fatalf("Failed to get player rank: %v", err)
+	}
+	if rank.Value != 3 || rank.Rank != 3 {
+		t.Errorf("Expected 3 wins at rank 3, got %+v", rank)
+	}
+	rank, err = playerRepo.GetPlayerRank("player-3", PlayerMetricGamesPlayed)
+	if err != nil {
+		t.Fatalf("Failed to get player rank: %v", err)
+	}
+	if rank.Value != 1 || rank.Rank != 4 {
+		t.Errorf("Expected 1 game played tied at rank 4, got %+v", rank)
+	}
+	if _, err := playerRepo.GetPlayerRank("player-9", PlayerMetricMaxStreak); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := playerRepo.GetPlayerRank("player-2", "username"); err == nil || !strings.Contains(err.Error(), "invalid rank metric") {
+		t.Errorf("Expected invalid metric error, got %v", err)
+	}
+}
+
+func TestSQLiteMultiBoardGame(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	gameRepo := NewGameRepository(db)
+
+	game, err := gameRepo.CreateMultiBoardGame("game-1", []string{"CRANE", "SLATE"}, 7)
+	if err != nil {
+		t.Fatalf("Failed to create multi-board game: %v", err)
+	}
+	if game.TargetWord != "CRANE" || !reflect.DeepEqual(game.Boards, NewBoards([]string{"CRANE", "SLATE"})) {
+		t.Errorf("Expected CRANE and SLATE boards, got %s and %+v", game.TargetWord, game.Boards)
+	}
+
+	game.GuessCount = 1
+	game.Boards = game.Boards.Solve("SLATE", 1)
+	if err := gameRepo.UpdateGame(game); err != nil {
+		t.Fatalf("Failed to update game: %v", err)
+	}
+	stored, err := gameRepo.GetGame(game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if !reflect.DeepEqual(stored.Boards, game.Boards) {
+		t.Errorf("Expected boards %+v, got %+v", game.Boards, stored.Boards)
+	}
+
+	// Regular games have no boards
+	regular, err := gameRepo.CreateGame("game-2", "AUDIO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if regular.Boards != nil {
+		t.Errorf("Expected no boards for a regular game, got %+v", regular.Boards)
+	}
+}
+
+func TestSQLiteDailyGameUniqueViolation(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	gameRepo := NewGameRepository(db)
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := gameRepo.CreateDailyGame("daily-1", "CRANE", 6, day); err != nil {
+		t.Fatalf("Failed to create daily game: %v", err)
+	}
+	if _, err := gameRepo.CreateDailyGame("daily-2", "CRANE", 6, day); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an already exists error for a second daily game, got %v", err)
+	}
+}
+
+func TestSQLiteDistinctGuessTallies(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	gameRepo := NewGameRepository(db)
+	guessRepo := NewGuessRepository(db)
+
+	// Two completed games with two distinct words each, one with three, and
+	// an unfinished game that must not be counted
+	seeded := []struct {
+		words     []string
+		completed bool
+	}{
+		{[]string{"SLATE", "SLATE", "CRANE"}, true},
+		{[]string{"AUDIO", "CRANE"}, true},
+		{[]string{"AUDIO", "SLATE", "CRANE"}, true},
+		{[]string{"AUDIO", "SLATE", "CRANE"}, false},
+	}
+	for i, seed := range seeded {
+		game, err := gameRepo.CreateGame(fmt.Sprintf("game-%d", i), "CRANE", 6)
+		if err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		for number, word := range seed.words {
+			if _, err := guessRepo.CreateGuess(fmt.Sprintf("guess-%d-%d", i, number), game.ID, word, number+1, GuessResult{}); err != nil {
+				t.Fatalf("Failed to create guess: %v", err)
+			}
+		}
+		game.GuessCount = len(seed.words)
+		game.IsCompleted = seed.completed
+		if err := gameRepo.UpdateGame(game); err != nil {
+			t.Fatalf("Failed to update game: %v", err)
+		}
+	}
+
+	tallies, err := guessRepo.GetDistinctGuessTallies()
+	if err != nil {
+		t.Fatalf("Failed to get distinct guess tallies: %v", err)
+	}
+	expected := []DistinctGuessTally{{DistinctGuesses: 2, Games: 2}, {DistinctGuesses: 3, Games: 1}}
+	if !reflect.DeepEqual(tallies, expected) {
+		t.Errorf("Expected tallies %+v, got %+v", expected, tallies)
+	}
+}
+
+func TestSQLiteGameServiceFlow(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	config := &GameConfig{MaxGuesses: 6, WordLength: 5}
+	service := NewGameServiceWithInterfaces(NewGameRepository(db), NewGuessRepository(db), NewMockWordList(), config)
+	service.SetTransactor(NewTransactor(db))
+
+	game, err := service.CreateNewGame(0)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	response, err := service.MakeGuess(game.ID, game.TargetWord)
+	if err != nil {
+		t.Fatalf("Failed to make guess: %v", err)
+	}
+	if !response.Game.IsWon || response.Game.GuessCount != 1 {
+		t.Errorf("Expected a won game after one guess, got %+v", response.Game)
+	}
+}
+
+func TestSQLiteGetPositionTallies(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	gameRepo := NewGameRepository(db)
+	guessRepo := NewGuessRepository(db)
+
+	game, err := gameRepo.CreateGame(UUIDGenerator{}.NewID(), "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	results := []GuessResult{
+		EvaluateGuess("WORLD", "HELLO"),
+		EvaluateGuess("HELPS", "HELLO"),
+		EvaluateGuess("HELLO", "HELLO"),
+	}
+	// Results stored in either format are tallied
+	defer SetGuessResultFormat(ResultFormatJSON)
+	for i, result := range results {
+		format := ResultFormatJSON
+		if i%2 == 1 {
+			format = ResultFormatCompact
+		}
+		if err := SetGuessResultFormat(format); err != nil {
+			t.Fatalf("Failed to set result format: %v", err)
+		}
+		if _, err := guessRepo.CreateGuess(UUIDGenerator{}.NewID(), game.ID, "GUESS", i+1, result); err != nil {
+			t.Fatalf("Failed to create guess: %v", err)
+		}
+	}
+
+	heatmap, err := guessRepo.GetPositionTallies(5)
+	if err != nil {
+		t.Fatalf("Failed to get position tallies: %v", err)
+	}
+	if want := BuildGuessHeatmap(results, 5); !reflect.DeepEqual(heatmap, want) {
+		t.Errorf("Expected %+v, got %+v", want, heatmap)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import "sort"
+
+// Bounds on "did you mean" suggestions for guesses that are not in the dictionary
+const (
+	// maxSuggestionDistance is the largest edit distance a suggestion may have
+	maxSuggestionDistance = 2
+	// maxSuggestions caps how many suggestions are returned
+	maxSuggestions = 5
+)
+
+// NearestWords returns up to limit candidates within maxDistance edits of
+// word, nearest first and ties alphabetical. The word itself is never
+// included. The result is empty, not nil, when nothing is near enough.
+func NearestWords(word string, candidates []string, maxDistance, limit int) []string {
+	type scored struct {
+		word     string
+		distance int
+	}
+
+	target := []rune(word)
+	var near []scored
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if candidate == word || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		if distance := editDistance(target, []rune(candidate), maxDistance); distance <= maxDistance {
+			near = append(near, scored{candidate, distance})
+		}
+	}
+	sort.Slice(near, func(i, j int) bool {
+		if near[i].distance != near[j].distance {
+			return near[i].distance < near[j].distance
+		}
+		return near[i].word < near[j].word
+	})
+
+	suggestions := []string{}
+	for i := 0; i < len(near) && i < limit; i++ {
+		suggestions = append(suggestions, near[i].word)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b, or some
+// value above limit as soon as the distance is known to exceed it
+func editDistance(a, b []rune, limit int) int {
+	if diff := len(a) - len(b); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			rowMin = min(rowMin, current[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
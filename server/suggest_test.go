@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"CRANE", "CRANE", 0},
+		{"CRABE", "CRANE", 1},
+		{"CRANE", "CRATE", 1},
+		{"CRANE", "CARNE", 2},
+		{"CRANE", "CRANES", 1},
+		{"", "ABC", 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b), 10); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	// Past the limit only the fact that it was exceeded matters
+	if got := editDistance([]rune("QUICK"), []rune("CRANE"), 2); got <= 2 {
+		t.Errorf("Expected a distance above the limit, got %d", got)
+	}
+}
+
+func TestNearestWords(t *testing.T) {
+	candidates := []string{"SLATE", "CRAVE", "CRANE", "CRATE", "BRAVE", "CRANE", "CRABS"}
+
+	got := NearestWords("CRABE", candidates, 2, 10)
+	want := []string{"CRABS", "CRANE", "CRATE", "CRAVE", "BRAVE"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if got := NearestWords("CRABE", candidates, 2, 2); !reflect.DeepEqual(got, want[:2]) {
+		t.Errorf("Expected the limit to keep the nearest two, got %v", got)
+	}
+
+	if got := NearestWords("ZZZZZ", candidates, 2, 10); got == nil || len(got) != 0 {
+		t.Errorf("Expected no suggestions, got %#v", got)
+	}
+}
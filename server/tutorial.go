@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Tutorial scripts an onboarding game: a fixed target word and the guidance
+// shown before each of the first few guesses.
+type Tutorial struct {
+	TargetWord string         `json:"target_word"`
+	Steps      []TutorialStep `json:"steps"`
+}
+
+// TutorialStep is the guidance shown before a guess and the guess it suggests
+type TutorialStep struct {
+	Guess    string `json:"guess"`    // Suggested word for this step
+	Guidance string `json:"guidance"` // Shown before the player makes this guess
+}
+
+// DefaultTutorial returns the built-in tutorial script
+func DefaultTutorial() *Tutorial {
+	return &Tutorial{
+		TargetWord: "PLANT",
+		Steps: []TutorialStep{
+			{
+				Guess:    "AUDIO",
+				Guidance: "Start with a word that has common vowels, like AUDIO.",
+			},
+			{
+				Guess:    "SLANT",
+				Guidance: "Green tiles are in the right spot, yellow tiles are in the word but somewhere else, and grey tiles are not in the word. Try SLANT to test some common consonants.",
+			},
+			{
+				Guess:    "PLANT",
+				Guidance: "Keep the green letters where they are and replace the grey ones. Try PLANT.",
+			},
+		},
+	}
+}
+
+// LoadTutorial reads a tutorial script from a JSON file
+func LoadTutorial(filePath string) (*Tutorial, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tutorial file %s: %w", filePath, err)
+	}
+
+	var tutorial Tutorial
+	if err := json.Unmarshal(data, &tutorial); err != nil {
+		return nil, fmt.Errorf("failed to parse tutorial file %s: %w", filePath, err)
+	}
+	if strings.TrimSpace(tutorial.TargetWord) == "" {
+		return nil, fmt.Errorf("tutorial file %s has no target word", filePath)
+	}
+
+	return &tutorial, nil
+}
+
+// GuidanceBefore returns the guidance shown before the given 1-based guess
+// number, or an empty string once the script has run out
+func (t *Tutorial) GuidanceBefore(guessNumber int) string {
+	if guessNumber < 1 || guessNumber > len(t.Steps) {
+		return ""
+	}
+	return t.Steps[guessNumber-1].Guidance
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultTutorial(t *testing.T) {
+	tutorial := DefaultTutorial()
+
+	wordList, err := NewWordList("")
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+
+	// Every scripted word must be accepted as a guess
+	if !wordList.Contains(tutorial.TargetWord) {
+		t.Errorf("Tutorial target %s is not a valid word", tutorial.TargetWord)
+	}
+	for i, step := range tutorial.Steps {
+		if !wordList.Contains(step.Guess) {
+			t.Errorf("Step %d guess %s is not a valid word", i+1, step.Guess)
+		}
+		if step.Guidance == "" {
+			t.Errorf("Step %d has no guidance", i+1)
+		}
+	}
+
+	// The last scripted guess solves the puzzle
+	last := tutorial.Steps[len(tutorial.Steps)-1].Guess
+	if last != tutorial.TargetWord {
+		t.Errorf("Expected final step to guess %s, got %s", tutorial.TargetWord, last)
+	}
+}
+
+func TestTutorialGuidanceBefore(t *testing.T) {
+	tutorial := &Tutorial{
+		TargetWord: "HELLO",
+		Steps: []TutorialStep{
+			{Guess: "AUDIO", Guidance: "first"},
+			{Guess: "WORLD", Guidance: "second"},
+		},
+	}
+
+	tests := []struct {
+		guessNumber int
+		expected    string
+	}{
+		{0, ""},
+		{1, "first"},
+		{2, "second"},
+		{3, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tutorial.GuidanceBefore(tt.guessNumber); got != tt.expected {
+			t.Errorf("GuidanceBefore(%d) = %q, expected %q", tt.guessNumber, got, tt.expected)
+		}
+	}
+}
+
+func TestLoadTutorial(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "tutorial.json")
+
+	content := `{"target_word": "HELLO", "steps": [{"guess": "AUDIO", "guidance": "Try vowels."}]}`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	tutorial, err := LoadTutorial(testFile)
+	if err != nil {
+		t.Fatalf("LoadTutorial should not return error: %v", err)
+	}
+	if tutorial.TargetWord != "HELLO" || len(tutorial.Steps) != 1 || tutorial.GuidanceBefore(1) != "Try vowels." {
+		t.Errorf("Unexpected tutorial: %+v", tutorial)
+	}
+
+	if _, err := LoadTutorial(filepath.Join(tempDir, "missing.json")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+
+	if err := os.WriteFile(testFile, []byte(`{"steps": []}`), 0644); err != nil {
+		t.Fatalf("Failed to update test file: %v", err)
+	}
+	if _, err := LoadTutorial(testFile); err == nil {
+		t.Error("Expected error for tutorial without a target word")
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import "runtime"
+
+// Build information, set at compile time with -ldflags, e.g.
+//
+//	go build -ldflags "-X main.buildVersion=1.2.0 -X main.buildCommit=$(git rev-parse --short HEAD) -X main.buildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	buildVersion = "dev"
+	buildCommit  = "unknown"
+	buildTime    = "unknown"
+)
+
+// VersionInfo describes the running server build
+type VersionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// CurrentVersion returns the build information of the running binary
+func CurrentVersion() VersionInfo {
+	return VersionInfo{
+		Version:   buildVersion,
+		Commit:    buildCommit,
+		BuildTime: buildTime,
+		GoVersion: runtime.Version(),
+	}
+}
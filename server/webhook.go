@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// GameCompletedEvent is the payload posted to the webhook when a game ends
+type GameCompletedEvent struct {
+	Event       string     `json:"event"` // Always "game.completed"
+	GameID      string     `json:"game_id"`
+	Outcome     string     `json:"outcome"` // "won" or "lost"
+	GuessCount  int        `json:"guess_count"`
+	MaxGuesses  int        `json:"max_guesses"`
+	TargetWord  string     `json:"target_word,omitempty"` // Only when enabled
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// WebhookNotifier posts game completion events to a webhook URL. Deliveries
+// run in the background with a per-attempt timeout and retries, so a slow or
+// failing webhook never delays or fails a guess.
+type WebhookNotifier struct {
+	url           string
+	includeTarget bool
+	client        *http.Client
+	maxAttempts   int
+	retryDelay    time.Duration // Delay before the first retry; doubles each attempt
+
+	inFlight sync.WaitGroup
+}
+
+// NewWebhookNotifier creates a notifier posting to url. Each attempt is
+// bounded by timeout and failed deliveries are attempted up to maxAttempts times.
+func NewWebhookNotifier(url string, includeTarget bool, timeout time.Duration, maxAttempts int) *WebhookNotifier {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &WebhookNotifier{
+		url:           url,
+		includeTarget: includeTarget,
+		client:        &http.Client{Timeout: timeout},
+		maxAttempts:   maxAttempts,
+		retryDelay:    500 * time.Millisecond,
+	}
+}
+
+// NotifyGameCompleted queues delivery of the completion event for game
+func (n *WebhookNotifier) NotifyGameCompleted(game Game) {
+	event := GameCompletedEvent{
+		Event:       "game.completed",
+		GameID:      game.ID,
+		Outcome:     "lost",
+		GuessCount:  game.GuessCount,
+		MaxGuesses:  game.MaxGuesses,
+		CompletedAt: game.CompletedAt,
+	}
+	if game.IsWon {
+		event.Outcome = "won"
+	}
+	if n.includeTarget {
+		event.TargetWord = game.TargetWord
+	}
+
+	n.inFlight.Add(1)
+	go func() {
+		defer n.inFlight.Done()
+		if err := n.deliver(event); err != nil {
+			log.Printf("Warning: webhook delivery for game %s failed: %v", game.ID, err)
+		}
+	}()
+}
+
+// Wait blocks until all queued deliveries have finished
+func (n *WebhookNotifier) Wait() {
+	n.inFlight.Wait()
+}
+
+// deliver posts the event, retrying with exponential backoff on errors and
+// non-2xx responses
+func (n *WebhookNotifier) deliver(event GameCompletedEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	delay := n.retryDelay
+	for attempt := 1; ; attempt++ {
+		err = n.post(body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= n.maxAttempts {
+			return fmt.Errorf("giving up after %d attempt(s): %w", attempt, err)
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post makes a single delivery attempt
+func (n *WebhookNotifier) post(body []byte) error {
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifierDeliversCompletion(t *testing.T) {
+	var mu sync.Mutex
+	var received []GameCompletedEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event GameCompletedEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode webhook payload: %v", err)
+		}
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, true, time.Second, 3)
+
+	gameRepo := NewMockGameRepository()
+	guessRepo := NewMockGuessRepository()
+	service := NewGameServiceWithInterfaces(gameRepo, guessRepo, NewMockWordList(), &GameConfig{MaxGuesses: 6, WordLength: 5})
+	service.SetCompletionNotifier(notifier)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	// Unfinished games are not announced
+	if _, err := service.MakeGuess(game.ID, "WORLD"); err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	if _, err := service.MakeGuess(game.ID, "HELLO"); err != nil {
+		t.Fatalf("MakeGuess should not return error: %v", err)
+	}
+	notifier.Wait()
+
+	if len(received) != 1 {
+		t.Fatalf("Expected exactly 1 webhook call, got %d", len(received))
+	}
+	event := received[0]
+	if event.Event != "game.completed" || event.GameID != game.ID || event.Outcome != "won" ||
+		event.GuessCount != 2 || event.TargetWord != "HELLO" || event.CompletedAt == nil {
+		t.Errorf("Unexpected webhook payload: %+v", event)
+	}
+}
+
+func TestWebhookNotifierOmitsTargetWord(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, false, time.Second, 1)
+	notifier.NotifyGameCompleted(Game{ID: "A", TargetWord: "HELLO", IsCompleted: true, GuessCount: 6, MaxGuesses: 6})
+	notifier.Wait()
+
+	if body["outcome"] != "lost" {
+		t.Errorf("Expected lost outcome, got %v", body["outcome"])
+	}
+	if _, ok := body["target_word"]; ok {
+		t.Errorf("Expected target word to be omitted, got %v", body)
+	}
+}
+
+func TestWebhookNotifierFailureDoesNotFailGuess(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, false, time.Second, 3)
+	notifier.retryDelay = time.Millisecond
+
+	gameRepo := NewMockGameRepository()
+	service := NewGameServiceWithInterfaces(gameRepo, NewMockGuessRepository(), NewMockWordList(), &GameConfig{MaxGuesses: 6, WordLength: 5})
+	service.SetCompletionNotifier(notifier)
+
+	game, err := gameRepo.CreateGame("", "HELLO", 6)
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	response, err := service.MakeGuess(game.ID, "HELLO")
+	if err != nil {
+		t.Fatalf("A failing webhook must not fail the guess: %v", err)
+	}
+	if !response.Game.IsWon {
+		t.Error("Expected the game to be won")
+	}
+
+	notifier.Wait()
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("Expected 3 delivery attempts, got %d", got)
+	}
+}
+
+func TestWebhookNotifierTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	notifier := NewWebhookNotifier(server.URL, false, 200*time.Millisecond, 1)
+
+	start := time.Now()
+	notifier.NotifyGameCompleted(Game{ID: "A", IsCompleted: true})
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("NotifyGameCompleted should not block, took %v", elapsed)
+	}
+
+	done := make(chan struct{})
+	go func() { notifier.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Expected the delivery to time out")
+	}
+}
@@ -2,12 +2,19 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 /*
@@ -23,12 +30,79 @@ type WordList struct {
 	targetWordSet  map[string]bool     // Set for target word lookup
 	validFilePath  string              // Path to validation words file
 	targetFilePath string              // Path to target words file
+	splitLines     bool                // Split multi-word lines instead of skipping them
+	maxWordLength  int                 // Longer words are skipped while loading; 0 keeps all
+	maxLineBytes   int                 // Longest line read from a word file or import
+	caseSensitive  bool                // Keep words as written and match them exactly
+	lower          func(string) string // Lowercases words with the locale's case rules; nil uses the Unicode defaults
+	requireLength  int                 // Loading fails unless some target word has this length; 0 disables
+	mu             sync.RWMutex        // Guards the word lists, sets and rng against concurrent reloads, imports and reseeds
+	fileMu         sync.Mutex          // Serializes reading the word files with persisted imports, without blocking readers
+	rng            *rand.Rand          // Own random source set by SetSeed; nil uses the shared one
+}
+
+// defaultMaxLineBytes is the longest word-file line read when
+// WordListOptions.MaxLineBytes is unset. A word list has one short word per
+// line, so only a corrupt or newline-free file comes near it.
+const defaultMaxLineBytes = 1024 * 1024
+
+// importBatchSize is how many imported words are validated before the target
+// list is locked to merge them, so long uploads don't block readers throughout
+const importBatchSize = 1000
+
+// ImportStats reports the outcome of importing target words
+type ImportStats struct {
+	Added      int  `json:"added"`      // New target words
+	Duplicates int  `json:"duplicates"` // Already targets, or repeated in the import
+	Invalid    int  `json:"invalid"`    // Wrong length, non-letters, or not in the validation list
+	Persisted  bool `json:"persisted"`  // Added words were appended to the target word file
+}
+
+// Skipped returns the number of imported words that were not added
+func (s ImportStats) Skipped() int {
+	return s.Duplicates + s.Invalid
+}
+
+// WordListOptions controls how word files are parsed
+type WordListOptions struct {
+	// SplitMultiWordLines treats each word on a line containing internal
+	// whitespace as a separate entry. When false such lines are skipped and logged.
+	SplitMultiWordLines bool
+
+	// MaxWordLength skips words with more letters than this while loading,
+	// logging how many were dropped. Zero keeps words of any length.
+	MaxWordLength int
+
+	// MaxLineBytes is the longest line read from a word file or import. A
+	// longer line fails loading with its line number instead of bufio's bare
+	// "token too long". Zero uses defaultMaxLineBytes.
+	MaxLineBytes int
+
+	// CaseSensitive keeps words in the case they are written in the file and
+	// makes Contains match exactly, so "Crane" and "crane" are distinct
+	// entries. By default words are lowercased and matched case-insensitively.
+	CaseSensitive bool
+
+	// RequireTargetLength makes loading fail when the target list has no
+	// words of this length, so a game length with no playable answers is
+	// caught at startup rather than on the first new game. Zero disables it.
+	RequireTargetLength int
+
+	// Locale is the BCP 47 locale whose case rules fold words when loading
+	// and looking them up, matching how guesses are evaluated (e.g. "tr"
+	// folds I to ı). Empty uses the default Unicode mapping.
+	Locale string
 }
 
 // NewWordList creates a new WordList instance
 // If validFilePath is empty, it defaults to "valid-wordle-words.txt" in the same directory
 // If targetFilePath is empty, it defaults to "common-target-words.txt" in the same directory
 func NewWordList(validFilePath string) (*WordList, error) {
+	return NewWordListWithOptions(validFilePath, WordListOptions{})
+}
+
+// NewWordListWithOptions creates a new WordList instance using the given parsing options
+func NewWordListWithOptions(validFilePath string, opts WordListOptions) (*WordList, error) {
 	dir, err := os.Getwd()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current directory: %w", err)
@@ -50,9 +124,20 @@ func NewWordList(validFilePath string) (*WordList, error) {
 		targetFilePath = filepath.Join(dir, "server", "common-target-words.txt")
 	}
 
+	lower, err := NewLowerCaser(opts.Locale)
+	if err != nil {
+		return nil, err
+	}
+
 	wl := &WordList{
 		validFilePath:  validFilePath,
 		targetFilePath: targetFilePath,
+		splitLines:     opts.SplitMultiWordLines,
+		maxWordLength:  opts.MaxWordLength,
+		maxLineBytes:   opts.MaxLineBytes,
+		caseSensitive:  opts.CaseSensitive,
+		lower:          lower,
+		requireLength:  opts.RequireTargetLength,
 		validWordSet:   make(map[string]bool),
 		targetWordSet:  make(map[string]bool),
 	}
@@ -64,115 +149,271 @@ func NewWordList(validFilePath string) (*WordList, error) {
 	return wl, nil
 }
 
-// loadWords reads words from both files and populates the word lists
+// loadWords reads words from both files and swaps them in together, so
+// concurrent readers see either the old lists or the new ones. It holds
+// fileMu so a persisted import is either fully on disk or not yet merged.
 func (wl *WordList) loadWords() error {
-	// Load validation words
-	if err := wl.loadValidWords(); err != nil {
+	wl.fileMu.Lock()
+	defer wl.fileMu.Unlock()
+
+	validWords, validWordSet, err := wl.readWordFile(wl.validFilePath, "validation")
+	if err != nil {
 		return err
 	}
-
-	// Load target words
-	if err := wl.loadTargetWords(); err != nil {
+	targetWords, targetWordSet, err := wl.readWordFile(wl.targetFilePath, "target")
+	if err != nil {
+		return err
+	}
+	if err := wl.checkRequiredLength(targetWords); err != nil {
 		return err
 	}
 
+	wl.mu.Lock()
+	wl.validWords, wl.validWordSet = validWords, validWordSet
+	wl.targetWords, wl.targetWordSet = targetWords, targetWordSet
+	wl.mu.Unlock()
+
 	return nil
 }
 
+// newLineScanner returns a scanner over the lines of r that accepts lines up
+// to the configured maximum length
+func (wl *WordList) newLineScanner(r io.Reader) *bufio.Scanner {
+	// The initial buffer's capacity also caps tokens, so it can't exceed the limit
+	limit := wl.maxLineLength()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(bufio.MaxScanTokenSize, limit)), limit)
+	return scanner
+}
+
+// maxLineLength returns the configured line limit in bytes, falling back to
+// the default when unset
+func (wl *WordList) maxLineLength() int {
+	if wl.maxLineBytes <= 0 {
+		return defaultMaxLineBytes
+	}
+	return wl.maxLineBytes
+}
+
+// lineTooLongError explains a line of source that overflowed the scanner,
+// which usually means a corrupt file or one without newlines
+func (wl *WordList) lineTooLongError(source string, lineNumber int) error {
+	return fmt.Errorf("%s line %d is longer than %d bytes; word files need one word per line (raise MAX_WORD_LINE_BYTES if the line is intended): %w",
+		source, lineNumber, wl.maxLineLength(), bufio.ErrTooLong)
+}
+
 // loadValidWords reads validation words from the file
 func (wl *WordList) loadValidWords() error {
-	file, err := os.Open(wl.validFilePath)
+	wl.fileMu.Lock()
+	defer wl.fileMu.Unlock()
+
+	validWords, validWordSet, err := wl.readWordFile(wl.validFilePath, "validation")
 	if err != nil {
-		return fmt.Errorf("failed to open validation word file %s: %w", wl.validFilePath, err)
+		return err
 	}
-	defer file.Close()
 
-	wl.validWords = wl.validWords[:0] // Clear existing words
-	wl.validWordSet = make(map[string]bool)
+	wl.mu.Lock()
+	wl.validWords, wl.validWordSet = validWords, validWordSet
+	wl.mu.Unlock()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		word := strings.TrimSpace(scanner.Text())
-		if word != "" {
-			wordLower := strings.ToLower(word)
-			wl.validWords = append(wl.validWords, wordLower)
-			wl.validWordSet[wordLower] = true
-		}
-	}
+	return nil
+}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading validation word file: %w", err)
+// loadTargetWords reads target words from the file
+func (wl *WordList) loadTargetWords() error {
+	wl.fileMu.Lock()
+	defer wl.fileMu.Unlock()
+
+	targetWords, targetWordSet, err := wl.readWordFile(wl.targetFilePath, "target")
+	if err != nil {
+		return err
 	}
+	if err := wl.checkRequiredLength(targetWords); err != nil {
+		return err
+	}
+
+	wl.mu.Lock()
+	wl.targetWords, wl.targetWordSet = targetWords, targetWordSet
+	wl.mu.Unlock()
 
 	return nil
 }
 
-// loadTargetWords reads target words from the file
-func (wl *WordList) loadTargetWords() error {
-	file, err := os.Open(wl.targetFilePath)
+// readWordFile reads the words of a validation or target word file (kind)
+// into a new list and set. Nothing is swapped in, so callers can replace the
+// current words in one step once the whole file has been read.
+func (wl *WordList) readWordFile(path, kind string) ([]string, map[string]bool, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		return fmt.Errorf("failed to open target word file %s: %w", wl.targetFilePath, err)
+		return nil, nil, fmt.Errorf("failed to open %s word file %s: %w", kind, path, err)
 	}
 	defer file.Close()
 
-	wl.targetWords = wl.targetWords[:0] // Clear existing words
-	wl.targetWordSet = make(map[string]bool)
+	words := []string{}
+	wordSet := make(map[string]bool)
 
-	scanner := bufio.NewScanner(file)
+	scanner := wl.newLineScanner(file)
+	lineNumber := 0
+	skipped := 0
 	for scanner.Scan() {
-		word := strings.TrimSpace(scanner.Text())
-		if word != "" {
-			wordLower := strings.ToLower(word)
-			wl.targetWords = append(wl.targetWords, wordLower)
-			wl.targetWordSet[wordLower] = true
+		lineNumber++
+		for _, word := range wl.lineWords(scanner.Text(), path, lineNumber) {
+			word = wl.normalize(word)
+			if word == "" {
+				continue
+			}
+			if wl.tooLong(word) {
+				skipped++
+				continue
+			}
+			words = append(words, word)
+			wordSet[word] = true
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading target word file: %w", err)
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, nil, wl.lineTooLongError(path, lineNumber+1)
+		}
+		return nil, nil, fmt.Errorf("error reading %s word file: %w", kind, err)
 	}
+	wl.logSkippedLong(skipped, path)
 
-	return nil
+	return words, wordSet, nil
+}
+
+// checkRequiredLength fails when a required target length is set and none of
+// the words have it, listing the lengths that were found
+func (wl *WordList) checkRequiredLength(words []string) error {
+	if wl.requireLength <= 0 {
+		return nil
+	}
+
+	counts := make(map[int]int)
+	for _, word := range words {
+		length := utf8.RuneCountInString(word)
+		if length == wl.requireLength {
+			return nil
+		}
+		counts[length]++
+	}
+
+	lengths := make([]int, 0, len(counts))
+	for length := range counts {
+		lengths = append(lengths, length)
+	}
+	sort.Ints(lengths)
+
+	found := make([]string, len(lengths))
+	for i, length := range lengths {
+		found[i] = fmt.Sprintf("%d-letter: %d", length, counts[length])
+	}
+	if len(found) == 0 {
+		found = append(found, "none")
+	}
+	return fmt.Errorf("target word file %s has no %d-letter words (lengths found: %s); check WORD_LENGTH or the target list",
+		wl.targetFilePath, wl.requireLength, strings.Join(found, ", "))
+}
+
+// lineWords returns the words on a line of a word file. A line with internal
+// whitespace ("crane slate") is split into its words or skipped with a warning,
+// depending on the splitLines option, rather than stored as one unguessable entry.
+func (wl *WordList) lineWords(line, path string, lineNumber int) []string {
+	words := strings.Fields(line)
+	if len(words) > 1 && !wl.splitLines {
+		log.Printf("Warning: skipping %s line %d: %q contains multiple words", path, lineNumber, line)
+		return nil
+	}
+	return words
+}
+
+// tooLong reports whether word exceeds the configured maximum word length
+func (wl *WordList) tooLong(word string) bool {
+	return wl.maxWordLength > 0 && utf8.RuneCountInString(word) > wl.maxWordLength
+}
+
+// logSkippedLong logs how many over-length words were dropped from path
+func (wl *WordList) logSkippedLong(skipped int, path string) {
+	if skipped > 0 {
+		log.Printf("Skipped %d words longer than %d letters in %s", skipped, wl.maxWordLength, path)
+	}
 }
 
 // Size returns the total number of validation words in the list
 func (wl *WordList) Size() int {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	return len(wl.validWords)
 }
 
 // TargetWordsSize returns the total number of target words in the list
 func (wl *WordList) TargetWordsSize() int {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	return len(wl.targetWords)
 }
 
-// Contains checks if a word is in the validation list (case-insensitive)
+// Contains checks if a word is in the validation list (case-insensitive
+// unless the list is case-sensitive)
 func (wl *WordList) Contains(word string) bool {
-	return wl.validWordSet[strings.ToLower(word)]
+	word = wl.normalize(word)
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
+	return wl.validWordSet[word]
+}
+
+// normalize returns the form in which word is stored and looked up, folding
+// case with the list's locale unless the list is case-sensitive
+func (wl *WordList) normalize(word string) string {
+	return NormalizeWord(word, NormalizeOptions{CaseSensitive: wl.caseSensitive, Lower: wl.lower})
+}
+
+// SetSeed gives the list its own random source with the given seed, so its
+// RandomWord and RandomValidWord sequences are reproducible regardless of
+// other draws. It is safe to call while other goroutines draw words.
+func (wl *WordList) SetSeed(seed int64) {
+	source := newLockedRand(seed)
+	wl.mu.Lock()
+	wl.rng = source
+	wl.mu.Unlock()
+}
+
+// random returns the list's own random source if it has one, and the shared
+// source (seeded once at startup) otherwise. Callers hold mu.
+func (wl *WordList) random() *rand.Rand {
+	if wl.rng != nil {
+		return wl.rng
+	}
+	return rng
 }
 
 // RandomWord returns a random word from the target words list (for game targets)
 func (wl *WordList) RandomWord() string {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	if len(wl.targetWords) == 0 {
 		return ""
 	}
-	rand.Seed(time.Now().UnixNano())
-	return wl.targetWords[rand.Intn(len(wl.targetWords))]
+	return wl.targetWords[wl.random().Intn(len(wl.targetWords))]
 }
 
 // RandomValidWord returns a random word from the validation list
 func (wl *WordList) RandomValidWord() string {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	if len(wl.validWords) == 0 {
 		return ""
 	}
-	rand.Seed(time.Now().UnixNano())
-	return wl.validWords[rand.Intn(len(wl.validWords))]
+	return wl.validWords[wl.random().Intn(len(wl.validWords))]
 }
 
 // WordsOfLength returns all validation words of the specified length
 func (wl *WordList) WordsOfLength(length int) []string {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	var result []string
 	for _, word := range wl.validWords {
-		if len(word) == length {
+		if utf8.RuneCountInString(word) == length {
 			result = append(result, word)
 		}
 	}
@@ -181,9 +422,11 @@ func (wl *WordList) WordsOfLength(length int) []string {
 
 // TargetWordsOfLength returns all target words of the specified length
 func (wl *WordList) TargetWordsOfLength(length int) []string {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	var result []string
 	for _, word := range wl.targetWords {
-		if len(word) == length {
+		if utf8.RuneCountInString(word) == length {
 			result = append(result, word)
 		}
 	}
@@ -200,6 +443,20 @@ func (wl *WordList) FiveLetterTargetWords() []string {
 	return wl.TargetWordsOfLength(5)
 }
 
+// DailyWord returns the five-letter target word for date's UTC day. Every
+// time during the same day maps to the same word, so all players share it;
+// the choice only shifts if the target list changes. It returns "" when there
+// are no five-letter target words.
+func (wl *WordList) DailyWord(date time.Time) string {
+	words := wl.FiveLetterTargetWords()
+	if len(words) == 0 {
+		return ""
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(dateKey(date)))
+	return words[hash.Sum64()%uint64(len(words))]
+}
+
 // Reload reloads the word list from the file
 func (wl *WordList) Reload() error {
 	return wl.loadWords()
@@ -207,6 +464,8 @@ func (wl *WordList) Reload() error {
 
 // ToSlice returns a copy of the validation words as a slice
 func (wl *WordList) ToSlice() []string {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	result := make([]string, len(wl.validWords))
 	copy(result, wl.validWords)
 	return result
@@ -214,6 +473,8 @@ func (wl *WordList) ToSlice() []string {
 
 // TargetWordsToSlice returns a copy of the target words as a slice
 func (wl *WordList) TargetWordsToSlice() []string {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	result := make([]string, len(wl.targetWords))
 	copy(result, wl.targetWords)
 	return result
@@ -221,6 +482,8 @@ func (wl *WordList) TargetWordsToSlice() []string {
 
 // ToSet returns the validation words as a map (set-like structure)
 func (wl *WordList) ToSet() map[string]bool {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	result := make(map[string]bool)
 	for word := range wl.validWordSet {
 		result[word] = true
@@ -230,9 +493,110 @@ func (wl *WordList) ToSet() map[string]bool {
 
 // TargetWordsToSet returns the target words as a map (set-like structure)
 func (wl *WordList) TargetWordsToSet() map[string]bool {
+	wl.mu.RLock()
+	defer wl.mu.RUnlock()
 	result := make(map[string]bool)
 	for word := range wl.targetWordSet {
 		result[word] = true
 	}
 	return result
 }
+
+// ImportTargetWords streams newline-delimited words from r into the target
+// list. Each word must have wordLength letters and be in the validation list
+// so it can be guessed; duplicates are skipped. With persist, added words are
+// also appended to the target word file so they survive a reload. It is safe
+// to call while other goroutines pick target words. A persisted import holds
+// fileMu from its first merge until its words are on disk, so a concurrent
+// reload can't drop them from memory or read a half-written append.
+func (wl *WordList) ImportTargetWords(r io.Reader, wordLength int, persist bool) (*ImportStats, error) {
+	if persist {
+		wl.fileMu.Lock()
+		defer wl.fileMu.Unlock()
+	}
+
+	stats := &ImportStats{}
+	var added []string
+
+	scanner := wl.newLineScanner(r)
+	lineNumber := 0
+	batch := make([]string, 0, importBatchSize)
+	for scanner.Scan() {
+		lineNumber++
+		word := wl.normalize(scanner.Text())
+		if word == "" {
+			continue
+		}
+		if utf8.RuneCountInString(word) != wordLength || !onlyLetters(word) || !wl.Contains(word) {
+			stats.Invalid++
+			continue
+		}
+		batch = append(batch, word)
+		if len(batch) == importBatchSize {
+			added = append(added, wl.mergeTargetWords(batch, stats)...)
+			batch = batch[:0]
+		}
+	}
+	added = append(added, wl.mergeTargetWords(batch, stats)...)
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return stats, wl.lineTooLongError("imported words", lineNumber+1)
+		}
+		return stats, fmt.Errorf("error reading imported words: %w", err)
+	}
+
+	if persist && len(added) > 0 {
+		if err := wl.appendTargetWords(added); err != nil {
+			return stats, err
+		}
+		stats.Persisted = true
+	}
+
+	return stats, nil
+}
+
+// mergeTargetWords adds the words that are not already targets, counting
+// duplicates in stats, and returns the words it added
+func (wl *WordList) mergeTargetWords(words []string, stats *ImportStats) []string {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	var added []string
+	for _, word := range words {
+		if wl.targetWordSet[word] {
+			stats.Duplicates++
+			continue
+		}
+		wl.targetWords = append(wl.targetWords, word)
+		wl.targetWordSet[word] = true
+		added = append(added, word)
+		stats.Added++
+	}
+	return added
+}
+
+// appendTargetWords appends words to the target word file, one per line.
+// Callers hold fileMu, not mu, so readers aren't held up by the disk.
+func (wl *WordList) appendTargetWords(words []string) error {
+	file, err := os.OpenFile(wl.targetFilePath, os.O_APPEND|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open target word file %s: %w", wl.targetFilePath, err)
+	}
+	defer file.Close()
+
+	// Start on a new line if the file doesn't end with one
+	content := strings.Join(words, "\n") + "\n"
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			content = "\n" + content
+		}
+	}
+
+	if _, err := file.WriteString(content); err != nil {
+		return fmt.Errorf("failed to persist target words: %w", err)
+	}
+	return nil
+}
+
@@ -87,6 +87,13 @@ func TestWordListRandomWordDistribution(t *testing.T) {
 		t.Fatalf("Failed to create WordList: %v", err)
 	}
 
+	// Draw targets from the same words, reproducibly
+	wordList.targetFilePath = testFile
+	if err := wordList.loadTargetWords(); err != nil {
+		t.Fatalf("Failed to load target words: %v", err)
+	}
+	wordList.SetSeed(1)
+
 	// Test that RandomWord returns valid words
 	seenWords := make(map[string]bool)
 	for i := 0; i < 20; i++ {
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewWordList(t *testing.T) {
@@ -52,6 +59,44 @@ func TestWordListRandomWord(t *testing.T) {
 	}
 }
 
+func TestWordListDailyWord(t *testing.T) {
+	wordList, err := NewWordList("")
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+
+	// Any time during a UTC day gives the day's word, in any time zone
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	word := wordList.DailyWord(start)
+	if len(word) != 5 || !wordList.Contains(word) {
+		t.Fatalf("Expected a five-letter word from the list, got %q", word)
+	}
+	sameDay := []time.Time{
+		start.Add(12 * time.Hour),
+		start.Add(24*time.Hour - time.Nanosecond),
+		time.Date(2024, 2, 29, 20, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60)),
+	}
+	for _, moment := range sameDay {
+		if got := wordList.DailyWord(moment); got != word {
+			t.Errorf("Expected %q at %v, got %q", word, moment, got)
+		}
+	}
+
+	// The word changes from day to day
+	words := make(map[string]bool)
+	for day := 0; day < 30; day++ {
+		words[wordList.DailyWord(start.AddDate(0, 0, day))] = true
+	}
+	if len(words) < 2 {
+		t.Errorf("Expected different words across 30 days, got %v", words)
+	}
+
+	empty := &WordList{}
+	if got := empty.DailyWord(start); got != "" {
+		t.Errorf("Expected no daily word without target words, got %q", got)
+	}
+}
+
 func TestWordListWordsOfLength(t *testing.T) {
 	wordList, err := NewWordList("")
 	if err != nil {
@@ -211,3 +256,417 @@ func TestWordListNormalization(t *testing.T) {
 		t.Error("Should find 'banana'")
 	}
 }
+
+func TestWordListConcurrentReload(t *testing.T) {
+	wordList := newImportTestWordList(t)
+
+	// Run with -race: readers must never see a reload or reseed half done
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if !wordList.Contains("crane") {
+					t.Error("Expected crane to stay valid during reloads")
+					return
+				}
+				wordList.RandomWord()
+				wordList.RandomValidWord()
+				wordList.WordsOfLength(5)
+				wordList.Size()
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		if err := wordList.Reload(); err != nil {
+			t.Errorf("Reload should not return error: %v", err)
+			break
+		}
+		wordList.SetSeed(int64(i))
+	}
+	close(stop)
+	wg.Wait()
+
+	if wordList.Size() != 5 || wordList.TargetWordsSize() != 1 {
+		t.Errorf("Expected 5 valid and 1 target word after reloading, got %d and %d", wordList.Size(), wordList.TargetWordsSize())
+	}
+}
+
+func TestWordListMultiWordLines(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "multi-word.txt")
+
+	content := "apple\ncrane slate\n  banana  \n"
+	err := os.WriteFile(testFile, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// By default the malformed line is skipped with a warning
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	wordList, err := NewWordList(testFile)
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+
+	if wordList.Size() != 2 {
+		t.Errorf("Expected 2 words with the multi-word line skipped, got %d", wordList.Size())
+	}
+	if wordList.Contains("crane slate") || wordList.Contains("crane") {
+		t.Error("Multi-word line should not be loaded when skipping")
+	}
+	if !strings.Contains(logs.String(), "line 2") || !strings.Contains(logs.String(), "crane slate") {
+		t.Errorf("Expected a warning naming the skipped line, got: %q", logs.String())
+	}
+
+	// With splitting enabled each word becomes its own entry
+	wordList, err = NewWordListWithOptions(testFile, WordListOptions{SplitMultiWordLines: true})
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+
+	if wordList.Size() != 4 {
+		t.Errorf("Expected 4 words with the multi-word line split, got %d", wordList.Size())
+	}
+	for _, word := range []string{"apple", "crane", "slate", "banana"} {
+		if !wordList.Contains(word) {
+			t.Errorf("Expected '%s' to be loaded", word)
+		}
+	}
+}
+
+func TestWordListMaxWordLength(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "mixed-lengths.txt")
+
+	content := "apple\nsupercalifragilisticexpialidocious\ncrane\nextraordinarily\nbanana\n"
+	err := os.WriteFile(testFile, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// By default words of every length are kept
+	wordList, err := NewWordList(testFile)
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+	if wordList.Size() != 5 {
+		t.Errorf("Expected all 5 words without a limit, got %d", wordList.Size())
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	wordList, err = NewWordListWithOptions(testFile, WordListOptions{MaxWordLength: 6})
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+
+	if wordList.Size() != 3 {
+		t.Errorf("Expected 3 words within the limit, got %d", wordList.Size())
+	}
+	for _, word := range []string{"apple", "crane", "banana"} {
+		if !wordList.Contains(word) {
+			t.Errorf("Expected '%s' to be loaded", word)
+		}
+	}
+	if wordList.Contains("supercalifragilisticexpialidocious") || wordList.Contains("extraordinarily") {
+		t.Error("Over-length words should be skipped")
+	}
+	if len(wordList.WordsOfLength(34)) != 0 {
+		t.Error("No words should be bucketed at the skipped length")
+	}
+	if !strings.Contains(logs.String(), "Skipped 2 words longer than 6 letters") {
+		t.Errorf("Expected a skipped-word count in the log, got: %q", logs.String())
+	}
+}
+
+func TestWordListLineTooLong(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "long-line.txt")
+
+	// The third line is a run of letters with no newline, past the default limit
+	content := "apple\ncrane\n" + strings.Repeat("a", defaultMaxLineBytes+1)
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	_, err := NewWordList(testFile)
+	if !errors.Is(err, bufio.ErrTooLong) {
+		t.Fatalf("Expected a line too long error, got %v", err)
+	}
+	expected := fmt.Sprintf("%s line 3 is longer than %d bytes", testFile, defaultMaxLineBytes)
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error to contain %q, got %q", expected, err.Error())
+	}
+
+	// A higher limit reads the line
+	if _, err := NewWordListWithOptions(testFile, WordListOptions{MaxLineBytes: 2 * defaultMaxLineBytes}); err != nil {
+		t.Errorf("Expected the line to load with a higher limit, got %v", err)
+	}
+
+	// Imports report the line too
+	wordList := newImportTestWordList(t)
+	wordList.maxLineBytes = 64
+	_, err = wordList.ImportTargetWords(strings.NewReader("slate\n"+strings.Repeat("b", 100)+"\n"), 5, false)
+	if err == nil || !strings.Contains(err.Error(), "imported words line 2 is longer than 64 bytes") {
+		t.Errorf("Expected the imported line to be identified, got %v", err)
+	}
+}
+
+func TestWordListCaseSensitive(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "proper-nouns.txt")
+
+	content := "Crane\ncrane\nParis\n"
+	err := os.WriteFile(testFile, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// By default case is folded, so the two spellings are the same word
+	wordList, err := NewWordList(testFile)
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+	if !wordList.Contains("PARIS") || !wordList.Contains("paris") {
+		t.Error("Default word list should match case-insensitively")
+	}
+
+	wordList, err = NewWordListWithOptions(testFile, WordListOptions{CaseSensitive: true})
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+
+	if wordList.Size() != 3 || len(wordList.validWordSet) != 3 {
+		t.Errorf("Expected Crane and crane as distinct entries, got %v", wordList.validWords)
+	}
+	for _, word := range []string{"Crane", "crane", "Paris"} {
+		if !wordList.Contains(word) {
+			t.Errorf("Expected %q to be loaded as written", word)
+		}
+	}
+	for _, word := range []string{"CRANE", "paris", "PARIS"} {
+		if wordList.Contains(word) {
+			t.Errorf("Expected %q not to match in a case-sensitive list", word)
+		}
+	}
+}
+
+func TestWordListLocale(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "turkish.txt")
+	if err := os.WriteFile(testFile, []byte("k\u0131z\u0131l\ni\u015fler\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// Turkish guesses are uppercased with Turkish rules (ı to I, i to İ), so
+	// the list must fold them back the same way to find them
+	wordList, err := NewWordListWithOptions(testFile, WordListOptions{Locale: "tr"})
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+	for _, word := range []string{"KIZIL", "\u0130\u015eLER", "k\u0131z\u0131l"} {
+		if !wordList.Contains(word) {
+			t.Errorf("Expected %q to match with the Turkish locale", word)
+		}
+	}
+	if wordList.Contains("kizil") {
+		t.Error("Dotted i must not match dotless \u0131 with the Turkish locale")
+	}
+
+	wordList, err = NewWordListWithOptions(testFile, WordListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+	if wordList.Contains("KIZIL") {
+		t.Error("Expected the default mapping to fold I to dotted i")
+	}
+
+	// Lengths count letters, not bytes
+	if got := wordList.WordsOfLength(5); len(got) != 2 {
+		t.Errorf("Expected both five-letter words, got %v", got)
+	}
+
+	if _, err := NewWordListWithOptions(testFile, WordListOptions{Locale: "not a locale!"}); err == nil {
+		t.Error("Expected an invalid locale to fail loading")
+	}
+}
+
+func TestWordListRequireTargetLength(t *testing.T) {
+	tempDir := t.TempDir()
+	validFile := filepath.Join(tempDir, "valid.txt")
+	if err := os.WriteFile(validFile, []byte("crane\nbanana\n"), 0644); err != nil {
+		t.Fatalf("Failed to create validation file: %v", err)
+	}
+
+	// The bundled target list has five-letter words
+	wordList, err := NewWordListWithOptions(validFile, WordListOptions{RequireTargetLength: 5})
+	if err != nil {
+		t.Fatalf("Expected target list with 5-letter words to load, got %v", err)
+	}
+
+	_, err = NewWordListWithOptions(validFile, WordListOptions{RequireTargetLength: 6})
+	if err == nil {
+		t.Fatal("Expected startup to fail when no target word has the required length")
+	}
+	if !strings.Contains(err.Error(), "no 6-letter words") || !strings.Contains(err.Error(), "5-letter: ") {
+		t.Errorf("Expected error to name the missing and found lengths, got %v", err)
+	}
+
+	// A target file with only six-letter words fails a five-letter game and
+	// leaves the loaded targets in place
+	before := wordList.TargetWordsSize()
+	sixLetterFile := filepath.Join(tempDir, "targets.txt")
+	if err := os.WriteFile(sixLetterFile, []byte("banana\nplanet\n"), 0644); err != nil {
+		t.Fatalf("Failed to create target file: %v", err)
+	}
+	wordList.targetFilePath = sixLetterFile
+	if err := wordList.Reload(); err == nil || !strings.Contains(err.Error(), "no 5-letter words") {
+		t.Errorf("Expected reload of six-letter targets to fail, got %v", err)
+	}
+	if wordList.TargetWordsSize() != before {
+		t.Errorf("Expected target words to be unchanged after a failed load, got %d want %d", wordList.TargetWordsSize(), before)
+	}
+
+	// Without the requirement the same file loads
+	wordList.requireLength = 0
+	if err := wordList.Reload(); err != nil {
+		t.Fatalf("Expected reload without a required length to succeed, got %v", err)
+	}
+	if wordList.TargetWordsSize() != 2 {
+		t.Errorf("Expected 2 target words, got %d", wordList.TargetWordsSize())
+	}
+}
+
+// newImportTestWordList returns a word list whose validation and target
+// files are temporary, so imports can be persisted without touching the
+// real word files
+func newImportTestWordList(t *testing.T) *WordList {
+	tempDir := t.TempDir()
+	validFile := filepath.Join(tempDir, "valid.txt")
+	targetFile := filepath.Join(tempDir, "targets.txt")
+	if err := os.WriteFile(validFile, []byte("crane\nslate\nfloat\nbanana\nworld\n"), 0644); err != nil {
+		t.Fatalf("Failed to create validation file: %v", err)
+	}
+	if err := os.WriteFile(targetFile, []byte("crane"), 0644); err != nil {
+		t.Fatalf("Failed to create target file: %v", err)
+	}
+
+	wordList, err := NewWordList(validFile)
+	if err != nil {
+		t.Fatalf("Failed to create WordList: %v", err)
+	}
+	wordList.targetFilePath = targetFile
+	if err := wordList.loadTargetWords(); err != nil {
+		t.Fatalf("Failed to load target words: %v", err)
+	}
+	return wordList
+}
+
+func TestWordListImportTargetWords(t *testing.T) {
+	wordList := newImportTestWordList(t)
+
+	// Readers may pick targets while the import runs
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			wordList.RandomWord()
+			wordList.TargetWordsSize()
+		}
+	}()
+
+	body := "slate\nSLATE\ncrane\nab\nbanana\nzzzzz\n\nslate\nfl0at\nfloat\n"
+	stats, err := wordList.ImportTargetWords(strings.NewReader(body), 5, false)
+	<-done
+	if err != nil {
+		t.Fatalf("ImportTargetWords should not return error: %v", err)
+	}
+
+	// slate and float are new; SLATE, crane and the second slate are duplicates;
+	// ab and banana have the wrong length, zzzzz is not a word and fl0at has a digit
+	if stats.Added != 2 || stats.Duplicates != 3 || stats.Invalid != 4 || stats.Skipped() != 7 {
+		t.Errorf("Unexpected import stats: %+v", stats)
+	}
+	if stats.Persisted {
+		t.Error("Import should not be persisted unless requested")
+	}
+
+	targets := wordList.TargetWordsToSet()
+	for _, word := range []string{"crane", "slate", "float"} {
+		if !targets[word] {
+			t.Errorf("Expected %s to be an eligible target", word)
+		}
+	}
+	if wordList.TargetWordsSize() != 3 {
+		t.Errorf("Expected 3 target words, got %d", wordList.TargetWordsSize())
+	}
+}
+
+func TestWordListImportTargetWordsPersist(t *testing.T) {
+	wordList := newImportTestWordList(t)
+
+	stats, err := wordList.ImportTargetWords(strings.NewReader("slate\nworld\ncrane\n"), 5, true)
+	if err != nil {
+		t.Fatalf("ImportTargetWords should not return error: %v", err)
+	}
+	if stats.Added != 2 || !stats.Persisted {
+		t.Errorf("Unexpected import stats: %+v", stats)
+	}
+
+	// Persisted words survive a reload
+	if err := wordList.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if got := wordList.TargetWordsToSlice(); strings.Join(got, ",") != "crane,slate,world" {
+		t.Errorf("Expected persisted targets after reload, got %v", got)
+	}
+}
+
+func TestWordListImportTargetWordsPersistDuringReloads(t *testing.T) {
+	wordList := newImportTestWordList(t)
+
+	// Run with -race: a reload between an import's merge and its append must
+	// not drop the imported words from memory
+	var wg sync.WaitGroup
+	for _, word := range []string{"slate", "world", "float"} {
+		wg.Add(1)
+		go func(word string) {
+			defer wg.Done()
+			if _, err := wordList.ImportTargetWords(strings.NewReader(word+"\n"), 5, true); err != nil {
+				t.Errorf("ImportTargetWords should not return error: %v", err)
+			}
+		}(word)
+	}
+	for i := 0; i < 20; i++ {
+		if err := wordList.Reload(); err != nil {
+			t.Errorf("Reload should not return error: %v", err)
+			break
+		}
+	}
+	wg.Wait()
+
+	targets := wordList.TargetWordsToSet()
+	for _, word := range []string{"crane", "slate", "world", "float"} {
+		if !targets[word] {
+			t.Errorf("Expected %s to stay a target, got %v", word, wordList.TargetWordsToSlice())
+		}
+	}
+	if err := wordList.Reload(); err != nil || wordList.TargetWordsSize() != 4 {
+		t.Errorf("Expected 4 persisted targets after a reload, got %v (err %v)", wordList.TargetWordsToSlice(), err)
+	}
+}
+